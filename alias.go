@@ -0,0 +1,39 @@
+package tago
+
+import "strings"
+
+// Alias registers alias as another name for the instruction key canonical: during parsing,
+// instructions using the alias are normalized to the canonical key, so handlers only have to match the canonical one.
+// Useful when migrating from one tag vocabulary to another.
+//
+// Example:
+//
+//	type MyModel struct {
+//		Address Address `gorm2:"eager=true"`
+//	}
+//	t := &TaGo{Name: "gorm2"}
+//	t.Alias("eager", "preload")
+//	tags := t.Get(&MyModel{}) // map[preload=true:[Address]]
+func (t *TaGo) Alias(alias string, canonical string) *TaGo {
+	t.configChanged()
+	if t.aliases == nil {
+		t.aliases = make(map[string]string)
+	}
+	t.aliases[alias] = canonical
+	return t
+}
+
+// Return the canonical key of an instruction key (the key itself if it isn't an alias)
+func (t TaGo) canonicalKey(key string) string {
+	if canonical, exists := t.aliases[key]; exists {
+		return canonical
+	}
+	if t.caseInsensitive {
+		for alias, canonical := range t.aliases {
+			if strings.EqualFold(alias, key) {
+				return canonical
+			}
+		}
+	}
+	return key
+}
@@ -0,0 +1,145 @@
+package tago
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Attribute is a span attribute, its value converted to a type OpenTelemetry attributes hold, see SpanAttributes
+type Attribute struct {
+	Key string
+
+	// bool, int64, float64, string, or a slice of them
+	Value any
+}
+
+// SpanAttributes returns the span attributes of a populated model, from the fields tagged otel=attr.name, nested fields
+// included, in declaration order. Fields tagged sensitive=true are skipped along with their subtree, as are nil
+// pointers. Numbers are converted to int64 or float64 (unsigned integers beyond the int64 range to strings, so they
+// don't wrap around), slices of scalars to slices of them, and other values (times,
+// registered types, ..) are formatted to strings with FormatValue; values which can't be formatted are reported as
+// warnings (see OnWarning) and skipped.
+// The attributes don't depend on the OpenTelemetry SDK, converting them takes a switch on the value type.
+//
+// Example:
+//
+//	type Order struct {
+//		ID     string  `api:"otel=order.id"`
+//		Amount float64 `api:"otel=order.amount"`
+//		Card   string  `api:"otel=order.card;sensitive=true"`
+//	}
+//	for _, attr := range t.SpanAttributes(&order) {
+//		switch value := attr.Value.(type) {
+//		case string:
+//			span.SetAttributes(attribute.String(attr.Key, value))
+//		case float64:
+//			span.SetAttributes(attribute.Float64(attr.Key, value))
+//		// ..
+//		}
+//	}
+func (t TaGo) SpanAttributes(model interface{}, opts ...Option) []Attribute {
+	attributes := make([]Attribute, 0)
+
+	o := newOptions(".", -1, opts)
+	o.skip = func(path FieldName, field reflect.StructField, owner reflect.Type, instructions []Instruction) string {
+		if sensitive, _ := lookupKey(instructions, "sensitive"); sensitive == "true" {
+			return "sensitive field"
+		}
+		return ""
+	}
+	t.walkContexts(model, o, func(ctx *FieldContext) {
+		key, exists := lookupKey(ctx.Instructions, "otel")
+		if !exists || !ctx.Value.IsValid() || !ctx.Value.CanInterface() {
+			return
+		}
+		value, err := t.attributeValue(ctx.Value)
+		if err != nil {
+			t.warn(Warning{Type: ctx.Owner, Field: ctx.Path, Instruction: Instruction("otel=" + key), Message: err.Error()})
+			return
+		}
+		if value == nil {
+			return
+		}
+		attributes = append(attributes, Attribute{Key: key, Value: value})
+	})
+	return attributes
+}
+
+// Convert a value to a type of attribute value, nil for a nil pointer
+func (t TaGo) attributeValue(value reflect.Value) (any, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	if _, exists := t.formatters[value.Type()]; !exists {
+		switch value.Kind() {
+		case reflect.Bool:
+			return value.Bool(), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return value.Int(), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if value.Uint() > math.MaxInt64 {
+				return strconv.FormatUint(value.Uint(), 10), nil
+			}
+			return int64(value.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			return value.Float(), nil
+		case reflect.String:
+			return value.String(), nil
+		case reflect.Slice, reflect.Array:
+			if slice, converted := attributeSlice(value); converted {
+				return slice, nil
+			}
+		}
+	}
+	return t.FormatValue(value, "")
+}
+
+// Convert a slice of bools, numbers or strings to the slice type of attribute values ([]byte is formatted as a string).
+// Unsigned integers are converted to []int64, or to []string if one of them is beyond the int64 range.
+func attributeSlice(value reflect.Value) (any, bool) {
+	n := value.Len()
+	switch value.Type().Elem().Kind() {
+	case reflect.Bool:
+		slice := make([]bool, n)
+		for i := range slice {
+			slice[i] = value.Index(i).Bool()
+		}
+		return slice, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		slice := make([]int64, n)
+		for i := range slice {
+			slice[i] = value.Index(i).Int()
+		}
+		return slice, true
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		slice := make([]int64, n)
+		for i := range slice {
+			if value.Index(i).Uint() > math.MaxInt64 {
+				formatted := make([]string, n)
+				for j := range formatted {
+					formatted[j] = strconv.FormatUint(value.Index(j).Uint(), 10)
+				}
+				return formatted, true
+			}
+			slice[i] = int64(value.Index(i).Uint())
+		}
+		return slice, true
+	case reflect.Float32, reflect.Float64:
+		slice := make([]float64, n)
+		for i := range slice {
+			slice[i] = value.Index(i).Float()
+		}
+		return slice, true
+	case reflect.String:
+		slice := make([]string, n)
+		for i := range slice {
+			slice[i] = value.Index(i).String()
+		}
+		return slice, true
+	}
+	return nil, false
+}
@@ -0,0 +1,50 @@
+package tago
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type attributesOrder struct {
+	ID      string   `api:"otel=order.id"`
+	Amount  float64  `api:"otel=order.amount"`
+	Count   uint32   `api:"otel=order.count"`
+	Big     uint64   `api:"otel=order.big"`
+	Sizes   []uint   `api:"otel=order.sizes"`
+	Hashes  []uint64 `api:"otel=order.hashes"`
+	Flags   []bool   `api:"otel=order.flags"`
+	Card    string   `api:"otel=order.card;sensitive=true"`
+	Coupon  *string  `api:"otel=order.coupon"`
+	Payload []byte   `api:"otel=order.payload"`
+}
+
+func TestSpanAttributes(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	order := attributesOrder{
+		ID:      "o1",
+		Amount:  9.5,
+		Count:   3,
+		Big:     math.MaxUint64,
+		Sizes:   []uint{1, 2},
+		Hashes:  []uint64{1, math.MaxInt64 + 1},
+		Flags:   []bool{true},
+		Card:    "4242",
+		Payload: []byte("raw"),
+	}
+	want := []Attribute{
+		{"order.id", "o1"},
+		{"order.amount", 9.5},
+		{"order.count", int64(3)},
+		// Unsigned integers beyond int64 don't wrap around
+		{"order.big", "18446744073709551615"},
+		{"order.sizes", []int64{1, 2}},
+		{"order.hashes", []string{"1", "9223372036854775808"}},
+		{"order.flags", []bool{true}},
+		{"order.payload", "raw"},
+	}
+	if got := tg.SpanAttributes(&order); !reflect.DeepEqual(got, want) {
+		t.Errorf("SpanAttributes =\n%#v\nwant\n%#v", got, want)
+	}
+}
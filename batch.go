@@ -0,0 +1,51 @@
+package tago
+
+import (
+	"reflect"
+	"sort"
+)
+
+// GetAll parses several models in one call, like GetNested with the "." separator (WithSeparator to change it),
+// and returns their instructions by model type (pointers, slices and arrays removed). With the cache enabled
+// (see EnableCache), it warms it at startup. Models which aren't structs are reported as warnings and skipped.
+//
+// Example:
+//
+//	models := t.GetAll(&User{}, &Address{}, &Order{})
+//	for _, typ := range SortedTypes(models) {
+//		fmt.Println(typ, models[typ])
+//	}
+func (t TaGo) GetAll(models ...any) map[reflect.Type]Instructions {
+	return t.GetAllWith(models)
+}
+
+// GetAllWith is GetAll with options
+func (t TaGo) GetAllWith(models []any, opts ...Option) map[reflect.Type]Instructions {
+	result := make(map[reflect.Type]Instructions, len(models))
+	for _, model := range models {
+		tags, err := t.parseModel(model, newOptions(".", -1, opts))
+		if err != nil {
+			t.warn(Warning{Type: reflect.TypeOf(model), Message: err.Error()})
+			if model == nil || typeToElem(reflect.TypeOf(model)).Kind() != reflect.Struct {
+				continue
+			}
+		}
+		result[typeToElem(reflect.TypeOf(model))] = tags
+	}
+	return result
+}
+
+// SortedTypes returns the types of a GetAll result sorted by name (package path included), for a deterministic iteration
+func SortedTypes(models map[reflect.Type]Instructions) []reflect.Type {
+	types := make([]reflect.Type, 0, len(models))
+	for typ := range models {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].PkgPath() != types[j].PkgPath() {
+			return types[i].PkgPath() < types[j].PkgPath()
+		}
+		return types[i].String() < types[j].String()
+	})
+	return types
+}
@@ -0,0 +1,35 @@
+package tago_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KooQix/tago/internal/bench"
+)
+
+// Run the benchmarks of the suite named <function>/<model> as sub-benchmarks, e.g. GetNested/Wide
+func runSuite(b *testing.B, function string) {
+	for _, benchmark := range bench.Suite() {
+		if model, found := strings.CutPrefix(benchmark.Name, function+"/"); found {
+			b.Run(model, benchmark.Run)
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B)       { runSuite(b, "Get") }
+func BenchmarkGetNested(b *testing.B) { runSuite(b, "GetNested") }
+func BenchmarkHas(b *testing.B)       { runSuite(b, "Has") }
+func BenchmarkHasKey(b *testing.B)    { runSuite(b, "HasKey") }
+
+func TestAllocationBudgets(t *testing.T) {
+	for _, benchmark := range bench.Suite() {
+		t.Run(benchmark.Name, func(t *testing.T) {
+			if benchmark.MaxAllocs < 0 {
+				t.Skip("no budget")
+			}
+			if allocs := testing.AllocsPerRun(20, benchmark.Op); allocs > float64(benchmark.MaxAllocs) {
+				t.Errorf("%.0f allocations per operation, over the budget of %d", allocs, benchmark.MaxAllocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package tago
+
+// InstructionsBuilder builds Instructions programmatically, e.g. in tests or when tags are decided at runtime
+//
+// Example:
+//
+//	tags := tago.NewInstructions().
+//		Field("User.Name").Set("preload", "true").Set("index", "idx_name").
+//		Field("User.Email").Set("unique", "").
+//		Build()
+//	// map[index=idx_name:[User.Name] preload=true:[User.Name] unique:[User.Email]]
+type InstructionsBuilder struct {
+	instructions Instructions
+	field        FieldName
+}
+
+// NewInstructions returns an empty InstructionsBuilder
+func NewInstructions() *InstructionsBuilder {
+	return &InstructionsBuilder{instructions: make(Instructions)}
+}
+
+// NewInstruction returns the instruction key=value, or key alone if value is empty
+func NewInstruction(key string, value string) Instruction {
+	if value == "" {
+		return Instruction(key)
+	}
+	return Instruction(key + "=" + value)
+}
+
+// Field selects the field the next instructions are set on
+func (b *InstructionsBuilder) Field(field FieldName) *InstructionsBuilder {
+	b.field = field
+	return b
+}
+
+// Set adds the instruction key=value (or key alone if value is empty) to the current field
+// Setting the same instruction twice on a field has no effect.
+func (b *InstructionsBuilder) Set(key string, value string) *InstructionsBuilder {
+	instruction := NewInstruction(key, value)
+	for _, field := range b.instructions[instruction] {
+		if field == b.field {
+			return b
+		}
+	}
+
+	b.instructions[instruction] = append(b.instructions[instruction], b.field)
+	return b
+}
+
+// Build returns the built instructions
+// The builder can still be used afterwards, without affecting the returned instructions.
+func (b *InstructionsBuilder) Build() Instructions {
+	instructions := make(Instructions, len(b.instructions))
+	instructions.concat(b.instructions, "")
+	return instructions
+}
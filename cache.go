@@ -0,0 +1,237 @@
+package tago
+
+import (
+	"container/list"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CacheStats reports the activity of the parse cache (see EnableCache)
+type CacheStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+
+	// Number of cached results, and the maximum (0: unbounded)
+	Entries    int
+	MaxEntries int
+}
+
+// EnableCache caches the result of Get, GetNested and Compile by model type and options, so hot paths don't walk
+// the same types again. maxEntries bounds the memory used: the least recently used results are evicted beyond it
+// (0 or less: unbounded). Copies of the TaGo made after this call share the cache.
+//
+// Calls with a tracer, a logger, exclude functions, field filters or WithCache(false) aren't cached. The warnings of the parse
+// that filled the cache are reported again on every hit. Results are cached by tag name and configuration: setters changing
+// how tags are parsed (Alias, Variables, SetParser, RegisterExternal, LoadOverlay, ..) drop the cached results.
+//
+// Example:
+//
+//	t := &TaGo{Name: "gorm2"}
+//	t.EnableCache(1000)
+//	tags := t.GetNested(&MyModel{}, ".") // parsed
+//	tags = t.GetNested(&MyModel{}, ".")  // cached
+func (t *TaGo) EnableCache(maxEntries int) *TaGo {
+	if maxEntries < 0 {
+		maxEntries = 0
+	}
+	t.cache = &parseCache{
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]*list.Element),
+		lru:        list.New(),
+	}
+	return t
+}
+
+// WithCache(false) bypasses the parse cache for a single call: the model is parsed again and the result isn't cached,
+// e.g. when a variable resolver may return other values. It can't enable a cache the TaGo doesn't have, see EnableCache.
+//
+// Example:
+//
+//	tags := t.GetNested(&MyModel{}, ".", tago.WithCache(false))
+func WithCache(enabled bool) Option {
+	return func(o *options) {
+		o.noCache = !enabled
+	}
+}
+
+// DisableCache drops the parse cache, every call parses its model again (see EnableCache)
+func (t *TaGo) DisableCache() *TaGo {
+	t.cache = nil
+	return t
+}
+
+// CacheStats returns the statistics of the parse cache, zero if it isn't enabled
+func (t TaGo) CacheStats() CacheStats {
+	if t.cache == nil {
+		return CacheStats{}
+	}
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	stats := t.cache.stats
+	stats.Entries = t.cache.lru.Len()
+	stats.MaxEntries = t.cache.maxEntries
+	return stats
+}
+
+// InvalidateCache drops every cached result, e.g. after a change the cache can't see (a variable of a resolver)
+func (t TaGo) InvalidateCache() {
+	if t.cache == nil {
+		return
+	}
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	t.cache.entries = make(map[cacheKey]*list.Element)
+	t.cache.lru.Init()
+}
+
+// InvalidateType drops the cached results of the models of the given types (values, pointers or reflect.Type),
+// and of the models traversing them
+func (t TaGo) InvalidateType(types ...any) {
+	if t.cache == nil {
+		return
+	}
+	t.cache.mu.Lock()
+	defer t.cache.mu.Unlock()
+
+	for _, typ := range types {
+		invalidated, ok := typ.(reflect.Type)
+		if !ok {
+			invalidated = reflect.TypeOf(typ)
+		}
+		if invalidated == nil {
+			continue
+		}
+		invalidated = typeToElem(invalidated)
+		for element := t.cache.lru.Front(); element != nil; {
+			next := element.Next()
+			entry := element.Value.(*cacheEntry)
+			if entry.key.typ == invalidated || entry.types[invalidated] {
+				t.cache.lru.Remove(element)
+				delete(t.cache.entries, entry.key)
+			}
+			element = next
+		}
+	}
+}
+
+// LRU cache of parse results
+type parseCache struct {
+	mu sync.Mutex
+
+	maxEntries int
+	entries    map[cacheKey]*list.Element
+
+	// Most recently used at the front
+	lru *list.List
+
+	stats CacheStats
+}
+
+// Last configuration generation, see configChanged
+var configGenerations atomic.Uint64
+
+// Record a change of the configuration parsing the tags: results cached before it aren't reused, by this TaGo
+// (new generation) or by the copies sharing its cache and maybe its maps (cache dropped)
+func (t *TaGo) configChanged() {
+	t.generation = configGenerations.Add(1)
+	t.InvalidateCache()
+}
+
+// Model type, tag name, configuration and the options changing the result of a parse
+type cacheKey struct {
+	typ             reflect.Type
+	name            string
+	generation      uint64
+	separator       string
+	maxDepth        int
+	flattenEmbedded bool
+	fieldOrder      FieldOrder
+	limits          Limits
+	excludedPaths   string
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	tags Instructions
+
+	// Struct types traversed, for InvalidateType
+	types map[reflect.Type]bool
+
+	// Warnings of the parse, reported again on hits
+	warnings []Warning
+}
+
+// Cache key of a parse, false if the options can't be cached (functions, tracer) or the call bypasses the cache
+func (o options) cacheKey(t TaGo, modelType reflect.Type) (cacheKey, bool) {
+	if o.noCache || o.tracer != nil || len(o.excludeFuncs) > 0 || len(o.fieldFilters) > 0 || o.onField != nil || o.skip != nil {
+		return cacheKey{}, false
+	}
+
+	excluded := make([]string, 0, len(o.excludedPaths))
+	for path := range o.excludedPaths {
+		excluded = append(excluded, path.String())
+	}
+	sort.Strings(excluded)
+
+	return cacheKey{
+		typ:             modelType,
+		name:            t.Name,
+		generation:      t.generation,
+		separator:       o.separator,
+		maxDepth:        o.maxDepth,
+		flattenEmbedded: o.flattenEmbedded,
+		fieldOrder:      o.fieldOrder,
+		limits:          o.limits,
+		excludedPaths:   strings.Join(excluded, "\x00"),
+	}, true
+}
+
+// Get a copy of a cached result and the warnings of its parse
+func (c *parseCache) get(key cacheKey) (Instructions, []Warning, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		c.stats.Misses++
+		return nil, nil, false
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(element)
+	entry := element.Value.(*cacheEntry)
+	return entry.tags.clone(), entry.warnings, true
+}
+
+// Cache a copy of a result, evicting the least recently used one beyond the limit
+func (c *parseCache) put(key cacheKey, tags Instructions, types map[reflect.Type]bool, warnings []Warning) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, tags: tags.clone(), types: types, warnings: warnings})
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// Deep copy of instructions, so callers can't modify the cached ones
+func (t Instructions) clone() Instructions {
+	copied := make(Instructions, len(t))
+	for instruction, fields := range t {
+		copied[instruction] = append([]FieldName(nil), fields...)
+	}
+	return copied
+}
@@ -0,0 +1,136 @@
+package tago
+
+import "testing"
+
+type cacheAddress struct {
+	City string `gorm2:"column=city"`
+}
+
+type cacheUser struct {
+	Name    string       `gorm2:"column=name"`
+	Address cacheAddress `gorm2:"preload=true"`
+}
+
+func TestCache(t *testing.T) {
+	tg := &TaGo{Name: "gorm2"}
+	tg.EnableCache(1)
+
+	want := Instructions{"column=name": {"Name"}, "preload=true": {"Address"}, "column=city": {"Address.City"}}
+	for i := 0; i < 2; i++ {
+		if got := tg.GetNested(&cacheUser{}, "."); !got.Equal(want) {
+			t.Fatalf("GetNested = %v, want %v", got, want)
+		}
+	}
+	if stats := tg.CacheStats(); stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("CacheStats = %+v, want 1 hit, 1 miss, 1 entry", stats)
+	}
+
+	// Cached results are copies
+	tg.GetNested(&cacheUser{}, ".")["column=name"][0] = "Changed"
+	if got := tg.GetNested(&cacheUser{}, "."); !got.Equal(want) {
+		t.Errorf("GetNested after changing a cached result = %v", got)
+	}
+
+	// Other options are other entries, evicting the least recently used one
+	tg.GetNested(&cacheUser{}, "/")
+	if stats := tg.CacheStats(); stats.Evictions != 1 || stats.Entries != 1 {
+		t.Errorf("CacheStats = %+v, want 1 eviction, 1 entry", stats)
+	}
+
+	tg.InvalidateType(cacheAddress{})
+	if stats := tg.CacheStats(); stats.Entries != 0 {
+		t.Errorf("InvalidateType of a nested type: %d entries left", stats.Entries)
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	tg := &TaGo{Name: "gorm2"}
+	tg.EnableCache(0)
+	warnings := 0
+	tg.Deprecate("preload", "").OnWarning(func(Warning) { warnings++ })
+
+	tg.GetNested(&cacheUser{}, ".")
+	tg.GetNested(&cacheUser{}, ".")
+	if warnings != 2 {
+		t.Fatalf("%d warnings, want 2: a cached result reports the warnings of its parse again", warnings)
+	}
+
+	// WithCache(false) parses the model again, without reading nor filling the cache
+	tg.GetNested(&cacheUser{}, ".", WithCache(false))
+	if stats := tg.CacheStats(); warnings != 3 || stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("WithCache(false): %d warnings, %+v", warnings, stats)
+	}
+
+	// WithCache(true) doesn't enable a cache the TaGo doesn't have
+	tg.DisableCache()
+	tg.GetNested(&cacheUser{}, ".", WithCache(true))
+	if stats := tg.CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("WithCache(true) without cache: %+v", stats)
+	}
+}
+
+type cacheTwoTags struct {
+	Name string `a:"column=a" b:"column=b"`
+	Home string `a:"eager=true"`
+}
+
+func TestCacheConfiguration(t *testing.T) {
+	a := &TaGo{Name: "a"}
+	a.EnableCache(10)
+	a.GetNested(&cacheTwoTags{}, ".")
+
+	// Copies share the cache, not the results of another tag name
+	b := *a
+	b.Name = "b"
+	if got, want := b.GetNested(&cacheTwoTags{}, "."), (Instructions{"column=b": {"Name"}}); !got.Equal(want) {
+		t.Errorf("GetNested of a copy with another name = %v, want %v", got, want)
+	}
+
+	// Configuration changes drop the cached results
+	tests := []struct {
+		name      string
+		configure func(tg *TaGo)
+		want      Instructions
+	}{
+		{"Alias", func(tg *TaGo) { tg.Alias("eager", "preload") }, Instructions{"column=a": {"Name"}, "preload=true": {"Home"}}},
+		{"Normalize", func(tg *TaGo) {
+			tg.Normalize(func(key, value string) (string, string) { return key, value + "!" })
+		}, Instructions{"column=a!": {"Name"}, "eager=true!": {"Home"}}},
+		{"Variables", func(tg *TaGo) { tg.Variables(map[string]string{}) }, Instructions{"column=a": {"Name"}, "eager=true": {"Home"}}},
+		{"RegisterExternal", func(tg *TaGo) {
+			if err := tg.RegisterExternal(cacheTwoTags{}, map[string]string{"Home": "column=home"}); err != nil {
+				t.Fatal(err)
+			}
+		}, Instructions{"column=a": {"Name"}, "eager=true": {"Home"}, "column=home": {"Home"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tg := &TaGo{Name: "a"}
+			tg.EnableCache(10)
+			tg.GetNested(&cacheTwoTags{}, ".")
+
+			test.configure(tg)
+			if got := tg.GetNested(&cacheTwoTags{}, "."); !got.Equal(test.want) {
+				t.Errorf("GetNested after %s = %v, want %v", test.name, got, test.want)
+			}
+			if stats := tg.CacheStats(); stats.Hits != 0 {
+				t.Errorf("CacheStats after %s = %+v, want no hit", test.name, stats)
+			}
+		})
+	}
+
+	// A copy configured differently doesn't read the results of the original, nor the other way around
+	c := *a
+	c.Alias("eager", "preload")
+	if got := c.GetNested(&cacheTwoTags{}, "."); got["preload=true"] == nil {
+		t.Errorf("GetNested of a copy with an alias = %v", got)
+	}
+	d := &TaGo{Name: "a"}
+	d.EnableCache(10)
+	e := *d
+	e.Alias("eager", "preload")
+	e.GetNested(&cacheTwoTags{}, ".")
+	if got := d.GetNested(&cacheTwoTags{}, "."); got["eager=true"] == nil {
+		t.Errorf("GetNested of the original after configuring a copy = %v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package tago
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CaseInsensitive makes instruction keys case-insensitive: keys are lower-cased during parsing
+// and boolean values are normalized to "true" / "false", so `Preload=TRUE` and `preload=true` are the same instruction.
+// Apply, ApplyOne and Has normalize the instructions they look up the same way, handlers can keep any case.
+// Other values are left untouched, they may be case-sensitive (column names, formats, ..).
+//
+// Example:
+//
+//	type MyModel struct {
+//		Address Address `gorm2:"Preload=TRUE"`
+//	}
+//	t := &TaGo{Name: "gorm2"}
+//	t.CaseInsensitive()
+//	tags := t.Get(&MyModel{}) // map[preload=true:[Address]]
+func (t *TaGo) CaseInsensitive() *TaGo {
+	t.configChanged()
+	t.caseInsensitive = true
+	return t
+}
+
+// Normalize the key and value of an instruction if keys are case-insensitive
+func (t TaGo) normalizeCase(key string, value string) (string, string) {
+	if !t.caseInsensitive {
+		return key, value
+	}
+	if b, err := strconv.ParseBool(value); err == nil && !isNumber(value) {
+		value = strconv.FormatBool(b)
+	}
+	return strings.ToLower(key), value
+}
+
+// Normalize an instruction looked up by a handler, see CaseInsensitive
+func (t TaGo) normalizeInstruction(instruction Instruction) Instruction {
+	if !t.caseInsensitive {
+		return instruction
+	}
+	key, value := t.normalizeCase(instruction.Key(), instruction.Value())
+	if !strings.Contains(string(instruction), "=") {
+		return Instruction(key)
+	}
+	return Instruction(key + "=" + value)
+}
+
+// "1" and "0" are valid booleans for strconv but are kept as numbers
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
@@ -0,0 +1,224 @@
+// Command tago inspects the tag usage of the models of a package, from its sources (see tagostatic):
+// no program needs to be written nor compiled against the package.
+//
+//	tago list  -tag gorm2 ./models     instructions of every struct type, with the fields using them
+//	tago lint  -tag gorm2 ./models     parse warnings and unknown keys (see -keys), exits with 1 if any
+//	tago graph -tag gorm2 ./models     relations between the struct types, in Graphviz DOT
+//	tago doc   -tag gorm2 ./models     Markdown documentation of the fields and their instructions
+//
+// The package is a directory or an import path. Flags:
+//
+//	-tag name   name of the tag (required)
+//	-sep .      separator between parent and nested field names
+//	-keys a,b   known instruction keys, for lint: other keys are reported (default: no check)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/tagostatic"
+)
+
+const usage = `usage: tago <list|lint|graph|doc> -tag name [-sep .] [-keys a,b] <package>`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet("tago "+command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	tag := flags.String("tag", "", "name of the tag")
+	separator := flags.String("sep", ".", "separator between parent and nested field names")
+	keys := flags.String("keys", "", "known instruction keys, comma separated (lint)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *tag == "" || flags.NArg() != 1 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	dir, err := packageDir(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "tago:", err)
+		return 1
+	}
+	e := tagostatic.New(tago.TaGo{Name: *tag})
+	e.Separator = *separator
+
+	switch command {
+	case "list":
+		err = list(e, dir, stdout)
+	case "lint":
+		var issues int
+		issues, err = lint(e, dir, *keys, stdout)
+		if err == nil && issues > 0 {
+			return 1
+		}
+	case "graph":
+		err = graph(e, dir, stdout)
+	case "doc":
+		err = doc(e, dir, stdout)
+	default:
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "tago:", err)
+		return 1
+	}
+	return 0
+}
+
+// Directory of a package: the directory itself if it exists, or the one of an import path
+func packageDir(path string) (string, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path, nil
+	}
+	pkg, err := build.Import(path, ".", build.FindOnly)
+	if err != nil {
+		return "", err
+	}
+	return pkg.Dir, nil
+}
+
+// Names of the types having instructions, sorted
+func taggedTypes(models map[string]tago.Instructions) []string {
+	names := make([]string, 0, len(models))
+	for name, tags := range models {
+		if len(tags) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedInstructions(tags tago.Instructions) []tago.Instruction {
+	instructions := make([]tago.Instruction, 0, len(tags))
+	for instruction := range tags {
+		instructions = append(instructions, instruction)
+	}
+	sort.Slice(instructions, func(i, j int) bool { return instructions[i] < instructions[j] })
+	return instructions
+}
+
+func joinFields(fields []tago.FieldName) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.String()
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func list(e *tagostatic.Extractor, dir string, w io.Writer) error {
+	models, err := e.ParseDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range taggedTypes(models) {
+		fmt.Fprintln(w, name)
+		for _, instruction := range sortedInstructions(models[name]) {
+			fmt.Fprintf(w, "  %s: %s\n", instruction, joinFields(models[name][instruction]))
+		}
+	}
+	return nil
+}
+
+// Print the warnings and unknown keys of the models, return their number
+func lint(e *tagostatic.Extractor, dir string, keys string, w io.Writer) (int, error) {
+	var issues []string
+	e.OnWarning = func(typeName string, warning tago.Warning) {
+		issues = append(issues, typeName+" "+warning.String())
+	}
+	models, err := e.ParseDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	if keys != "" {
+		known := make(map[string]bool)
+		for _, key := range strings.Split(keys, ",") {
+			known[strings.TrimSpace(key)] = true
+		}
+		for _, name := range taggedTypes(models) {
+			for _, instruction := range sortedInstructions(models[name]) {
+				// Empty keys are already reported as warnings
+				if instruction == tago.Skip || instruction.Key() == "" || known[instruction.Key()] {
+					continue
+				}
+				for _, field := range models[name][instruction] {
+					issues = append(issues, fmt.Sprintf("%s %s: %s: unknown instruction key %q", name, field, instruction, instruction.Key()))
+				}
+			}
+		}
+	}
+
+	sort.Strings(issues)
+	for _, issue := range issues {
+		fmt.Fprintln(w, issue)
+	}
+	return len(issues), nil
+}
+
+func graph(e *tagostatic.Extractor, dir string, w io.Writer) error {
+	relations, err := e.Relations(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "digraph tago {")
+	for _, relation := range relations {
+		label := relation.Field
+		if relation.Many {
+			label += " []"
+		}
+		fmt.Fprintf(w, "  %q -> %q [label=%q];\n", relation.From, relation.To, label)
+	}
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+func doc(e *tagostatic.Extractor, dir string, w io.Writer) error {
+	models, err := e.ParseDir(dir)
+	if err != nil {
+		return err
+	}
+	for i, name := range taggedTypes(models) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %s\n\n| Field | Instructions |\n| --- | --- |\n", name)
+
+		// Instructions by field
+		fields := make(map[tago.FieldName][]string)
+		for _, instruction := range sortedInstructions(models[name]) {
+			for _, field := range models[name][instruction] {
+				fields[field] = append(fields[field], "`"+strings.ReplaceAll(string(instruction), "|", "\\|")+"`")
+			}
+		}
+		names := make([]tago.FieldName, 0, len(fields))
+		for field := range fields {
+			names = append(names, field)
+		}
+		sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+		for _, field := range names {
+			fmt.Fprintf(w, "| %s | %s |\n", field, strings.Join(fields[field], " "))
+		}
+	}
+	return nil
+}
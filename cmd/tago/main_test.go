@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const models = `package models
+
+type User struct {
+	ID      uint64  ` + "`gorm2:\"primaryKey\"`" + `
+	Email   string  ` + "`gorm2:\"column=email;unique\"`" + `
+	Orders  []Order ` + "`gorm2:\"preload=true\"`" + `
+	Address Address
+	Note    string ` + "`gorm2:\"check=a|b;colour=red\"`" + `
+}
+
+type Order struct {
+	ID    uint64 ` + "`gorm2:\"primaryKey\"`" + `
+	Total int    ` + "`gorm2:\"=5\"`" + `
+}
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(models), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"list", []string{"list", "-tag", "gorm2", dir}, 0, `Order
+  =5: Total
+  primaryKey: ID
+User
+  =5: Orders.Total
+  check=a|b: Note
+  colour=red: Note
+  column=email: Email
+  preload=true: Orders
+  primaryKey: ID, Orders.ID
+  unique: Email
+`, ""},
+		{"list with a separator", []string{"list", "-tag", "gorm2", "-sep", "/", dir}, 0, `Order
+  =5: Total
+  primaryKey: ID
+User
+  =5: Orders/Total
+  check=a|b: Note
+  colour=red: Note
+  column=email: Email
+  preload=true: Orders
+  primaryKey: ID, Orders/ID
+  unique: Email
+`, ""},
+		{"lint", []string{"lint", "-tag", "gorm2", dir}, 1, `Order Total: =5: missing instruction key
+User Orders.Total: =5: missing instruction key
+`, ""},
+		{"lint with keys", []string{"lint", "-tag", "gorm2", "-keys", "primaryKey, column,unique,preload,check", dir}, 1, `Order Total: =5: missing instruction key
+User Note: colour=red: unknown instruction key "colour"
+User Orders.Total: =5: missing instruction key
+`, ""},
+		{"graph", []string{"graph", "-tag", "gorm2", dir}, 0, `digraph tago {
+  "User" -> "Order" [label="Orders []"];
+  "User" -> "Address" [label="Address"];
+}
+`, ""},
+		{"doc", []string{"doc", "-tag", "gorm2", dir}, 0, "## Order\n\n" +
+			"| Field | Instructions |\n| --- | --- |\n" +
+			"| ID | `primaryKey` |\n" +
+			"| Total | `=5` |\n\n" +
+			"## User\n\n" +
+			"| Field | Instructions |\n| --- | --- |\n" +
+			"| Email | `column=email` `unique` |\n" +
+			"| ID | `primaryKey` |\n" +
+			"| Note | `check=a\\|b` `colour=red` |\n" +
+			"| Orders | `preload=true` |\n" +
+			"| Orders.ID | `primaryKey` |\n" +
+			"| Orders.Total | `=5` |\n", ""},
+		{"no command", nil, 2, "", usage},
+		{"unknown command", []string{"show", "-tag", "gorm2", dir}, 2, "", usage},
+		{"no tag", []string{"list", dir}, 2, "", usage},
+		{"no package", []string{"list", "-tag", "gorm2"}, 2, "", usage},
+		{"unknown flag", []string{"list", "-name", "gorm2", dir}, 2, "", "flag provided but not defined: -name"},
+		{"unknown package", []string{"list", "-tag", "gorm2", "example.com/missing/models"}, 1, "", "tago: "},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			if code := run(test.args, &stdout, &stderr); code != test.code {
+				t.Errorf("run = %d, want %d (stderr: %s)", code, test.code, stderr.String())
+			}
+			if stdout.String() != test.stdout {
+				t.Errorf("stdout =\n%s\nwant\n%s", stdout.String(), test.stdout)
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), test.stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Command tagobench runs the benchmark suite of tago and exits with an error when a benchmark exceeds its
+// allocation budget, see internal/bench.
+//
+//	go run ./cmd/tagobench
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/KooQix/tago/internal/bench"
+)
+
+func main() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BENCHMARK\tNS/OP\tB/OP\tALLOCS/OP\tBUDGET\t")
+
+	failed := false
+	for _, result := range bench.Run() {
+		status := ""
+		if result.OverBudget() {
+			status, failed = "OVER BUDGET", true
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", result.Name, result.NsPerOp(), result.AllocedBytesPerOp(), result.AllocsPerOp(), result.MaxAllocs, status)
+	}
+	w.Flush()
+
+	if failed {
+		os.Exit(1)
+	}
+}
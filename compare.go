@@ -0,0 +1,50 @@
+package tago
+
+// Count how many times each field appears in a list of fields
+func countFields(fields []FieldName) map[FieldName]int {
+	counts := make(map[FieldName]int, len(fields))
+	for _, field := range fields {
+		counts[field]++
+	}
+	return counts
+}
+
+// Equal reports whether both instructions hold the same instructions with the same fields,
+// ignoring the order of the fields
+//
+// Example:
+//
+//	a := Instructions{"preload=true": {"Field1", "Field3"}}
+//	b := Instructions{"preload=true": {"Field3", "Field1"}}
+//	a.Equal(b) // true
+func (t Instructions) Equal(other Instructions) bool {
+	if len(t) != len(other) {
+		return false
+	}
+	return t.Contains(other) && other.Contains(t)
+}
+
+// Contains reports whether every instruction of other exists in t with (at least) the same fields,
+// ignoring the order of the fields
+//
+// Example:
+//
+//	tags := Instructions{"preload=true": {"Field1", "Field3"}, "otherOption=value": {"Field1"}}
+//	tags.Contains(Instructions{"preload=true": {"Field3"}}) // true
+func (t Instructions) Contains(other Instructions) bool {
+	for instruction, fields := range other {
+		existing, exists := t[instruction]
+		if !exists {
+			return false
+		}
+
+		counts := countFields(existing)
+		for _, field := range fields {
+			if counts[field] == 0 {
+				return false
+			}
+			counts[field]--
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Snapshot is the tag-derived schema of a model at a point in time, meant to be stored (it marshals to JSON) and
+// compared with the current model by BreakingChanges
+type Snapshot struct {
+	Tag string `json:"tag"`
+
+	// Fingerprint of the model, see Fingerprint: comparing it with the current one tells whether the tags changed
+	Fingerprint string `json:"fingerprint"`
+
+	// Every field of the model, nested fields included, in traversal order
+	Fields []SnapshotField `json:"fields"`
+}
+
+// SnapshotField is a field of a Snapshot
+type SnapshotField struct {
+	// Path of the field, nested fields being prefixed with their parents ("Address.City")
+	Path FieldName `json:"path"`
+
+	// Go type of the field, and kind of the type with pointers dereferenced
+	Type string `json:"type"`
+	Kind string `json:"kind"`
+
+	Instructions []Instruction `json:"instructions,omitempty"`
+}
+
+// BreakingChange is a change of a model breaking the consumers of its previous payloads, see BreakingChanges
+type BreakingChange struct {
+	Path FieldName
+
+	// Type of the field in the previous snapshot, and in the current model ("" if the field was removed)
+	OldType string
+	NewType string
+}
+
+func (c BreakingChange) String() string {
+	if c.NewType == "" {
+		return fmt.Sprintf("%s: removed (was %s)", c.Path, c.OldType)
+	}
+	return fmt.Sprintf("%s: type changed from %s to %s", c.Path, c.OldType, c.NewType)
+}
+
+// Snapshot returns the tag-derived schema of a model, to be stored and compared later with BreakingChanges
+//
+// Example:
+//
+//	snapshot, _ := json.Marshal(t.Snapshot(&OrderCreated{}))
+//	os.WriteFile("schemas/order_created.json", snapshot, 0o644)
+func (t TaGo) Snapshot(model interface{}, opts ...Option) Snapshot {
+	snapshot := Snapshot{Tag: t.Name, Fingerprint: t.Fingerprint(model, opts...), Fields: make([]SnapshotField, 0)}
+
+	o := newOptions(".", -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		kind := field.Type
+		for kind.Kind() == reflect.Ptr {
+			kind = kind.Elem()
+		}
+		snapshot.Fields = append(snapshot.Fields, SnapshotField{
+			Path:         path,
+			Type:         field.Type.String(),
+			Kind:         kind.Kind().String(),
+			Instructions: instructions,
+		})
+	}
+	t.mustParseModel(model, o)
+
+	return snapshot
+}
+
+// BreakingChanges compares a model with a previous snapshot of it and returns the changes breaking the consumers of
+// its payloads, in the order of the snapshot:
+//
+//	removed fields       any field of the snapshot missing from the model (its nested fields aren't reported)
+//	type changes         fields tagged event=true in the snapshot, or nested in one, whose type changed (struct
+//	                     fields are compared field by field, renaming their type doesn't break anything)
+//
+// Adding fields isn't breaking. Event-driven services can run it in their tests against the snapshots of the payload
+// versions already published.
+//
+// Example:
+//
+//	var previous tago.Snapshot
+//	json.Unmarshal(stored, &previous)
+//	for _, change := range t.BreakingChanges(previous, &OrderCreated{}) {
+//		fmt.Println(change) // Amount: type changed from int64 to float64
+//	}
+func (t TaGo) BreakingChanges(previous Snapshot, model interface{}, opts ...Option) []BreakingChange {
+	current := make(map[FieldName]SnapshotField)
+	for _, field := range t.Snapshot(model, opts...).Fields {
+		current[field.Path] = field
+	}
+
+	changes := make([]BreakingChange, 0)
+	var removed, events []FieldName
+	for _, old := range previous.Fields {
+		if event, tagged := lookupKey(old.Instructions, "event"); tagged && event != "false" {
+			events = append(events, old.Path)
+		}
+
+		field, exists := current[old.Path]
+		if !exists {
+			if !underAny(old.Path, removed) {
+				removed = append(removed, old.Path)
+				changes = append(changes, BreakingChange{Path: old.Path, OldType: old.Type})
+			}
+			continue
+		}
+
+		if old.Type == field.Type || !containsField(events, old.Path) && !underAny(old.Path, events) {
+			continue
+		}
+		if old.Kind == reflect.Struct.String() && field.Kind == reflect.Struct.String() {
+			continue
+		}
+		changes = append(changes, BreakingChange{Path: old.Path, OldType: old.Type, NewType: field.Type})
+	}
+	return changes
+}
+
+// Check whether a path is nested under one of the given paths
+func underAny(path FieldName, parents []FieldName) bool {
+	for _, parent := range parents {
+		if strings.HasPrefix(string(path), string(parent)+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package tago
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type compatCustomer struct {
+	ID   int64
+	Name string
+}
+
+type compatCustomerV2 struct {
+	ID   string
+	Name string
+}
+
+type compatOrderV1 struct {
+	ID       int64          `api:"event=true"`
+	Amount   int64          `api:"event=true"`
+	Note     string         `api:"column=note"`
+	Customer compatCustomer `api:"event=true"`
+	Legacy   *compatCustomer
+	Internal int `api:"event=false"`
+}
+
+// Same payload, Customer renamed to another struct type whose ID changed, Legacy removed
+type compatOrderV2 struct {
+	ID       int64   `api:"event=true"`
+	Amount   float64 `api:"event=true"`
+	Note     []byte
+	Customer compatCustomerV2 `api:"event=true"`
+	Internal string           `api:"event=false"`
+	Added    bool             `api:"event=true"`
+}
+
+func TestSnapshot(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	snapshot := tg.Snapshot(&compatOrderV1{})
+	if snapshot.Tag != "api" || snapshot.Fingerprint != tg.Fingerprint(&compatOrderV1{}) {
+		t.Errorf("Snapshot = %+v", snapshot)
+	}
+	var paths []FieldName
+	for _, field := range snapshot.Fields {
+		paths = append(paths, field.Path)
+	}
+	want := []FieldName{"ID", "Amount", "Note", "Customer", "Customer.ID", "Customer.Name", "Legacy", "Legacy.ID", "Legacy.Name", "Internal"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Snapshot paths = %v, want %v", paths, want)
+	}
+	if legacy := snapshot.Fields[6]; legacy.Type != "*tago.compatCustomer" || legacy.Kind != "struct" {
+		t.Errorf("Snapshot of Legacy = %+v", legacy)
+	}
+}
+
+func TestBreakingChanges(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	// Snapshots are stored as JSON
+	encoded, err := json.Marshal(tg.Snapshot(&compatOrderV1{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var previous Snapshot
+	if err := json.Unmarshal(encoded, &previous); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		want  []BreakingChange
+	}{
+		{"same model", &compatOrderV1{}, []BreakingChange{}},
+		{"changed model", &compatOrderV2{}, []BreakingChange{
+			{Path: "Amount", OldType: "int64", NewType: "float64"},
+			{Path: "Customer.ID", OldType: "int64", NewType: "string"},
+			{Path: "Legacy", OldType: "*tago.compatCustomer"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tg.BreakingChanges(previous, test.model); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("BreakingChanges = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBreakingChangeString(t *testing.T) {
+	tests := []struct {
+		change BreakingChange
+		want   string
+	}{
+		{BreakingChange{Path: "Legacy", OldType: "string"}, "Legacy: removed (was string)"},
+		{BreakingChange{Path: "Amount", OldType: "int64", NewType: "float64"}, "Amount: type changed from int64 to float64"},
+	}
+	for _, test := range tests {
+		if got := test.change.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tago
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// ContextKey is the type of the context keys of the ctxKey= instruction, see ToContext.
+// Values are read with ctx.Value(tago.ContextKey("tenantID")).
+type ContextKey string
+
+// ToContext returns a copy of ctx holding the value of every field of a model tagged ctxKey=name, nested fields
+// included, under ContextKey(name). Fields behind a nil pointer are skipped. See FromContext for the reverse.
+//
+// Example:
+//
+//	type Request struct {
+//		TenantID string `api:"ctxKey=tenantID"`
+//		User     struct {
+//			ID int64 `api:"ctxKey=userID"`
+//		}
+//	}
+//	ctx = t.ToContext(ctx, &req)
+//	tenant := ctx.Value(tago.ContextKey("tenantID")).(string)
+func (t TaGo) ToContext(ctx context.Context, model interface{}, opts ...Option) context.Context {
+	t.walkContexts(model, newOptions(".", -1, opts), func(field *FieldContext) {
+		key, exists := lookupKey(field.Instructions, "ctxKey")
+		if exists && field.Value.IsValid() && field.Value.CanInterface() {
+			ctx = context.WithValue(ctx, ContextKey(key), field.Value.Interface())
+		}
+	})
+	return ctx
+}
+
+// FromContext sets the fields of a model tagged ctxKey=name from the values of ctx under ContextKey(name), nested
+// fields included: the reverse of ToContext. Nil pointers to structs are allocated to reach the nested fields
+// (unless WithAllocate(false) is given), keys missing from ctx leave their field untouched. Values are converted
+// like Unflatten does; failures are reported as joined *PathError. model must be a non-nil pointer to a struct.
+//
+// Example:
+//
+//	var req Request
+//	err := t.FromContext(ctx, &req) // req.TenantID, req.User.ID
+func (t TaGo) FromContext(ctx context.Context, model interface{}, opts ...Option) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+
+	var errs []error
+	t.walkContexts(model, newOptions(".", -1, append([]Option{WithAllocate(true)}, opts...)), func(field *FieldContext) {
+		instructions := field.Instructions
+		key, exists := lookupKey(instructions, "ctxKey")
+		if !exists || !field.Value.IsValid() || !field.Value.CanSet() {
+			return
+		}
+		value := ctx.Value(ContextKey(key))
+		if value == nil {
+			return
+		}
+
+		layout, _ := lookupKey(instructions, "format")
+		converted := reflect.New(field.Value.Type()).Elem()
+		if err := assign(converted, value, layout); err != nil {
+			errs = append(errs, &PathError{Path: field.Path, Instruction: Instruction("ctxKey=" + key), Err: err})
+			return
+		}
+		field.Value.Set(converted)
+	})
+	return errors.Join(errs...)
+}
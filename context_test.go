@@ -0,0 +1,104 @@
+package tago
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type contextUser struct {
+	ID   int64  `api:"ctxKey=userID"`
+	Role string `api:"ctxKey=role"`
+}
+
+type contextRequest struct {
+	TenantID string `api:"ctxKey=tenantID"`
+	Limit    int    `api:"ctxKey=limit"`
+	Trace    string
+	User     *contextUser
+	secret   string `api:"ctxKey=secret"`
+}
+
+func TestToContext(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	req := contextRequest{TenantID: "acme", Limit: 10, Trace: "t", User: &contextUser{ID: 7, Role: "admin"}, secret: "s"}
+	ctx := tg.ToContext(context.Background(), &req)
+
+	tests := []struct {
+		key  ContextKey
+		want any
+	}{
+		{"tenantID", "acme"},
+		{"limit", 10},
+		{"userID", int64(7)},
+		{"role", "admin"},
+		{"secret", nil},
+		{"Trace", nil},
+	}
+	for _, test := range tests {
+		if got := ctx.Value(test.key); got != test.want {
+			t.Errorf("ctx.Value(%s) = %v, want %v", test.key, got, test.want)
+		}
+	}
+
+	// Fields behind a nil pointer are skipped, context keys don't collide with plain strings
+	ctx = tg.ToContext(context.WithValue(context.Background(), "tenantID", "other"), contextRequest{TenantID: "acme"})
+	if ctx.Value(ContextKey("userID")) != nil || ctx.Value(ContextKey("tenantID")) != "acme" || ctx.Value("tenantID") != "other" {
+		t.Errorf("ToContext without user: tenantID = %v, userID = %v", ctx.Value(ContextKey("tenantID")), ctx.Value(ContextKey("userID")))
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, ContextKey("tenantID"), "acme")
+	ctx = context.WithValue(ctx, ContextKey("limit"), "25")
+	ctx = context.WithValue(ctx, ContextKey("userID"), 7)
+
+	// Values are converted, nil pointers allocated, missing keys leave their field untouched
+	req := contextRequest{Trace: "t"}
+	if err := tg.FromContext(ctx, &req); err != nil {
+		t.Fatalf("FromContext: %v", err)
+	}
+	if req.TenantID != "acme" || req.Limit != 25 || req.Trace != "t" || req.User == nil || *req.User != (contextUser{ID: 7}) {
+		t.Errorf("FromContext = %+v, user %+v", req, req.User)
+	}
+
+	// Round trip
+	var back contextRequest
+	if err := tg.FromContext(tg.ToContext(context.Background(), &req), &back); err != nil {
+		t.Fatalf("FromContext: %v", err)
+	}
+	if back.TenantID != req.TenantID || back.Limit != req.Limit || *back.User != *req.User {
+		t.Errorf("FromContext(ToContext) = %+v, want %+v", back, req)
+	}
+
+	// Without allocation, nested fields behind nil pointers are left alone
+	var unallocated contextRequest
+	if err := tg.FromContext(ctx, &unallocated, WithAllocate(false)); err != nil || unallocated.User != nil || unallocated.TenantID != "acme" {
+		t.Errorf("FromContext without allocation = %v, %+v", err, unallocated)
+	}
+}
+
+func TestFromContextErrors(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	if err := tg.FromContext(context.Background(), contextRequest{}); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("FromContext into a struct = %v, want ErrNotStruct", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ContextKey("limit"), "many")
+	ctx = context.WithValue(ctx, ContextKey("tenantID"), "acme")
+	var req contextRequest
+	err := tg.FromContext(ctx, &req)
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "Limit" || !strings.Contains(err.Error(), "ctxKey=limit") {
+		t.Errorf("FromContext with an invalid limit = %v, want a *PathError on Limit", err)
+	}
+	if req.TenantID != "acme" {
+		t.Errorf("FromContext = %+v, want the other fields set", req)
+	}
+}
@@ -0,0 +1,70 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// SetDefaults assigns the value of the `default=` instruction to the zero-valued fields of a model, nested fields included.
+// Values are converted to the field type: strings, numbers, bools, durations, times (layout of the `format=` instruction,
+// time.RFC3339 by default), encoding.TextUnmarshaler and slices from comma separated values.
+// Nil pointers to structs are left nil, their fields aren't set. model must be a non-nil pointer to a struct.
+//
+// Example:
+//
+//	type Config struct {
+//		Port    int           `gorm2:"default=8080"`
+//		Timeout time.Duration `gorm2:"default=5s"`
+//		Hosts   []string      `gorm2:"default=a.local,b.local"`
+//	}
+//	config := Config{Port: 9090}
+//	err := t.SetDefaults(&config) // {Port:9090 Timeout:5s Hosts:[a.local b.local]}
+func (t TaGo) SetDefaults(model interface{}, opts ...Option) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+
+	var errs []error
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		instructions := ctx.Instructions
+		value, exists := lookupKey(instructions, "default")
+		if !exists || !ctx.Value.IsValid() || !ctx.Value.CanSet() || !ctx.Value.IsZero() {
+			return
+		}
+		if isFuncOrChan(ctx.Field.Type) {
+			errs = append(errs, &PathError{Path: ctx.Path, Instruction: Instruction("default=" + value), Err: errors.New("func and chan fields can't have a default")})
+			return
+		}
+
+		layout, _ := lookupKey(instructions, "format")
+		if err := convert.Set(ctx.Value, value, layout); err != nil {
+			errs = append(errs, &PathError{Path: ctx.Path, Instruction: Instruction("default=" + value), Err: err})
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// Check that a model is a non-nil pointer to a struct, for the features setting its fields
+func checkSettable(model interface{}) error {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("%w: %T is not a non-nil pointer to a struct", ErrNotStruct, model)
+	}
+	if value.Elem().Kind() != reflect.Struct {
+		return notStruct(value.Elem().Type())
+	}
+	return nil
+}
+
+// Value of the first instruction with the given key
+func lookupKey(instructions []Instruction, key string) (string, bool) {
+	for _, instruction := range instructions {
+		if instruction.Key() == key {
+			return instruction.Value(), true
+		}
+	}
+	return "", false
+}
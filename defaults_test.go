@@ -0,0 +1,113 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type defaultsLevel int
+
+func (l *defaultsLevel) UnmarshalText(text []byte) error {
+	if string(text) != "debug" {
+		return errors.New("unknown level")
+	}
+	*l = 1
+	return nil
+}
+
+type defaultsServer struct {
+	Host string `gorm2:"default=localhost"`
+	Port int    `gorm2:"default=8080"`
+}
+
+type defaultsConfig struct {
+	Name     string        `gorm2:"default=app"`
+	Ratio    float64       `gorm2:"default=0.5"`
+	Debug    bool          `gorm2:"default=true"`
+	Timeout  time.Duration `gorm2:"default=5s"`
+	Since    time.Time     `gorm2:"default=2024-03-01;format=2006-01-02"`
+	Hosts    []string      `gorm2:"default=a.local,b.local"`
+	Level    defaultsLevel `gorm2:"default=debug"`
+	Retries  *int          `gorm2:"default=3"`
+	Server   defaultsServer
+	Fallback *defaultsServer
+	Plain    string
+}
+
+func TestSetDefaults(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	retries := 3
+
+	var config defaultsConfig
+	if err := tg.SetDefaults(&config); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+	want := defaultsConfig{
+		Name:    "app",
+		Ratio:   0.5,
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Since:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Hosts:   []string{"a.local", "b.local"},
+		Level:   1,
+		Retries: &retries,
+		Server:  defaultsServer{Host: "localhost", Port: 8080},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("SetDefaults = %+v, want %+v", config, want)
+	}
+}
+
+func TestSetDefaultsKeepsValues(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	// Set fields are kept, nil pointers to structs are left nil
+	config := defaultsConfig{Name: "set", Server: defaultsServer{Port: 9090}, Fallback: &defaultsServer{Host: "set"}}
+	if err := tg.SetDefaults(&config); err != nil {
+		t.Fatalf("SetDefaults: %v", err)
+	}
+	if config.Name != "set" || config.Server != (defaultsServer{Host: "localhost", Port: 9090}) ||
+		*config.Fallback != (defaultsServer{Host: "set", Port: 8080}) {
+		t.Errorf("SetDefaults = %+v", config)
+	}
+}
+
+func TestSetDefaultsErrors(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	tests := []struct {
+		name  string
+		model any
+		err   string
+	}{
+		{"nil", nil, "not a non-nil pointer"},
+		{"struct", defaultsConfig{}, "not a non-nil pointer"},
+		{"pointer to int", new(int), "not a struct"},
+		{"invalid number", &struct {
+			Port int `gorm2:"default=http"`
+		}{}, "Port: default=http"},
+		{"invalid text", &struct {
+			Level defaultsLevel `gorm2:"default=trace"`
+		}{}, "unknown level"},
+	}
+	for _, test := range tests {
+		if err := tg.SetDefaults(test.model); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("SetDefaults(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+
+	// The other fields are still set
+	model := struct {
+		Port int    `gorm2:"default=http"`
+		Host string `gorm2:"default=localhost"`
+	}{}
+	if err := tg.SetDefaults(&model); err == nil || model.Host != "localhost" {
+		t.Errorf("SetDefaults = %v, %+v, want an error and Host set", err, model)
+	}
+	if err := tg.SetDefaults(new(int)); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("SetDefaults(*int) = %v, want ErrNotStruct", err)
+	}
+}
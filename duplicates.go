@@ -0,0 +1,55 @@
+package tago
+
+import "sort"
+
+// Duplicate is an instruction carried by several fields of a model, see Duplicates
+type Duplicate struct {
+	Instruction Instruction
+	Fields      []FieldName
+}
+
+// Duplicates returns the instructions with one of the given keys carried by more than one field of a model,
+// at any depth (see WithMaxDepth to only check the top-level fields), e.g. two primaryKey=true or two column=email.
+// Flags are compared with their value: primaryKey and primaryKey=true are duplicates, reported as primaryKey=true.
+// The result is sorted by instruction, the fields of each duplicate by path.
+//
+// Example:
+//
+//	for _, duplicate := range t.Duplicates(&User{}, ".", "primaryKey", "column") {
+//		log.Printf("%s used on %v", duplicate.Instruction, duplicate.Fields) // column=email used on [Email Login]
+//	}
+func (t TaGo) Duplicates(model interface{}, separator string, keys ...string) []Duplicate {
+	return t.DuplicatesWith(model, separator, keys)
+}
+
+// DuplicatesWith is Duplicates with options
+func (t TaGo) DuplicatesWith(model interface{}, separator string, keys []string, opts ...Option) []Duplicate {
+	checked := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		checked[t.normalizeInstruction(Instruction(key)).Key()] = true
+	}
+
+	// Fields by instruction, flags with their value
+	carriers := make(Instructions)
+	for instruction, fields := range t.GetNested(model, separator, opts...) {
+		if checked[instruction.Key()] {
+			explicit := Instruction(instruction.Key() + "=" + instruction.Value())
+			for _, field := range fields {
+				if !containsField(carriers[explicit], field) {
+					carriers[explicit] = append(carriers[explicit], field)
+				}
+			}
+		}
+	}
+
+	duplicates := make([]Duplicate, 0)
+	for _, instruction := range carriers.Keys() {
+		fields := carriers[instruction]
+		if len(fields) < 2 {
+			continue
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i] < fields[j] })
+		duplicates = append(duplicates, Duplicate{Instruction: instruction, Fields: fields})
+	}
+	return duplicates
+}
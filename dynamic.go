@@ -0,0 +1,70 @@
+package tago
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// GetNestedDynamic is GetNested on a populated model, also traversing the dynamic values of its interface fields
+// (any, []any, map payloads excepted): GetNested only knows their static type, they are leaves there.
+// The struct held by an interface field is traversed under the path of the field, the elements of a slice under
+// their index, as their types may differ: "Payload.Name", "Events[0].ID". Nil values have nothing to traverse.
+// Interface fields are only reached through fields holding a value: not inside slices of structs nor behind nil pointers.
+//
+// Example:
+//
+//	type Envelope struct {
+//		Kind    string `gorm2:"index"`
+//		Payload any
+//	}
+//	t.GetNestedDynamic(Envelope{Payload: User{}}, ".") // map[column=email:[Payload.Email] index:[Kind]]
+func (t TaGo) GetNestedDynamic(model interface{}, separator string, opts ...Option) Instructions {
+	return t.getNestedDynamic(model, separator, opts, map[uintptr]bool{})
+}
+
+func (t TaGo) getNestedDynamic(model interface{}, separator string, opts []Option, seen map[uintptr]bool) Instructions {
+	type dynamicField struct {
+		path  FieldName
+		value reflect.Value
+	}
+	var fields []dynamicField
+
+	tags := t.walkContexts(model, newOptions(separator, -1, opts), func(ctx *FieldContext) {
+		if ctx.Value.IsValid() && typeToElem(ctx.Field.Type).Kind() == reflect.Interface {
+			fields = append(fields, dynamicField{path: ctx.Path, value: ctx.Value})
+		}
+	})
+	for _, field := range fields {
+		t.expandDynamic(tags, field.value, field.path.String(), separator, opts, seen)
+	}
+	return tags
+}
+
+// Add the instructions of the structs held by a dynamic value to tags, under prefix
+func (t TaGo) expandDynamic(tags Instructions, value reflect.Value, prefix string, separator string, opts []Option, seen map[uintptr]bool) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		if value.Kind() == reflect.Ptr {
+			// Stop on cycles of pointers
+			if seen[value.Pointer()] {
+				return
+			}
+			seen[value.Pointer()] = true
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if t.resolveType(value.Type()) == nil || t.isIgnored(value.Type()) {
+			return
+		}
+		tags.concat(t.getNestedDynamic(value.Interface(), separator, opts, seen), prefix+separator)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			t.expandDynamic(tags, value.Index(i), prefix+"["+strconv.Itoa(i)+"]", separator, opts, seen)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNotStruct is wrapped by the errors of the functions given a model which isn't a struct (or a pointer, slice
+	// or array of structs, as accepted), nil models included
+	ErrNotStruct = errors.New("tago: not a struct")
+
+	// ErrUnknownField is wrapped by the PathError of a path naming no field (Unflatten, ApplyPatch, GetNestedUnder, ..)
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrSkippedField is wrapped by the PathError of a path at or under a field tagged "-" (see Skip), whose subtree
+	// isn't traversed, see GetNestedUnder
+	ErrSkippedField = errors.New("skipped field")
+
+	// ErrReadOnly is wrapped by the PathError of a change to a field tagged readOnly=true, see EnforceReadOnly
+	ErrReadOnly = errors.New("read-only field changed")
+
+	// ErrUnboundedLabel is wrapped by the PathError of a metric label whose values aren't bounded, see MetricLabels
+	ErrUnboundedLabel = errors.New("unbounded metric label")
+
+	// ErrLimitExceeded is wrapped by the PathError of a field exceeding its maxItems=, maxLen= or maxBytes= limit, see
+	// CheckLimits
+	ErrLimitExceeded = errors.New("limit exceeded")
+)
+
+// ParseError reports a tag its parser couldn't parse (see Parser). It is raised as the Err of a Warning,
+// and returned by Compile. Parsers can return one to give the position of the error, Type and Field are filled in.
+//
+// Example:
+//
+//	var parseErr *tago.ParseError
+//	if _, err := t.Compile(&User{}); errors.As(err, &parseErr) {
+//		fmt.Println(parseErr.Field, parseErr.Pos) // Email 12
+//	}
+type ParseError struct {
+	// Struct type declaring the field, nil if unknown (GetFromField), and path of the field
+	Type  reflect.Type
+	Field FieldName
+
+	// Raw tag, and byte offset of the error in it (-1 if unknown)
+	Raw string
+	Pos int
+
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	message := "tago: "
+	if e.Type != nil {
+		message += e.Type.String() + " "
+	}
+	if e.Field != "" {
+		message += e.Field.String() + ": "
+	}
+	return message + e.message()
+}
+
+// Message of the error without its location
+func (e *ParseError) message() string {
+	if e.Pos >= 0 {
+		return fmt.Sprintf("%v (at byte %d of %q)", e.Err, e.Pos, e.Raw)
+	}
+	return fmt.Sprintf("%v (tag %q)", e.Err, e.Raw)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// PathError reports a failure on the field of a model at a path, by the features reading or setting values
+// (SetDefaults, Unflatten, ApplyPatch, MapStructs, ApplyFieldPipeline, ..). Several of them are joined with errors.Join.
+//
+// Example:
+//
+//	var pathErr *tago.PathError
+//	if err := t.ApplyPatch(&user, patch); errors.As(err, &pathErr) && errors.Is(pathErr, tago.ErrUnknownField) {
+//		http.Error(w, "unknown field "+pathErr.Path.String(), http.StatusBadRequest)
+//	}
+type PathError struct {
+	Path FieldName
+
+	// Instruction being applied, if any
+	Instruction Instruction
+
+	Err error
+}
+
+func (e *PathError) Error() string {
+	if e.Instruction != "" {
+		return fmt.Sprintf("tago: %s: %s: %v", e.Path, e.Instruction, e.Err)
+	}
+	return fmt.Sprintf("tago: %s: %v", e.Path, e.Err)
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// Error of a model which isn't a struct, wrapping ErrNotStruct: "tago: not a struct: int"
+func notStruct(what any) error {
+	return fmt.Errorf("%w: %v", ErrNotStruct, what)
+}
@@ -0,0 +1,90 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Expander controls how GetNested traverses the fields of a given type.
+//
+// Expand receives the field type (as declared, before pointers/slices are unwrapped) and returns
+// the type whose fields must be traversed instead, or nil to treat the field as a leaf.
+// The returned type is unwrapped (pointers, slices, arrays) like any other field type.
+//
+// Example, flatten a generic Optional[T] wrapper to its T:
+//
+//	type Optional[T any] struct {
+//		value T
+//		set   bool
+//	}
+//	t.RegisterExpander(Optional[any]{}, tago.ExpanderFunc(func(typ reflect.Type) reflect.Type {
+//		return typ.Field(0).Type
+//	}))
+//
+// Example, expand a json.RawMessage against a known schema:
+//
+//	t.RegisterExpander(json.RawMessage{}, tago.ExpanderFunc(func(typ reflect.Type) reflect.Type {
+//		return reflect.TypeOf(Payload{})
+//	}))
+type Expander interface {
+	Expand(typ reflect.Type) reflect.Type
+}
+
+// ExpanderFunc is a function implementing Expander
+type ExpanderFunc func(typ reflect.Type) reflect.Type
+
+func (f ExpanderFunc) Expand(typ reflect.Type) reflect.Type {
+	return f(typ)
+}
+
+// RegisterExpander registers an Expander for the type of the given value (or reflect.Type).
+// For generic types, registering any instantiation (e.g. Optional[any]{}) applies to all of them (Optional[int], Optional[User], ..).
+func (t *TaGo) RegisterExpander(typ any, expander Expander) *TaGo {
+	t.configChanged()
+	if t.expanders == nil {
+		t.expanders = make(map[string]Expander)
+	}
+
+	rt, ok := typ.(reflect.Type)
+	if !ok {
+		rt = reflect.TypeOf(typ)
+	}
+	if rt != nil {
+		t.expanders[expanderKey(rt)] = expander
+	}
+	return t
+}
+
+// Key under which an expander is registered for the given type
+// Generic instantiations share the same key: pkg.Optional[int] -> pkg.Optional
+func expanderKey(typ reflect.Type) string {
+	if typ.Name() == "" {
+		return typ.String()
+	}
+	name, _, _ := strings.Cut(typ.Name(), "[")
+	return typ.PkgPath() + "." + name
+}
+
+// Get the type to traverse for a field type: apply registered expanders and unwrap pointers, slices and arrays
+// Return nil if an expander decided the field is a leaf
+func (t TaGo) resolveType(typ reflect.Type) reflect.Type {
+	applied := make(map[string]bool)
+	for typ != nil {
+		// Apply the expander registered for this type, once (an expander may return its own type)
+		if key := expanderKey(typ); !applied[key] {
+			if expander, exists := t.expanders[key]; exists {
+				applied[key] = true
+				typ = expander.Expand(typ)
+				continue
+			}
+		}
+
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			typ = typ.Elem()
+		default:
+			return typ
+		}
+	}
+	return nil
+}
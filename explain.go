@@ -0,0 +1,53 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// PlannedCall is a handler invocation planned by ExplainApply
+type PlannedCall struct {
+	Instruction Instruction
+	Field       FieldName
+
+	// Name of the handler function, e.g. "main.preload" or "main.main.func1" for a closure
+	Handler string
+}
+
+func (c PlannedCall) String() string {
+	return fmt.Sprintf("%s(%s) [%s]", c.Handler, c.Field, c.Instruction)
+}
+
+// ExplainApply returns the handler invocations Apply would make, without executing them,
+// to log or assert the plan before side-effecting handlers run.
+// Calls are ordered like ApplyReport executes them: by instruction alphabetically, then by field discovery order.
+//
+// Example usage:
+//
+//	for _, call := range t.ExplainApply(instructions, instructionMapping) {
+//	    fmt.Println(call) // main.preload(Address) [preload=true]
+//	}
+func (t TaGo) ExplainApply(instructions Instructions, instructionMapping map[Instruction]func(field FieldName)) []PlannedCall {
+	plan := make([]PlannedCall, 0)
+	for _, instruction := range sortedInstructions(instructionMapping) {
+		handler := funcName(instructionMapping[instruction])
+		for _, field := range instructions[t.normalizeInstruction(instruction)] {
+			plan = append(plan, PlannedCall{Instruction: instruction, Field: field, Handler: handler})
+		}
+	}
+	return plan
+}
+
+// Name of a function, without the "-fm" suffix of method values
+func funcName(fn any) string {
+	value := reflect.ValueOf(fn)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return "<nil>"
+	}
+	if f := runtime.FuncForPC(value.Pointer()); f != nil {
+		return strings.TrimSuffix(f.Name(), "-fm")
+	}
+	return "<unknown>"
+}
@@ -0,0 +1,486 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Evaluator evaluates the expressions found in instruction values, e.g. visibleIf=role=='admin' or max=len(Name)+10
+// vars holds the variables of the expression: the fields of the model and the extra variables given to EvalWith.
+type Evaluator interface {
+	Eval(expr string, vars func(name string) (any, bool)) (any, error)
+}
+
+// EvaluatorFunc is a function implementing Evaluator
+type EvaluatorFunc func(expr string, vars func(name string) (any, bool)) (any, error)
+
+func (f EvaluatorFunc) Eval(expr string, vars func(name string) (any, bool)) (any, error) {
+	return f(expr, vars)
+}
+
+// SetEvaluator replaces the default expression evaluator (see DefaultEvaluator) used by Eval / EvalWith
+func (t *TaGo) SetEvaluator(evaluator Evaluator) *TaGo {
+	t.evaluator = evaluator
+	return t
+}
+
+// Eval evaluates an expression against a model: identifiers are the fields of the model, nested fields
+// being accessed with dots (Address.City). See DefaultEvaluator for the supported syntax.
+//
+// Example:
+//
+//	type Product struct {
+//		Name  string
+//		Stock int `validate:"max=len(Name)+10"`
+//	}
+//	value, _ := instructions.Lookup("max")
+//	max, err := t.Eval(product, value) // float64(len(product.Name) + 10)
+func (t TaGo) Eval(model any, expr string) (any, error) {
+	return t.EvalWith(model, expr, nil)
+}
+
+// EvalWith evaluates an expression like Eval, with extra variables taking precedence over the model fields
+//
+// Example:
+//
+//	value, _ := instructions.Lookup("visibleIf") // role=='admin'
+//	visible, err := t.EvalWith(user, value, map[string]any{"role": currentRole})
+func (t TaGo) EvalWith(model any, expr string, vars map[string]any) (any, error) {
+	evaluator := t.evaluator
+	if evaluator == nil {
+		evaluator = DefaultEvaluator
+	}
+
+	modelValue := reflect.ValueOf(model)
+	lookup := func(name string) (any, bool) {
+		first, rest, _ := strings.Cut(name, ".")
+		if value, exists := vars[first]; exists {
+			if rest == "" {
+				return value, true
+			}
+			return lookupPath(reflect.ValueOf(value), rest)
+		}
+		return lookupPath(modelValue, name)
+	}
+
+	return evaluator.Eval(expr, lookup)
+}
+
+// Resolve a dotted path of fields (or map keys) from a value
+func lookupPath(v reflect.Value, path string) (any, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, exists := v.Type().FieldByName(name)
+			if !exists || !field.IsExported() {
+				return nil, false
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// DefaultEvaluator is a small expression evaluator supporting:
+//
+//	literals      10, 2.5, 'admin', "admin", true, false, nil
+//	variables     Name, Address.City (unknown variables are nil)
+//	operators     || && ! == != < <= > >= + - * / % and parentheses
+//	functions     len(x), lower(s), upper(s)
+//
+// Numbers are evaluated as float64, + concatenates strings. || and && short-circuit: their right operand is only
+// evaluated when the left one doesn't decide the result, so B != nil && B.Count > 1 guards the comparison.
+var DefaultEvaluator Evaluator = EvaluatorFunc(evalExpr)
+
+func evalExpr(expr string, vars func(name string) (any, bool)) (any, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := exprParser{tokens: tokens, vars: vars}
+	value, err := p.parse(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("tago: expression %q: unexpected %q", expr, p.tokens[p.pos].text)
+	}
+	return value, nil
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", ","}
+
+func tokenize(expr string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i])})
+		case r == '\'' || r == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("tago: expression %q: unterminated string", expr)
+			}
+			i++
+			tokens = append(tokens, token{tokenString, string(runes[start+1 : i-1])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i])})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{tokenOperator, op})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("tago: expression %q: unexpected character %q", expr, r)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// Binding power of the binary operators
+var precedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+// Precedence climbing parser evaluating while parsing
+type exprParser struct {
+	tokens []token
+	pos    int
+	vars   func(name string) (any, bool)
+
+	// Above 0 while parsing the right operand of a short-circuited || or &&: syntax errors are still reported,
+	// evaluation errors aren't
+	skipping int
+}
+
+// Evaluation error of an operand, nil if the operand is short-circuited (its value is nil then)
+func (p *exprParser) evalErr(err error) error {
+	if p.skipping > 0 {
+		return nil
+	}
+	return err
+}
+
+func (p *exprParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *exprParser) expect(op string) error {
+	tok, ok := p.peek()
+	if !ok || tok.kind != tokenOperator || tok.text != op {
+		return fmt.Errorf("tago: expression: expected %q", op)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *exprParser) parse(minPrecedence int) (any, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOperator {
+			return left, nil
+		}
+		prec, isBinary := precedence[tok.text]
+		if !isBinary || prec <= minPrecedence {
+			return left, nil
+		}
+		p.pos++
+
+		// false && .. and true || .. don't depend on their right operand: it is parsed, not evaluated
+		shortCircuit := tok.text == "&&" && !truthy(left) || tok.text == "||" && truthy(left)
+		if shortCircuit {
+			p.skipping++
+		}
+		right, err := p.parse(prec)
+		if shortCircuit {
+			p.skipping--
+		}
+		switch {
+		case err != nil:
+			return nil, err
+		case shortCircuit:
+			left = truthy(left)
+		default:
+			if left, err = binary(tok.text, left, right); p.evalErr(err) != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (p *exprParser) unary() (any, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("tago: expression: unexpected end")
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokenNumber:
+		return strconv.ParseFloat(tok.text, 64)
+	case tokenString:
+		return tok.text, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "nil", "null":
+			return nil, nil
+		}
+
+		// Function call
+		if next, ok := p.peek(); ok && next.kind == tokenOperator && next.text == "(" {
+			p.pos++
+			arg, err := p.parse(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			value, err := call(tok.text, arg)
+			return value, p.evalErr(err)
+		}
+
+		value, _ := p.vars(tok.text)
+		return normalize(value), nil
+	}
+
+	switch tok.text {
+	case "(":
+		value, err := p.parse(0)
+		if err != nil {
+			return nil, err
+		}
+		return value, p.expect(")")
+	case "!":
+		value, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(value), nil
+	case "-":
+		value, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		number, ok := value.(float64)
+		if !ok {
+			return nil, p.evalErr(fmt.Errorf("tago: expression: cannot negate %v", value))
+		}
+		return -number, nil
+	}
+	return nil, fmt.Errorf("tago: expression: unexpected %q", tok.text)
+}
+
+func call(name string, arg any) (any, error) {
+	switch name {
+	case "len":
+		if s, ok := arg.(string); ok {
+			return float64(len([]rune(s))), nil
+		}
+		v := reflect.ValueOf(arg)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			return float64(v.Len()), nil
+		case reflect.Invalid:
+			return float64(0), nil
+		}
+		return nil, fmt.Errorf("tago: expression: len of %T", arg)
+	case "lower":
+		return strings.ToLower(fmt.Sprint(arg)), nil
+	case "upper":
+		return strings.ToUpper(fmt.Sprint(arg)), nil
+	}
+	return nil, fmt.Errorf("tago: expression: unknown function %q", name)
+}
+
+func binary(op string, left, right any) (any, error) {
+	switch op {
+	case "||":
+		return truthy(left) || truthy(right), nil
+	case "&&":
+		return truthy(left) && truthy(right), nil
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	}
+
+	// String concatenation and comparison
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok {
+			switch op {
+			case "+":
+				return ls + rs, nil
+			case "<":
+				return ls < rs, nil
+			case "<=":
+				return ls <= rs, nil
+			case ">":
+				return ls > rs, nil
+			case ">=":
+				return ls >= rs, nil
+			}
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("tago: expression: invalid operands for %s: %v, %v", op, left, right)
+	}
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("tago: expression: division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, fmt.Errorf("tago: expression: division by zero")
+		}
+		return float64(int64(l) % int64(r)), nil
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	}
+	return nil, fmt.Errorf("tago: expression: unknown operator %q", op)
+}
+
+// Convert numbers to float64 so they can be compared and computed, dereference pointers
+func normalize(value any) any {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Invalid:
+		return nil
+	}
+	return v.Interface()
+}
+
+func equal(left, right any) bool {
+	return reflect.DeepEqual(left, right)
+}
+
+// Truthiness of a value: false, nil, 0, "" and empty collections are false
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() > 0
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+package tago
+
+import (
+	"strings"
+	"testing"
+)
+
+type evalProduct struct {
+	Name    string
+	Stock   int
+	Tags    []string
+	Address *evalAddress
+}
+
+type evalAddress struct {
+	City string
+}
+
+func TestEval(t *testing.T) {
+	tg := TaGo{Name: "validate"}
+	product := evalProduct{Name: "chair", Stock: 3, Tags: []string{"wood", "oak"}}
+
+	tests := []struct {
+		expr string
+		want any
+	}{
+		{"1 + 2 * 3", 7.0},
+		{"(1 + 2) * 3", 9.0},
+		{"7 % 4", 3.0},
+		{"-Stock", -3.0},
+		{"len(Name) + 10", 15.0},
+		{"len(Tags)", 2.0},
+		{"upper(Name) == 'CHAIR'", true},
+		{"Name + '-' + lower('X')", "chair-x"},
+		{"Stock >= 3 && Stock < 10", true},
+		{"!(Stock > 3)", true},
+		{"Address == nil", true},
+		{"Address.City", nil},
+		{"Missing", nil},
+		{"'b' > 'a'", true},
+		{"Stock != 3 || Name == \"chair\"", true},
+	}
+	for _, test := range tests {
+		got, err := tg.Eval(product, test.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", test.expr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Eval(%q) = %#v, want %#v", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestEvalShortCircuit(t *testing.T) {
+	tg := TaGo{Name: "validate"}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		// The right operand would fail: nil > 1, -'x', len(1), 1/0
+		{"Address != nil && Address.City > 1", false},
+		{"Address == nil || Address.City > 1", true},
+		{"false && -'x'", false},
+		{"true || len(1)", true},
+		{"false && 1/0 > 1 || true", true},
+		{"Stock > 1 && (Address == nil || Address.City == 'Paris')", true},
+	}
+	for _, test := range tests {
+		got, err := tg.Eval(evalProduct{Stock: 3}, test.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", test.expr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Eval(%q) = %v, want %v", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tg := TaGo{Name: "validate"}
+
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"true && -'x'", "cannot negate"},
+		{"false || 1/0 > 1", "division by zero"},
+		{"unknown(1)", "unknown function"},
+		{"'a' * 2", "invalid operands"},
+		{"'unterminated", "unterminated string"},
+		{"1 +", "unexpected end"},
+		{"(1", `expected ")"`},
+		{"1 2", `unexpected "2"`},
+		{"1 # 2", "unexpected character"},
+
+		// Short-circuited operands are still parsed
+		{"false && (1", `expected ")"`},
+	}
+	for _, test := range tests {
+		_, err := tg.Eval(evalProduct{}, test.expr)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Eval(%q) error = %v, want %q", test.expr, err, test.err)
+		}
+	}
+}
+
+func TestEvalWith(t *testing.T) {
+	tg := TaGo{Name: "validate"}
+	vars := map[string]any{"role": "admin", "Stock": 10, "user": evalAddress{City: "Paris"}}
+
+	got, err := tg.EvalWith(evalProduct{Stock: 3}, "role == 'admin' && Stock == 10 && user.City == 'Paris'", vars)
+	if err != nil || got != true {
+		t.Errorf("EvalWith = %v, %v, want true", got, err)
+	}
+}
+
+func TestSetEvaluator(t *testing.T) {
+	tg := TaGo{Name: "validate"}
+	tg.SetEvaluator(EvaluatorFunc(func(expr string, vars func(name string) (any, bool)) (any, error) {
+		value, _ := vars(expr)
+		return value, nil
+	}))
+
+	got, err := tg.Eval(evalProduct{Name: "chair"}, "Name")
+	if err != nil || got != "chair" {
+		t.Errorf("Eval with a custom evaluator = %v, %v, want chair", got, err)
+	}
+}
+
+func FuzzEval(f *testing.F) {
+	for _, expr := range []string{
+		"1 + 2 * 3",
+		"len(Name) > 3 && Stock != 0",
+		"Address != nil && Address.City == 'Paris'",
+		"!(Stock % 2 == 0) || upper(Name) + \"x\" >= 'A'",
+		"-(-1.5) / 0",
+		"'unterminated",
+		"((((",
+	} {
+		f.Add(expr)
+	}
+
+	tg := TaGo{Name: "validate"}
+	product := evalProduct{Name: "chair", Stock: 3, Address: &evalAddress{City: "Paris"}}
+	f.Fuzz(func(t *testing.T, expr string) {
+		// Any input either evaluates or fails with an error, it never panics
+		value, err := tg.Eval(product, expr)
+		if err != nil && value != nil {
+			t.Errorf("Eval(%q) returned both %v and %v", expr, value, err)
+		}
+	})
+}
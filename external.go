@@ -0,0 +1,71 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterExternal attaches tags to the fields of a struct type that can't be modified (e.g. types from a vendored SDK).
+// tags maps a field name to its raw tag value, as it would be written between the quotes of the t.Name tag.
+// During traversal (Get, GetNested, ..) they are parsed as if declared inline, after the inline tag if the field has one.
+//
+// An error is returned if the type isn't a struct or a field doesn't exist.
+// Note that GetFromField can't know the struct declaring a field, so it only sees the inline tags.
+//
+// Example:
+//
+//	t := &TaGo{Name: "gorm2"}
+//	err := t.RegisterExternal(stripe.Customer{}, map[string]string{
+//		"Email":   "column=email;unique",
+//		"Address": "preload=true",
+//	})
+func (t *TaGo) RegisterExternal(typ any, tags map[string]string) error {
+	t.configChanged()
+	rt, ok := typ.(reflect.Type)
+	if !ok {
+		rt = reflect.TypeOf(typ)
+	}
+	if rt == nil {
+		return fmt.Errorf("tago: RegisterExternal: nil type")
+	}
+	rt = typeToElem(rt)
+	if rt.Kind() != reflect.Struct {
+		return fmt.Errorf("tago: RegisterExternal: %w", notStruct(rt))
+	}
+
+	for field := range tags {
+		if _, exists := rt.FieldByName(field); !exists {
+			return fmt.Errorf("tago: RegisterExternal: %s has no field %s", rt, field)
+		}
+	}
+
+	if t.external == nil {
+		t.external = make(map[reflect.Type]map[string]string)
+	}
+	if t.external[rt] == nil {
+		t.external[rt] = make(map[string]string)
+	}
+	for field, tag := range tags {
+		t.external[rt][field] = tag
+	}
+	return nil
+}
+
+// Get the raw t.Name tag of a field, merged with the external and overlay tags registered for it (owner can be nil)
+func (t TaGo) rawTag(modelField reflect.StructField, owner reflect.Type) string {
+	tag := modelField.Tag.Get(t.Name)
+	if owner == nil {
+		return tag
+	}
+
+	for _, extra := range []string{t.external[owner][modelField.Name], t.overlayTag(owner, modelField.Name)} {
+		switch {
+		case extra == "":
+		case tag == "":
+			tag = extra
+		default:
+			tag += ";" + extra
+		}
+	}
+	return tag
+}
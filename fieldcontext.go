@@ -0,0 +1,135 @@
+package tago
+
+import (
+	"reflect"
+	"slices"
+)
+
+// FieldContext is what handlers of ApplyContext receive: everything known about the field an instruction applies to
+type FieldContext struct {
+	// Path of the field, as returned by GetNested
+	Path  FieldName
+	Field reflect.StructField
+
+	// Struct type declaring the field, and its nesting depth (0 for the fields of the model)
+	Owner reflect.Type
+	Depth int
+
+	// Value of the field in the model, invalid if a pointer on the way is nil or the field is inside a slice or map.
+	// It is settable if the model was given as a pointer.
+	Value reflect.Value
+
+	// A nil pointer on the way to the field was allocated to reach it (see WithAllocate), or was left nil: Value is then
+	// invalid. Both are false when the way to the field has no nil pointer.
+	Allocated bool
+	NilParent bool
+
+	// Instruction the handler is called for, and the raw tag of the field
+	Instruction Instruction
+	RawTag      string
+
+	// Instructions of the field, in tag order, as parsed by the traversal
+	Instructions []Instruction
+
+	// Context of the struct field containing this one, nil for the fields of the model
+	Parent *FieldContext
+}
+
+// ApplyContext applies the instruction mapping to the fields of a model, nested fields included,
+// giving handlers the whole context of the field rather than its name: its metadata, value and parent.
+// Fields are visited in declaration order, the handlers of a field in alphabetical order of their instruction.
+// The separator of the paths is "." unless WithSeparator is given.
+// The fields behind a nil pointer are visited with an invalid Value, unless WithAllocate is given.
+//
+// Example usage:
+//
+//	t.ApplyContext(&user, map[Instruction]func(FieldContext){
+//		"trim": func(ctx FieldContext) {
+//			if ctx.Value.Kind() == reflect.String && ctx.Value.CanSet() {
+//				ctx.Value.SetString(strings.TrimSpace(ctx.Value.String()))
+//			}
+//		},
+//	})
+func (t TaGo) ApplyContext(model interface{}, instructionMapping map[Instruction]func(ctx FieldContext), opts ...Option) {
+	handlers := sortedInstructions(instructionMapping)
+	normalized := make([]Instruction, len(handlers))
+	for i, instruction := range handlers {
+		normalized[i] = t.normalizeInstruction(instruction)
+	}
+
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		for i, instruction := range normalized {
+			if slices.Contains(ctx.Instructions, instruction) {
+				call := *ctx
+				call.Instruction = instruction
+				instructionMapping[handlers[i]](call)
+			}
+		}
+	})
+}
+
+// Visit the context of every field of a model, depth-first in declaration order, return the instructions of the model
+func (t TaGo) walkContexts(model interface{}, o options, visit func(ctx *FieldContext)) Instructions {
+	root := reflect.ValueOf(model)
+	var parents []*FieldContext
+
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		ctx := &FieldContext{Path: path, Field: field, Owner: owner, Depth: depth, RawTag: t.rawTag(field, owner), Instructions: instructions}
+
+		// Fields are visited depth-first, the parent is the last field visited one level up
+		container := root
+		if depth > 0 && depth <= len(parents) {
+			ctx.Parent = parents[depth-1]
+			container = ctx.Parent.Value
+			if o.allocate {
+				ctx.Allocated = allocateNil(container)
+			}
+			ctx.NilParent = ctx.Parent.NilParent || isNilPointer(container)
+		}
+		parents = append(parents[:min(depth, len(parents))], ctx)
+		if container = structValue(container); container.IsValid() && container.Type() == owner {
+			ctx.Value = container.FieldByIndex(field.Index)
+		}
+
+		visit(ctx)
+	}
+	return t.mustParseModel(model, o)
+}
+
+// Allocate the nil pointers of a settable pointer chain to a struct, return whether one was allocated
+func allocateNil(value reflect.Value) bool {
+	allocated := false
+	for value.IsValid() && value.Kind() == reflect.Ptr && value.CanSet() {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+			allocated = true
+		}
+		value = value.Elem()
+	}
+	return allocated
+}
+
+// Check whether a pointer chain ends with a nil pointer
+func isNilPointer(value reflect.Value) bool {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	return false
+}
+
+// Dereference pointers down to a struct value, invalid if a pointer is nil or the value isn't a struct
+func structValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return value
+}
@@ -0,0 +1,45 @@
+package tago
+
+import "reflect"
+
+// FieldMap returns the metadata of every field of a model, nested fields included, by path.
+// Paths are the ones GetNested returns with the same separator and options, so consumers can go from
+// the fields of Instructions back to their type and other tags without a second traversal.
+//
+// Example:
+//
+//	fields := t.FieldMap(&User{}, ".")
+//	tags := t.GetNested(&User{}, ".")
+//	for _, field := range tags["preload=true"] {
+//		fmt.Println(field, fields[field].Type, fields[field].Tag.Get("json"))
+//	}
+func (t TaGo) FieldMap(model interface{}, separator string, opts ...Option) map[FieldName]reflect.StructField {
+	fields := make(map[FieldName]reflect.StructField)
+
+	o := newOptions(separator, -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		fields[path] = field
+	}
+	t.mustParseModel(model, o)
+
+	return fields
+}
+
+// RawTags returns the raw tag of every field of a model, nested fields included, by path (empty for untagged fields),
+// for consumers who want the traversal and prefixing of GetNested but their own parsing.
+// Tags registered with RegisterExternal and overlays are merged like during parsing.
+//
+// Example:
+//
+//	t.RawTags(&User{}, ".") // map[Address:preload=true Address.City:column=city Name:]
+func (t TaGo) RawTags(model interface{}, separator string, opts ...Option) map[FieldName]string {
+	tags := make(map[FieldName]string)
+
+	o := newOptions(separator, -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		tags[path] = t.rawTag(field, owner)
+	}
+	t.mustParseModel(model, o)
+
+	return tags
+}
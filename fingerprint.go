@@ -0,0 +1,43 @@
+package tago
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+)
+
+// Fingerprint returns a stable hash (hex encoded SHA-256) of the tag structure of a model: the path, type and
+// instructions, in tag order, of every tagged field at any depth, along with the tag name.
+// It changes whenever an annotation of the model changes, and only then: services can compare it with the one
+// stored next to their schema metadata at startup, to invalidate caches or trigger migrations.
+//
+// Example:
+//
+//	if t.Fingerprint(&User{}) != stored.Fingerprint {
+//		migrate()
+//	}
+func (t TaGo) Fingerprint(model interface{}, opts ...Option) string {
+	hash := sha256.New()
+	write := func(s string) {
+		// Length-prefixed, so that the boundaries between strings are part of the hash
+		hash.Write([]byte{byte(len(s) >> 24), byte(len(s) >> 16), byte(len(s) >> 8), byte(len(s))})
+		hash.Write([]byte(s))
+	}
+	write(t.Name)
+
+	o := newOptions(".", -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		if len(instructions) == 0 {
+			return
+		}
+		write(path.String())
+		write(field.Type.String())
+		for _, instruction := range instructions {
+			write(string(instruction))
+		}
+		write("")
+	}
+	t.mustParseModel(model, o)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
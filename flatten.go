@@ -0,0 +1,228 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// Flatten converts a populated model to a flat map of dotted keys to values, for flat stores like Redis hashes.
+// Keys are made of the `name=` instruction of the fields (their Go name by default), fields tagged "-" are skipped.
+// Nested structs are flattened, nil pointers to structs and func or chan fields are omitted, other values (slices, maps, time.Time and the
+// other leaf types) are kept as is, unless their field has a format= instruction or their type a Formatter: they are
+// then formatted to strings, see FormatValue. See Unflatten for the reverse.
+//
+// Example:
+//
+//	type User struct {
+//		Name    string  `gorm2:"name=name"`
+//		Address Address `gorm2:"name=address"` // City string `gorm2:"name=city"`
+//	}
+//	t.Flatten(user, ".") // map[address.city:Paris name:Bob]
+//
+// Invalid formats are reported as warnings (see OnWarning), the value is then kept as is.
+func (t TaGo) Flatten(model interface{}, separator string) map[string]any {
+	flat := make(map[string]any)
+	value := structValue(reflect.ValueOf(model))
+	if !value.IsValid() {
+		return flat
+	}
+	t.walkFlat(value, "", separator, "name", make(typeFields), map[reflect.Type]bool{}, func(key string, owner reflect.Type, value reflect.Value, instructions []Instruction, nested bool) bool {
+		switch {
+		case nested:
+			return true
+		case value.Kind() == reflect.Ptr:
+			flat[key] = nil
+			return false
+		}
+
+		flat[key] = value.Interface()
+		if layout, _ := lookupKey(instructions, "format"); t.formatted(value.Type(), layout) {
+			formatted, err := t.FormatValue(value, layout)
+			if err != nil {
+				t.warn(Warning{Type: owner, Field: FieldName(key), Message: err.Error()})
+				return false
+			}
+			flat[key] = formatted
+		}
+		return false
+	})
+	return flat
+}
+
+// Visit the fields of a struct value by flat key, made of the by= instruction of the fields (see fieldKey).
+// visit is called with nested set for the struct fields, and traverses them if it returns true, then for the other
+// fields with their value, pointers dereferenced (a nil pointer if one is on the way), and their instructions.
+// Nil pointers to structs, and func or chan fields, are skipped.
+func (t TaGo) walkFlat(value reflect.Value, prefix string, separator string, by string, fields typeFields, visiting map[reflect.Type]bool, visit func(key string, owner reflect.Type, value reflect.Value, instructions []Instruction, nested bool) bool) {
+	typ := value.Type()
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i, instructions := range t.fieldsOf(fields, typ) {
+		field := typ.Field(i)
+		name, skipped := fieldKey(field, instructions, by)
+		if !field.IsExported() || skipped || isFuncOrChan(field.Type) {
+			continue
+		}
+		key := prefix + name
+
+		nested := value.Field(i)
+		for nested.Kind() == reflect.Ptr && !nested.IsNil() {
+			nested = nested.Elem()
+		}
+
+		switch {
+		case nested.Kind() == reflect.Ptr && nested.Type().Elem().Kind() == reflect.Struct && !t.isIgnored(nested.Type().Elem()):
+			// Nil pointer to a struct: nothing to flatten
+		case nested.Kind() == reflect.Struct && !t.isIgnored(nested.Type()) && !visiting[nested.Type()]:
+			if visit(key, typ, nested, instructions, true) {
+				t.walkFlat(nested, key+separator, separator, by, fields, visiting, visit)
+			}
+		default:
+			visit(key, typ, nested, instructions, false)
+		}
+	}
+}
+
+// Unflatten sets the fields of a model from a flat map of dotted keys, the reverse of Flatten.
+// Values are assigned directly when their type matches, converted when possible (float64 to int, ..) or parsed from strings,
+// times with the layout of their format= instruction.
+// Pointers are allocated as needed. model must be a non-nil pointer to a struct; unknown keys and invalid values are reported.
+//
+// Example:
+//
+//	var user User
+//	err := t.Unflatten(map[string]any{"name": "Bob", "address.city": "Paris"}, ".", &user)
+func (t TaGo) Unflatten(flat map[string]any, separator string, model interface{}) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+	root := reflect.ValueOf(model).Elem()
+
+	// Sorted keys so errors are reported in a stable order
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	fields := make(typeFields)
+	for _, key := range keys {
+		fieldValue, instructions, err := t.resolveKey(root, key, separator, "name", fields, true)
+		if err == nil {
+			layout, _ := lookupKey(instructions, "format")
+			err = assign(fieldValue, flat[key], layout)
+		}
+		if err != nil {
+			errs = append(errs, &PathError{Path: FieldName(key), Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Name of a field in flat keys (its by= instruction, name= for Flatten, or its Go name), and whether it's skipped ("-")
+func fieldKey(field reflect.StructField, instructions []Instruction, by string) (string, bool) {
+	if slices.Contains(instructions, Skip) {
+		return "", true
+	}
+	if key, _ := lookupKey(instructions, by); key != "" {
+		return key, key == "-"
+	}
+	return field.Name, false
+}
+
+// Find the field of a struct value at a dotted key made of field keys (see fieldKey), and its instructions.
+// Nil pointers on the way are allocated if allocate is set, otherwise a detached zero value stands for them.
+func (t TaGo) resolveKey(root reflect.Value, key string, separator string, by string, fields typeFields, allocate bool) (reflect.Value, []Instruction, error) {
+	segments := []string{key}
+	if separator != "" {
+		segments = strings.Split(key, separator)
+	}
+
+	value := root
+	var found []Instruction
+	for i, segment := range segments {
+		for value.Kind() == reflect.Ptr {
+			switch {
+			case !value.IsNil():
+			case allocate:
+				value.Set(reflect.New(value.Type().Elem()))
+			default:
+				value = reflect.New(value.Type().Elem())
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, nil, fmt.Errorf("%s is not a struct", strings.Join(segments[:i], separator))
+		}
+
+		owner := value.Type()
+		index := -1
+		for j, instructions := range t.fieldsOf(fields, owner) {
+			field := owner.Field(j)
+			if name, skipped := fieldKey(field, instructions, by); field.IsExported() && !skipped && name == segment {
+				index, found = j, instructions
+				break
+			}
+		}
+		if index < 0 {
+			return reflect.Value{}, nil, ErrUnknownField
+		}
+		value = value.Field(index)
+	}
+	return value, found, nil
+}
+
+// Assign v to a settable value, converting it if needed. layout is the time layout of strings parsed to times.
+func assign(dst reflect.Value, v any, layout string) error {
+	if isFuncOrChan(dst.Type()) {
+		return fmt.Errorf("%s is a func or chan, it can't be set", dst.Type())
+	}
+	if v == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	src := reflect.ValueOf(v)
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case dst.Kind() == reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), v, layout)
+	case src.Kind() == reflect.String:
+		return convert.Set(dst, src.String(), layout)
+	case dst.Kind() == reflect.String && convert.IsScalar(src.Type()):
+		formatted, err := convert.Format(src, layout)
+		if err != nil {
+			return err
+		}
+		dst.SetString(formatted)
+	case src.Type().ConvertibleTo(dst.Type()) && isNumberKind(src.Kind()) == isNumberKind(dst.Kind()):
+		dst.Set(src.Convert(dst.Type()))
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := assign(slice.Index(i), src.Index(i).Interface(), layout); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	default:
+		return fmt.Errorf("cannot assign %s to %s", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
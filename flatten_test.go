@@ -0,0 +1,73 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type flattenAddress struct {
+	City string `gorm2:"name=city;legacy"`
+}
+
+type flattenUser struct {
+	Name     string          `gorm2:"name=name"`
+	Born     time.Time       `gorm2:"name=born;format=2006-01-02"`
+	Address  flattenAddress  `gorm2:"name=address"`
+	Shipping *flattenAddress `gorm2:"name=shipping"`
+	Secret   string          `gorm2:"-"`
+	Hidden   string          `gorm2:"name=-"`
+	Tags     []string
+}
+
+func TestFlatten(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	user := flattenUser{
+		Name:    "Bob",
+		Born:    time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC),
+		Address: flattenAddress{City: "Paris"},
+		Secret:  "secret",
+		Hidden:  "hidden",
+		Tags:    []string{"a"},
+	}
+	want := map[string]any{"name": "Bob", "born": "1990-05-01", "address.city": "Paris", "Tags": []string{"a"}}
+	if got := tg.Flatten(&user, "."); !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten = %v, want %v", got, want)
+	}
+
+	var back flattenUser
+	if err := tg.Unflatten(map[string]any{"name": "Bob", "born": "1990-05-01", "address.city": "Paris", "shipping.city": "Lyon"}, ".", &back); err != nil {
+		t.Fatalf("Unflatten: %v", err)
+	}
+	if back.Name != "Bob" || !back.Born.Equal(user.Born) || back.Address.City != "Paris" || back.Shipping == nil || back.Shipping.City != "Lyon" {
+		t.Errorf("Unflatten = %+v", back)
+	}
+
+	for _, key := range []string{"Secret", "Hidden", "-", "address.missing"} {
+		if err := tg.Unflatten(map[string]any{key: "x"}, ".", &back); !errors.Is(err, ErrUnknownField) {
+			t.Errorf("Unflatten(%q): error = %v, want ErrUnknownField", key, err)
+		}
+	}
+}
+
+func TestFlattenWarnsOnce(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	warnings := 0
+	tg.Deprecate("legacy", "").OnWarning(func(Warning) { warnings++ })
+
+	// The fields of a type are parsed once per call, whatever the number of keys
+	tg.Flatten(&flattenUser{Shipping: &flattenAddress{}}, ".")
+	if warnings != 1 {
+		t.Errorf("Flatten: %d warnings, want 1", warnings)
+	}
+
+	warnings = 0
+	if err := tg.Unflatten(map[string]any{"address.city": "Paris", "shipping.city": "Lyon"}, ".", &flattenUser{}); err != nil {
+		t.Fatalf("Unflatten: %v", err)
+	}
+	if warnings != 1 {
+		t.Errorf("Unflatten: %d warnings, want 1", warnings)
+	}
+}
@@ -0,0 +1,115 @@
+package tago
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys returns the instructions sorted alphabetically, so output doesn't depend on map iteration order
+func (t Instructions) Keys() []Instruction {
+	keys := make([]Instruction, 0, len(t))
+	for key := range t {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// String returns a stable, single-line representation of the instructions.
+// Instructions are sorted alphabetically, fields keep their discovery order.
+//
+// Example:
+//
+//	fmt.Println(tags) // map[otherOption=value:[Field1] preload=true:[Field1 Field3]]
+func (t Instructions) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	for i, key := range t.Keys() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(key))
+		b.WriteString(":[")
+		for j, field := range t[key] {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(field.String())
+		}
+		b.WriteByte(']')
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// GoString returns a Go-syntax representation of the instructions (used by %#v), sorted like String
+//
+// Example:
+//
+//	fmt.Printf("%#v", tags) // tago.Instructions{"preload=true": {"Field1", "Field3"}}
+func (t Instructions) GoString() string {
+	var b strings.Builder
+	b.WriteString("tago.Instructions{")
+	for i, key := range t.Keys() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(string(key)))
+		b.WriteString(": {")
+		for j, field := range t[key] {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.Quote(field.String()))
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Pretty returns a multi-line representation of the instructions, one instruction per line
+// followed by its fields, indented. Handy for debugging and golden files.
+//
+// Example:
+//
+//	otherOption=value
+//	    Field1
+//	preload=true
+//	    Field1
+//	    Field3
+func (t Instructions) Pretty() string {
+	var b strings.Builder
+	for _, key := range t.Keys() {
+		b.WriteString(string(key))
+		b.WriteByte('\n')
+		for _, field := range t[key] {
+			b.WriteString("    ")
+			b.WriteString(field.String())
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// Format implements fmt.Formatter so that printing instructions is always deterministic
+//
+//	%v, %s  -> String()
+//	%+v     -> Pretty() (multi-line)
+//	%#v     -> GoString()
+func (t Instructions) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, t.GoString())
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprint(f, t.Pretty())
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(f, t.String())
+	case verb == 'q':
+		fmt.Fprint(f, strconv.Quote(t.String()))
+	default:
+		fmt.Fprintf(f, "%%!%c(tago.Instructions=%s)", verb, t.String())
+	}
+}
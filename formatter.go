@@ -0,0 +1,68 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// Formatter formats the values of a type for the value-oriented features (Flatten, tagocsv, ..).
+// layout is the value of the format= instruction of the field, empty if it has none.
+type Formatter func(value reflect.Value, layout string) (string, error)
+
+// RegisterFormatter registers the formatter of a type, used instead of the default formatting of its values.
+// Formatting is one-way: to read the values back (Unflatten, tagocsv), the type must implement encoding.TextUnmarshaler.
+//
+// Example:
+//
+//	t.RegisterFormatter(reflect.TypeOf(Money{}), func(v reflect.Value, layout string) (string, error) {
+//		m := v.Interface().(Money)
+//		return fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency), nil
+//	})
+func (t *TaGo) RegisterFormatter(typ reflect.Type, formatter Formatter) *TaGo {
+	if t.formatters == nil {
+		t.formatters = make(map[reflect.Type]Formatter)
+	}
+	t.formatters[typ] = formatter
+	return t
+}
+
+// FormatValue returns the string representation of a leaf value, given the format= instruction of its field:
+//
+//	registered type     its Formatter (see RegisterFormatter)
+//	time.Time           Go time layout, format=2006-01-02 (default: time.RFC3339)
+//	numbers             fmt verb, format=%.2f or format=%06d (default: shortest representation)
+//	other values        as for the value-oriented features, the layout is ignored
+//
+// Nil pointers are formatted as an empty string.
+func (t TaGo) FormatValue(value reflect.Value, layout string) (string, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	if formatter, exists := t.formatters[value.Type()]; exists {
+		return formatter(value, layout)
+	}
+	if layout != "" && isNumberKind(value.Kind()) {
+		if !strings.Contains(layout, "%") {
+			return "", fmt.Errorf("invalid number format %q, expected a fmt verb like %%.2f", layout)
+		}
+		return fmt.Sprintf(layout, value.Interface()), nil
+	}
+	return convert.Format(value, layout)
+}
+
+// Check whether the values of a type are formatted by FormatValue rather than kept as is
+func (t TaGo) formatted(typ reflect.Type, layout string) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	_, exists := t.formatters[typ]
+	return exists || layout != "" && (isNumberKind(typ.Kind()) || typ == reflect.TypeOf(time.Time{}))
+}
@@ -0,0 +1,100 @@
+package tago
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Grammar of a tag value (the part between the quotes of `name:"..."`), in EBNF:
+//
+//	tag         = [ instruction ] { ";" [ instruction ] } .
+//	instruction = key [ "=" value ] .
+//	key         = { keychar } .                  (surrounding white space is ignored)
+//	value       = { valuechar } .                (surrounding white space is ignored, may contain "=")
+//	keychar     = escape | char - ( ";" | "=" ) .
+//	valuechar   = escape | char - ";" .
+//	escape      = "\" ( ";" | "\" ) .            (a backslash before any other char is kept as is)
+//
+// Struct tags are Go string literals, so the backslash of an escape is doubled in the source: `gorm2:"sep=\\;"`.
+//
+// Empty instructions (stray or trailing ";") are ignored. An instruction without value is a flag,
+// its value is "true" (see Instruction.Value). Invalid UTF-8 is kept as is.
+// A key can be namespaced with a prefix, "db:index=true;api:readOnly", and the instructions of a namespace grouped
+// with commas, "db:index=true,unique", see Instructions.Namespace.
+
+// Instruction of a tag, as read by parseTag
+type tagPart struct {
+	Key      string
+	Value    string
+	HasValue bool
+
+	// Byte offset of the instruction in the tag
+	Pos int
+}
+
+// Escape the separators and backslashes of an instruction, the reverse of parseTag
+func escapeTagValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`).Replace(s)
+}
+
+// Instruction string of the part: key=value, or key for a flag
+func (p tagPart) instruction() string {
+	if !p.HasValue {
+		return p.Key
+	}
+	return p.Key + "=" + p.Value
+}
+
+// Parse a tag value according to the grammar above
+func parseTag(tag string) []tagPart {
+	parts := make([]tagPart, 0, strings.Count(tag, ";")+1)
+
+	var current strings.Builder
+	part := tagPart{}
+	inValue := false
+
+	// End the current instruction, ignoring it if empty
+	flush := func() {
+		if inValue {
+			part.Value = strings.TrimSpace(current.String())
+		} else {
+			part.Key = strings.TrimSpace(current.String())
+		}
+		if part.Key != "" || part.HasValue {
+			parts = append(parts, part)
+		}
+		current.Reset()
+		inValue = false
+	}
+
+	for i := 0; i < len(tag); {
+		r, size := utf8.DecodeRuneInString(tag[i:])
+		if r == utf8.RuneError && size <= 1 {
+			// Keep invalid bytes untouched
+			current.WriteByte(tag[i])
+			i++
+			continue
+		}
+
+		switch {
+		case r == '\\' && i+1 < len(tag) && (tag[i+1] == ';' || tag[i+1] == '\\'):
+			current.WriteByte(tag[i+1])
+			i += 2
+			continue
+		case r == ';':
+			flush()
+			part = tagPart{Pos: i + 1}
+		case r == '=' && !inValue:
+			part.Key = strings.TrimSpace(current.String())
+			part.HasValue = true
+			current.Reset()
+			inValue = true
+		default:
+			current.WriteRune(r)
+		}
+		i += size
+	}
+	flush()
+
+	return parts
+}
@@ -0,0 +1,119 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want []tagPart
+	}{
+		{"empty", "", []tagPart{}},
+		{"only separators", ";;;", []tagPart{}},
+		{"white space", "  ;\t; ", []tagPart{}},
+		{"flag", "unique", []tagPart{{Key: "unique"}}},
+		{"instruction", "column=email", []tagPart{{Key: "column", Value: "email", HasValue: true}}},
+		{"empty value", "default=", []tagPart{{Key: "default", HasValue: true}}},
+		{"empty key", "=value", []tagPart{{Value: "value", HasValue: true}}},
+		{"stray separators", ";column=email;;unique;", []tagPart{
+			{Key: "column", Value: "email", HasValue: true, Pos: 1},
+			{Key: "unique", Pos: 15},
+		}},
+		{"surrounding white space", " column = email ; unique ", []tagPart{
+			{Key: "column", Value: "email", HasValue: true},
+			{Key: "unique", Pos: 17},
+		}},
+		{"inner white space", "default=hello world", []tagPart{{Key: "default", Value: "hello world", HasValue: true}}},
+		{"equal sign in value", "check=a=b", []tagPart{{Key: "check", Value: "a=b", HasValue: true}}},
+		{"duplicates", "unique;unique", []tagPart{{Key: "unique"}, {Key: "unique", Pos: 7}}},
+		{"escaped separator", `sep=\;;unique`, []tagPart{{Key: "sep", Value: ";", HasValue: true}, {Key: "unique", Pos: 7}}},
+		{"escaped backslash", `path=a\\;unique`, []tagPart{{Key: "path", Value: `a\`, HasValue: true}, {Key: "unique", Pos: 9}}},
+		{"escaped backslash then separator", `path=\\\;`, []tagPart{{Key: "path", Value: `\;`, HasValue: true}}},
+		{"other backslash", `regex=\d+`, []tagPart{{Key: "regex", Value: `\d+`, HasValue: true}}},
+		{"trailing backslash", `path=a\`, []tagPart{{Key: "path", Value: `a\`, HasValue: true}}},
+		{"escaped separator in key", `a\;b=c`, []tagPart{{Key: "a;b", Value: "c", HasValue: true}}},
+		{"unicode", "label=café;ключ=значение", []tagPart{
+			{Key: "label", Value: "café", HasValue: true},
+			{Key: "ключ", Value: "значение", HasValue: true, Pos: 12},
+		}},
+		{"invalid UTF-8", "label=\xff\xfe;\xc3", []tagPart{
+			{Key: "label", Value: "\xff\xfe", HasValue: true},
+			{Key: "\xc3", Pos: 9},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseTag(test.tag); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseTag(%q) = %+v, want %+v", test.tag, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseDuplicateInstructions(t *testing.T) {
+	type model struct {
+		Email string `gorm2:"unique;column=email;unique; column = email "`
+	}
+
+	tg := TaGo{Name: "gorm2"}
+	want := Instructions{"unique": {"Email"}, "column=email": {"Email"}}
+	if got := tg.Get(&model{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Get = %v, want %v", got, want)
+	}
+}
+
+func FuzzTagParts(f *testing.F) {
+	for _, tag := range []string{
+		"",
+		";;",
+		"column=email;unique",
+		" column = email ; index=idx,priority:1 ",
+		`sep=\;;path=a\\;regex=\d+`,
+		"db:index=true,unique;api:readOnly",
+		"label=café;\xff\xfe=\xc3",
+		`trailing\`,
+	} {
+		f.Add(tag)
+	}
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		parts := parseTag(tag)
+
+		var serialized []string
+		for _, part := range parts {
+			if part.Key == "" && !part.HasValue {
+				t.Errorf("parseTag(%q) returned an empty instruction at %d", tag, part.Pos)
+			}
+			if part.Key != strings.TrimSpace(part.Key) || part.Value != strings.TrimSpace(part.Value) {
+				t.Errorf("parseTag(%q) returned untrimmed %+v", tag, part)
+			}
+			if strings.Contains(part.Key, "=") {
+				t.Errorf("parseTag(%q) returned the key %q containing =", tag, part.Key)
+			}
+			if part.Pos < 0 || part.Pos > len(tag) {
+				t.Errorf("parseTag(%q) returned the position %d out of the tag", tag, part.Pos)
+			}
+
+			instruction := escapeTagValue(part.Key)
+			if part.HasValue {
+				instruction += "=" + escapeTagValue(part.Value)
+			}
+			serialized = append(serialized, instruction)
+		}
+
+		// The parts written back with escapes parse the same
+		again := parseTag(strings.Join(serialized, ";"))
+		if len(again) != len(parts) {
+			t.Fatalf("parseTag(%q) = %+v, parsed back as %+v", tag, parts, again)
+		}
+		for i := range parts {
+			if again[i].Key != parts[i].Key || again[i].Value != parts[i].Value || again[i].HasValue != parts[i].HasValue {
+				t.Errorf("parseTag(%q) = %+v, parsed back as %+v", tag, parts[i], again[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+)
+
+// HasKey checks if an instruction with the given key exists on the top-level fields of a model, whatever its value
+func (t TaGo) HasKey(model interface{}, key string) bool {
+	if found, ok := t.scanTags(model, func(k string, _ string, _ bool) bool { return k == key }); ok {
+		return found
+	}
+
+	key = t.normalizeInstruction(Instruction(key)).Key()
+	for instruction := range t.Get(model) {
+		if instruction.Key() == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan the instructions of the top-level fields of a model without building Instructions, returning early on the first match.
+// ok is false when the fast path can't give the same result as Get: the TaGo has a custom Parser, rewrites instructions (aliases,
+// variables, normalizers, ..), reports parses or warnings, or the model isn't a struct.
+func (t TaGo) scanTags(model interface{}, match func(key string, value string, hasValue bool) bool) (found bool, ok bool) {
+	if t.parser != nil || len(t.aliases) > 0 || len(t.normalizers) > 0 || t.caseInsensitive || len(t.deprecated) > 0 || t.resolver != nil ||
+		t.hooks != nil || t.logger != nil || t.onWarning != nil || model == nil {
+		return false, false
+	}
+
+	modelType := typeToElem(reflect.TypeOf(model))
+	if modelType.Kind() != reflect.Struct {
+		return false, false
+	}
+
+	for i := 0; i < modelType.NumField(); i++ {
+		tag := t.rawTag(modelType.Field(i), modelType)
+		if tag == "" {
+			continue
+		}
+		if strings.TrimSpace(tag) == string(Skip) {
+			if match(string(Skip), "", false) {
+				return true, true
+			}
+			continue
+		}
+
+		// Escapes need the full parser
+		if strings.IndexByte(tag, '\\') >= 0 {
+			for _, part := range expandNamespaceGroups(parseTag(tag)) {
+				if match(part.Key, part.Value, part.HasValue) {
+					return true, true
+				}
+			}
+			continue
+		}
+
+		// Instructions are substrings of the tag: no allocation
+		for tag != "" {
+			part, rest, _ := strings.Cut(tag, ";")
+			tag = rest
+
+			key, value, hasValue := strings.Cut(part, "=")
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if key == "" && !hasValue {
+				continue
+			}
+			// Namespace groups (db:index=true,unique) are expanded by the parser
+			if strings.Contains(key, ":") && strings.Contains(part, ",") {
+				return false, false
+			}
+			if match(key, value, hasValue) {
+				return true, true
+			}
+		}
+	}
+	return false, true
+}
@@ -0,0 +1,128 @@
+package tago
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Describe documents an instruction key, for Help and HelpFor. Aliases are documented under their canonical key.
+//
+// Example:
+//
+//	t.Describe("preload", "eagerly load the relation").
+//		Describe("column", "name of the column, default: the field name in snake_case")
+func (t *TaGo) Describe(key string, description string) *TaGo {
+	if t.descriptions == nil {
+		t.descriptions = make(map[string]string)
+	}
+	t.descriptions[t.descriptionKey(key)] = description
+	return t
+}
+
+// Key of an instruction key in the descriptions: canonical, and lower-cased when case-insensitive
+func (t TaGo) descriptionKey(key string) string {
+	return t.normalizeInstruction(Instruction(t.canonicalKey(key))).Key()
+}
+
+// Description returns the description of an instruction key, and whether it is documented
+func (t TaGo) Description(key string) (string, bool) {
+	description, exists := t.descriptions[t.descriptionKey(key)]
+	return description, exists
+}
+
+// Help returns the documentation of every described instruction key, sorted by key, one per line.
+// The aliases and deprecation hints of the keys are listed along with their description.
+//
+// Example:
+//
+//	fmt.Print(t.Help())
+//	// column   name of the column, default: the field name in snake_case
+//	// preload  eagerly load the relation (alias: eager)
+func (t TaGo) Help() string {
+	keys := make([]string, 0, len(t.descriptions))
+	for key := range t.descriptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\n", key, t.describeKey(key))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// HelpFor returns the documentation of the instruction keys a model uses, nested fields included, sorted by key:
+// the description of each key (or "undocumented") and the fields using it.
+//
+// Example:
+//
+//	fmt.Print(t.HelpFor(&User{}))
+//	// column   name of the column, default: the field name in snake_case  Address.City, Email
+//	// preload  eagerly load the relation (alias: eager)                   Address
+//	// size     undocumented                                               Email
+func (t TaGo) HelpFor(model interface{}, opts ...Option) string {
+	fields := make(map[string]map[FieldName]bool)
+	for instruction, names := range t.GetNested(model, ".", opts...) {
+		if instruction == Skip {
+			continue
+		}
+		key := t.descriptionKey(instruction.Key())
+		if fields[key] == nil {
+			fields[key] = make(map[FieldName]bool)
+		}
+		for _, name := range names {
+			fields[key][name] = true
+		}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		names := make([]string, 0, len(fields[key]))
+		for name := range fields[key] {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", key, t.describeKey(key), strings.Join(names, ", "))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// Description of a key with its aliases and deprecation hint
+func (t TaGo) describeKey(key string) string {
+	description, exists := t.descriptions[key]
+	if !exists {
+		description = "undocumented"
+	}
+
+	var aliases []string
+	for alias, canonical := range t.aliases {
+		if t.descriptionKey(canonical) == key {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) > 0 {
+		sort.Strings(aliases)
+		description += " (alias: " + strings.Join(aliases, ", ") + ")"
+	}
+	if hint, deprecated := t.deprecated[key]; deprecated {
+		description += " (deprecated"
+		if hint != "" {
+			description += ": " + hint
+		}
+		description += ")"
+	}
+	return description
+}
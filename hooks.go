@@ -0,0 +1,65 @@
+package tago
+
+import (
+	"reflect"
+	"time"
+)
+
+// Hooks are notified of every parse (Get, GetNested, ..) and apply (Apply, ApplyOne, ..),
+// so services can export metrics about tag-driven processing, e.g. with Prometheus.
+//
+// Example:
+//
+//	type metrics struct{}
+//
+//	func (metrics) OnParse(e tago.ParseEvent) {
+//		parseDuration.WithLabelValues(e.Type.Name()).Observe(e.Duration.Seconds())
+//		parsedFields.WithLabelValues(e.Type.Name()).Add(float64(e.Fields))
+//	}
+//	func (metrics) OnApply(e tago.ApplyEvent) {
+//		applyCalls.Add(float64(e.Calls))
+//	}
+//
+//	t.SetHooks(metrics{})
+type Hooks interface {
+	OnParse(ParseEvent)
+	OnApply(ApplyEvent)
+}
+
+// ParseEvent is reported to Hooks.OnParse once a model has been parsed
+type ParseEvent struct {
+	// Parsed model type
+	Type reflect.Type
+
+	Duration time.Duration
+
+	// Number of fields visited, nested fields included
+	Fields int
+
+	// Number of distinct instructions found
+	Instructions int
+}
+
+// ApplyEvent is reported to Hooks.OnApply once instructions have been applied
+type ApplyEvent struct {
+	Duration time.Duration
+
+	// Number of handlers given (instructions of the mapping)
+	Handlers int
+
+	// Number of handler calls, one per field
+	Calls int
+}
+
+// SetHooks registers the hooks notified of every parse and apply, nil to remove them
+func (t *TaGo) SetHooks(hooks Hooks) *TaGo {
+	t.hooks = hooks
+	return t
+}
+
+// Report an apply to the hooks, if any
+func (t TaGo) reportApply(handlers int, calls int, start time.Time) {
+	if t.hooks != nil {
+		t.hooks.OnApply(ApplyEvent{Duration: time.Since(start), Handlers: handlers, Calls: calls})
+	}
+}
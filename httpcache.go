@@ -0,0 +1,143 @@
+package tago
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl returns the Cache-Control header value of a response model from its struct level instructions, declared
+// on a blank field (`_ struct{}`) of the model:
+//
+//	cache=public                 public, private, no-cache or no-store
+//	maxAge=300                   max-age, in seconds or as a duration (5m)
+//	sMaxAge=1h                   s-maxage, idem
+//	staleWhileRevalidate=30      stale-while-revalidate, idem
+//	mustRevalidate / immutable   the directives of the same name
+//
+// It returns "" if the model declares no caching policy. Invalid instructions are reported as joined *PathError.
+//
+// Example:
+//
+//	type ProductResponse struct {
+//		_     struct{} `api:"cache=public;maxAge=5m;staleWhileRevalidate=30"`
+//		ID    int64    `api:"etag"`
+//		Price int64    `api:"etag"`
+//	}
+//	header, err := t.CacheControl(&resp) // public, max-age=300, stale-while-revalidate=30
+//	w.Header().Set("Cache-Control", header)
+func (t TaGo) CacheControl(model interface{}) (string, error) {
+	if model == nil || typeToElem(reflect.TypeOf(model)).Kind() != reflect.Struct {
+		return "", notStruct(fmt.Sprintf("%T", model))
+	}
+	typ := typeToElem(reflect.TypeOf(model))
+
+	var directives []string
+	var errs []error
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+
+		for _, instruction := range t.parseInstructions(field, typ, FieldName(field.Name)) {
+			directive, err := cacheDirective(instruction)
+			if err != nil {
+				errs = append(errs, &PathError{Path: FieldName(field.Name), Instruction: instruction, Err: err})
+				continue
+			}
+			if directive != "" {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return strings.Join(directives, ", "), errors.Join(errs...)
+}
+
+// Cache-Control directive of an instruction, empty if the instruction isn't a caching one
+func cacheDirective(instruction Instruction) (string, error) {
+	switch key, value := instruction.Key(), instruction.Value(); key {
+	case "cache":
+		switch value {
+		case "public", "private", "no-cache", "no-store":
+			return value, nil
+		}
+		return "", fmt.Errorf("invalid cache %q, expected public, private, no-cache or no-store", value)
+	case "maxAge", "sMaxAge", "staleWhileRevalidate":
+		seconds, err := cacheSeconds(value)
+		if err != nil {
+			return "", err
+		}
+		names := map[string]string{"maxAge": "max-age", "sMaxAge": "s-maxage", "staleWhileRevalidate": "stale-while-revalidate"}
+		return names[key] + "=" + strconv.FormatInt(seconds, 10), nil
+	case "mustRevalidate", "immutable":
+		if flag, err := strconv.ParseBool(value); err != nil || !flag {
+			return "", err
+		}
+		if key == "mustRevalidate" {
+			return "must-revalidate", nil
+		}
+		return key, nil
+	}
+	return "", nil
+}
+
+// Number of seconds of a cache duration, given in seconds or as a time.ParseDuration value
+func cacheSeconds(value string) (int64, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected seconds or a duration like 5m", value)
+	}
+	return int64(duration / time.Second), nil
+}
+
+// ETag returns a strong ETag (quoted) of a populated model: a hash of the paths and JSON encoded values of its fields
+// tagged etag=true (or etag), nested fields included, so that it changes whenever one of these values changes.
+// Fields behind a nil pointer hash as null. It returns "" if no field is tagged.
+//
+// Example:
+//
+//	etag, err := t.ETag(&resp)
+//	if r.Header.Get("If-None-Match") == etag {
+//		w.WriteHeader(http.StatusNotModified)
+//		return
+//	}
+//	w.Header().Set("ETag", etag)
+func (t TaGo) ETag(model interface{}, opts ...Option) (string, error) {
+	if model == nil || typeToElem(reflect.TypeOf(model)).Kind() != reflect.Struct {
+		return "", notStruct(fmt.Sprintf("%T", model))
+	}
+
+	hash := sha256.New()
+	tagged := false
+	var errs []error
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if etag, exists := lookupKey(ctx.Instructions, "etag"); !exists || etag == "false" {
+			return
+		}
+		tagged = true
+
+		encoded := []byte("null")
+		if ctx.Value.IsValid() && ctx.Value.CanInterface() {
+			var err error
+			if encoded, err = json.Marshal(ctx.Value.Interface()); err != nil {
+				errs = append(errs, &PathError{Path: ctx.Path, Instruction: "etag", Err: err})
+				return
+			}
+		}
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(ctx.Path), ctx.Path, len(encoded), encoded)
+	})
+	if !tagged || len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`, nil
+}
@@ -0,0 +1,57 @@
+package tago
+
+import (
+	"errors"
+	"testing"
+)
+
+type cachedProduct struct {
+	_     struct{} `api:"cache=public;maxAge=5m;staleWhileRevalidate=30;immutable"`
+	ID    int64    `api:"etag"`
+	Price int64    `api:"etag"`
+	Name  string
+}
+
+type cachedInvalid struct {
+	_ struct{} `api:"cache=everyone;maxAge=soon;legacy"`
+}
+
+func TestCacheControl(t *testing.T) {
+	tg := TaGo{Name: "api"}
+	warnings := 0
+	tg.Deprecate("legacy", "").OnWarning(func(Warning) { warnings++ })
+
+	header, err := tg.CacheControl(&cachedProduct{})
+	if want := "public, max-age=300, stale-while-revalidate=30, immutable"; err != nil || header != want {
+		t.Errorf("CacheControl = %q, %v, want %q", header, err, want)
+	}
+
+	// Invalid instructions are reported, the warnings of the tag too
+	_, err = tg.CacheControl(cachedInvalid{})
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) || pathErr.Instruction != "cache=everyone" {
+		t.Errorf("CacheControl error = %v, want *PathError on cache=everyone", err)
+	}
+	if warnings != 1 {
+		t.Errorf("CacheControl: %d warnings, want 1", warnings)
+	}
+
+	if _, err := tg.CacheControl(42); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("int model: error = %v, want ErrNotStruct", err)
+	}
+}
+
+func TestETag(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	etag, err := tg.ETag(&cachedProduct{ID: 1, Price: 10, Name: "a"})
+	if err != nil || etag == "" {
+		t.Fatalf("ETag = %q, %v", etag, err)
+	}
+	if same, _ := tg.ETag(&cachedProduct{ID: 1, Price: 10, Name: "b"}); same != etag {
+		t.Errorf("ETag changed with a field not tagged etag: %s != %s", same, etag)
+	}
+	if other, _ := tg.ETag(&cachedProduct{ID: 1, Price: 11}); other == etag {
+		t.Errorf("ETag didn't change with a field tagged etag")
+	}
+}
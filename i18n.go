@@ -0,0 +1,62 @@
+package tago
+
+import (
+	"reflect"
+	"sort"
+)
+
+// I18nKeys returns the catalog of the translation keys of a model: the values of its i18n=key.path instructions,
+// at any depth, sorted and without duplicates. It is computed from the type only, to feed translation files.
+//
+// Example:
+//
+//	type Product struct {
+//		Status string `api:"i18n=product.status"`
+//		Error  string `api:"i18n=errors.product"`
+//	}
+//	keys := t.I18nKeys(&Product{}) // [errors.product product.status]
+func (t TaGo) I18nKeys(model interface{}, opts ...Option) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+
+	o := newOptions(".", -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		key, exists := lookupKey(instructions, "i18n")
+		if exists && key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	t.mustParseModel(model, o)
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Translate replaces, in place, the value of the string fields of a model tagged i18n=key.path with the result of
+// translate, nested fields included. translate receives the key and the current value, which it can use as a fallback
+// or as a message argument; pointers to strings and slices of strings are translated element by element.
+// model must be a non-nil pointer to a struct.
+//
+// Example:
+//
+//	err := t.Translate(&product, func(key, value string) string {
+//		return catalog.Message(locale, key+"."+value, value)
+//	})
+func (t TaGo) Translate(model interface{}, translate func(key, value string) string, opts ...Option) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if !ctx.Value.IsValid() || !ctx.Value.CanSet() {
+			return
+		}
+		key, exists := lookupKey(ctx.Instructions, "i18n")
+		if !exists || key == "" {
+			return
+		}
+		sanitizeValue(ctx.Value, func(s string) string { return translate(key, s) })
+	})
+	return nil
+}
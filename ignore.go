@@ -0,0 +1,140 @@
+package tago
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Struct types, as "package/path.TypeName", that GetNested treats as scalars out of the box, see DefaultLeafTypes
+var defaultLeafTypes = []string{
+	"time.Time",
+	"time.Location",
+	"math/big.Int",
+	"math/big.Float",
+	"math/big.Rat",
+	"net/netip.Addr",
+	"net/netip.AddrPort",
+	"net/netip.Prefix",
+	"net/url.URL",
+	"net/url.Userinfo",
+	"regexp.Regexp",
+	"sync.Mutex",
+	"sync.RWMutex",
+	"sync.Once",
+	"sync.WaitGroup",
+}
+
+// DefaultLeafTypes returns the struct types, as "package/path.TypeName", that GetNested treats as scalars
+// out of the box: their fields are implementation details that would only pollute the results.
+// Non-struct types (time.Duration, net.IP, [16]byte uuids, ..) are never expanded anyway.
+//
+// Any type of the database/sql package whose name starts with "Null" (sql.NullString, sql.Null[T], ..) is a leaf too.
+//
+// The list is a copy, the defaults can't be changed globally: extend them per instance with AddLeafTypes or Ignore,
+// or disable them with DisableDefaultLeaves.
+func DefaultLeafTypes() []string {
+	return slices.Clone(defaultLeafTypes)
+}
+
+// Check whether the given (unwrapped) type is a leaf by name: one of the DefaultLeafTypes, or added with AddLeafTypes
+func (t TaGo) isLeafType(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	name := typ.PkgPath() + "." + typ.Name()
+	if _, exists := t.leafTypes[name]; exists {
+		return true
+	}
+	if t.noDefaultLeaves {
+		return false
+	}
+
+	// sql.NullString, sql.NullInt64, .., and the generic sql.Null[T]
+	if typ.PkgPath() == "database/sql" && strings.HasPrefix(typ.Name(), "Null") {
+		return true
+	}
+	return slices.Contains(defaultLeafTypes, name)
+}
+
+// AddLeafTypes makes GetNested treat the given struct types, as "package/path.TypeName", as scalars on top of the
+// DefaultLeafTypes, like Ignore does for the types that can't be imported.
+//
+// Example:
+//
+//	t.AddLeafTypes("github.com/shopspring/decimal.Decimal", "github.com/google/uuid.NullUUID")
+func (t *TaGo) AddLeafTypes(names ...string) *TaGo {
+	t.configChanged()
+	if t.leafTypes == nil {
+		t.leafTypes = make(map[string]struct{})
+	}
+	for _, name := range names {
+		t.leafTypes[name] = struct{}{}
+	}
+	return t
+}
+
+// DisableDefaultLeaves makes GetNested expand the DefaultLeafTypes like any other struct
+func (t *TaGo) DisableDefaultLeaves() *TaGo {
+	t.configChanged()
+	t.noDefaultLeaves = true
+	return t
+}
+
+// Ignore registers types that GetNested must not expand, e.g. time.Time or sql.NullString.
+// Tags declared on a field of an ignored type are still parsed, only its nested fields are skipped.
+// Types can be given as values (time.Time{}, &sql.NullString{}) or as reflect.Type.
+//
+// Example:
+//
+//	t := &TaGo{Name: "gorm2"}
+//	t.Ignore(time.Time{}, sql.NullString{})
+//	tags := t.GetNested(&MyModel{}, ".") // no more CreatedAt.wall, CreatedAt.ext, ..
+func (t *TaGo) Ignore(types ...any) *TaGo {
+	t.configChanged()
+	if t.ignored == nil {
+		t.ignored = make(map[reflect.Type]struct{})
+	}
+	for _, v := range types {
+		typ, ok := v.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(v)
+		}
+		if typ == nil {
+			continue
+		}
+
+		t.ignored[typeToElem(typ)] = struct{}{}
+	}
+	return t
+}
+
+// IgnoreFunc registers a predicate deciding whether a struct type must not be expanded by GetNested.
+// The predicate receives the unwrapped type (no pointer, slice or array).
+//
+// Example:
+//
+//	t.IgnoreFunc(func(typ reflect.Type) bool {
+//		return typ.PkgPath() == "github.com/shopspring/decimal"
+//	})
+func (t *TaGo) IgnoreFunc(predicate func(reflect.Type) bool) *TaGo {
+	t.configChanged()
+	t.ignoreFuncs = append(t.ignoreFuncs, predicate)
+	return t
+}
+
+// Check whether the given (unwrapped) type must not be expanded
+func (t TaGo) isIgnored(typ reflect.Type) bool {
+	if t.isLeafType(typ) {
+		return true
+	}
+	if _, exists := t.ignored[typ]; exists {
+		return true
+	}
+	for _, predicate := range t.ignoreFuncs {
+		if predicate(typ) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package tago
+
+import "strings"
+
+// Options returns the options of a structured instruction value, nil if it has none.
+// Two syntaxes are supported:
+//
+//	preload=true(limit=10,order=desc)  -> map[limit:10 order:desc]
+//	index=idx_name,priority:2,unique   -> map[priority:2 unique:true]
+//
+// Options are separated by ",", their value by "=" or ":", an option without value is "true".
+// The base value and the option values can be quoted with ' or " to hold these characters, the quotes are removed:
+//
+//	default='a,b'               -> no options, base value a,b
+//	index=idx,where:'a, b'      -> map[where:a, b]
+//
+// See BaseValue for the value without its options.
+func (i Instruction) Options() map[string]string {
+	_, options, found := i.splitOptions()
+	if !found {
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, option := range splitUnquoted(options, ',') {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value, hasValue := option, "true", false
+		if sep := indexUnquoted(option, "=:"); sep >= 0 {
+			key, value, hasValue = option[:sep], option[sep+1:], true
+		}
+		if hasValue {
+			value = unquoteOption(strings.TrimSpace(value))
+		}
+		result[strings.TrimSpace(key)] = value
+	}
+	return result
+}
+
+// BaseValue returns the value of the instruction without its options (see Options)
+//
+//	preload=true(limit=10)      -> true
+//	index=idx_name,priority:2   -> idx_name
+//	column=email                -> email
+//	default='a,b'               -> a,b
+func (i Instruction) BaseValue() string {
+	base, _, _ := i.splitOptions()
+	return base
+}
+
+// Split the value of the instruction into its base value and its options
+func (i Instruction) splitOptions() (base string, options string, found bool) {
+	value := i.Value()
+
+	// value(options)
+	if open := indexUnquoted(value, "("); open >= 0 && strings.HasSuffix(value, ")") {
+		return unquoteOption(strings.TrimSpace(value[:open])), value[open+1 : len(value)-1], true
+	}
+
+	// value,options
+	if comma := indexUnquoted(value, ","); comma >= 0 {
+		return unquoteOption(strings.TrimSpace(value[:comma])), value[comma+1:], true
+	}
+
+	return unquoteOption(value), "", false
+}
+
+// Index of the first of the given characters outside of quotes in s, -1 if none.
+// A quote only opens at the start of a value (after one of ",(=:" or a space), "it's" isn't quoted.
+func indexUnquoted(s string, chars string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case opensOptionQuote(s, i):
+			quote = c
+		case strings.IndexByte(chars, c) >= 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// Whether the quote at s[i] opens a quoted value: at the start of a value only, after one of ",(=:" or a space
+func opensOptionQuote(s string, i int) bool {
+	return (s[i] == '\'' || s[i] == '"') && (i == 0 || strings.IndexByte(",(=: ", s[i-1]) >= 0)
+}
+
+// Split s around sep, outside of quotes
+func splitUnquoted(s string, sep byte) []string {
+	parts := make([]string, 0)
+	for {
+		i := indexUnquoted(s, string(sep))
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
+
+// Remove the quotes around a value, if any
+func unquoteOption(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
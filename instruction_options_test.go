@@ -0,0 +1,38 @@
+package tago
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstructionOptions(t *testing.T) {
+	tests := []struct {
+		instruction Instruction
+		base        string
+		options     map[string]string
+	}{
+		{"column=email", "email", nil},
+		{"unique", "true", nil},
+		{"preload=true(limit=10,order=desc)", "true", map[string]string{"limit": "10", "order": "desc"}},
+		{"index=idx_name,priority:2,unique", "idx_name", map[string]string{"priority": "2", "unique": "true"}},
+		{"index=idx,expr=a:b", "idx", map[string]string{"expr": "a:b"}},
+
+		// Quoted values keep their commas, parentheses and separators
+		{"default='a,b'", "a,b", nil},
+		{`default="f(x)"`, "f(x)", nil},
+		{"index='idx,1',unique", "idx,1", map[string]string{"unique": "true"}},
+		{"index=idx,where:'a, b',sep=\",\"", "idx", map[string]string{"where": "a, b", "sep": ","}},
+		{"preload=true(order='name, id')", "true", map[string]string{"order": "name, id"}},
+
+		// Quotes inside a word don't open a quoted value
+		{"default=it's,x", "it's", map[string]string{"x": "true"}},
+	}
+	for _, test := range tests {
+		if base := test.instruction.BaseValue(); base != test.base {
+			t.Errorf("%s: BaseValue = %q, want %q", test.instruction, base, test.base)
+		}
+		if options := test.instruction.Options(); !reflect.DeepEqual(options, test.options) {
+			t.Errorf("%s: Options = %v, want %v", test.instruction, options, test.options)
+		}
+	}
+}
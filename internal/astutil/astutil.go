@@ -0,0 +1,40 @@
+// Package astutil holds the go/ast helpers shared by the source based packages (tagosource, tagostatic).
+package astutil
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// TypeSpecs returns the package level type declarations of a file, in declaration order.
+// Types declared in functions are local: they are left out, so they can't shadow a package type of the same name.
+func TypeSpecs(file *ast.File) []*ast.TypeSpec {
+	var specs []*ast.TypeSpec
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			specs = append(specs, spec.(*ast.TypeSpec))
+		}
+	}
+	return specs
+}
+
+// EmbeddedName returns the name of an embedded field from its type expression: T, *T, pkg.T, T[U]
+func EmbeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return EmbeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return EmbeddedName(e.X)
+	case *ast.IndexListExpr:
+		return EmbeddedName(e.X)
+	}
+	return ""
+}
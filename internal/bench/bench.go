@@ -0,0 +1,160 @@
+// Package bench holds the benchmark suite of tago and its performance budget.
+//
+// The suite runs with go test, where TestAllocationBudgets fails when a budget is exceeded:
+//
+//	go test -run TestAllocationBudgets -bench . github.com/KooQix/tago
+//
+// and with testing.Benchmark from cmd/tagobench, which prints a table and fails the same way:
+//
+//	go run ./cmd/tagobench
+//
+// Budgets are allocations per operation, which unlike durations don't depend on the machine.
+// Changes to the parser or the traversal must keep every benchmark within its budget, or update the budget
+// in the same change with the reason in its description.
+package bench
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+// Benchmark of the suite
+type Benchmark struct {
+	Name string
+
+	// Maximum allocations per operation, negative for no budget
+	MaxAllocs int64
+
+	// One operation of the benchmark
+	Op func()
+}
+
+// Run runs the operation b.N times, reporting allocations
+func (bm Benchmark) Run(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bm.Op()
+	}
+}
+
+// Result of a benchmark
+type Result struct {
+	Benchmark
+	testing.BenchmarkResult
+}
+
+// OverBudget reports whether the benchmark allocated more than its budget
+func (r Result) OverBudget() bool {
+	return r.MaxAllocs >= 0 && r.AllocsPerOp() > r.MaxAllocs
+}
+
+// Flat model: 10 tagged fields, no nesting
+type Flat struct {
+	ID        uint64 `gorm2:"primaryKey;column=id"`
+	Email     string `gorm2:"column=email;unique;notNull"`
+	Name      string `gorm2:"column=name;index=idx_name"`
+	Age       int    `gorm2:"column=age"`
+	Active    bool   `gorm2:"column=active;default=true"`
+	Role      string `gorm2:"column=role;default=user"`
+	Score     float64
+	Country   string `gorm2:"column=country;index=idx_country,priority:1"`
+	City      string `gorm2:"column=city;index=idx_country,priority:2"`
+	CreatedAt int64  `gorm2:"column=created_at;autoCreateTime"`
+}
+
+// Nested model: 5 levels of nesting
+type (
+	Level1 struct {
+		Name  string `gorm2:"column=name"`
+		Level Level2 `gorm2:"preload=true"`
+	}
+	Level2 struct {
+		Name  string  `gorm2:"column=name"`
+		Level *Level3 `gorm2:"preload=true"`
+	}
+	Level3 struct {
+		Name  string   `gorm2:"column=name"`
+		Level []Level4 `gorm2:"preload=true"`
+	}
+	Level4 struct {
+		Name  string    `gorm2:"column=name"`
+		Level []*Level5 `gorm2:"preload=true"`
+	}
+	Level5 struct {
+		Name string `gorm2:"column=name"`
+		Flat Flat
+	}
+)
+
+// Graph model: slices of pointers between types, with a cycle
+type (
+	Node struct {
+		ID       uint64  `gorm2:"primaryKey"`
+		Children []*Node `gorm2:"preload=true"`
+		Edges    []*Edge `gorm2:"preload=true"`
+	}
+	Edge struct {
+		Weight float64 `gorm2:"column=weight"`
+		From   *Node
+		To     *Node
+	}
+)
+
+// Wide returns a model of 200 tagged fields, built at run time
+func Wide() any {
+	fields := make([]reflect.StructField, 200)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: "Field" + strconv.Itoa(i),
+			Type: reflect.TypeOf(""),
+			Tag:  reflect.StructTag(`gorm2:"column=field_` + strconv.Itoa(i) + `;index=idx_` + strconv.Itoa(i%10) + `"`),
+		}
+	}
+	return reflect.New(reflect.StructOf(fields)).Interface()
+}
+
+// Suite returns the benchmarks and their budget
+func Suite() []Benchmark {
+	t := tago.TaGo{Name: "gorm2"}
+	wide := Wide()
+
+	cached := &tago.TaGo{Name: "gorm2"}
+	cached.EnableCache(0)
+
+	return []Benchmark{
+		{Name: "Get/Flat", MaxAllocs: 200, Op: func() {
+			t.Get(&Flat{})
+		}},
+		{Name: "GetNested/Nested", MaxAllocs: 550, Op: func() {
+			t.GetNested(&Level1{}, ".")
+		}},
+		{Name: "GetNested/Wide", MaxAllocs: 4200, Op: func() {
+			t.GetNested(wide, ".")
+		}},
+		{Name: "GetNested/Graph", MaxAllocs: 80, Op: func() {
+			t.GetNested([]*Node{}, ".")
+		}},
+		{Name: "GetNested/Cached", MaxAllocs: 40, Op: func() {
+			cached.GetNested(&Level1{}, ".")
+		}},
+		{Name: "Has/Flat", MaxAllocs: 0, Op: func() {
+			t.Has(&Flat{}, "autoCreateTime")
+		}},
+		{Name: "HasKey/Wide", MaxAllocs: 0, Op: func() {
+			t.HasKey(wide, "missing")
+		}},
+	}
+}
+
+// Run runs the benchmarks of the suite
+func Run() []Result {
+	suite := Suite()
+	results := make([]Result, len(suite))
+	for i, benchmark := range suite {
+		results[i] = Result{Benchmark: benchmark, BenchmarkResult: testing.Benchmark(benchmark.Run)}
+	}
+	return results
+}
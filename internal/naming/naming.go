@@ -0,0 +1,25 @@
+// Package naming converts Go identifiers to the naming conventions of the generated schemas (SQL, protobuf, ..).
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a Go identifier to snake case: ID -> id, UserName -> user_name, HTTPStatus -> http_status
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// New word: after a lower case letter, or before one at the end of an acronym
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,12 @@
+// Package typeutil holds the reflection helpers shared by the generator packages (tagogql, tagopb, tagots, ..).
+package typeutil
+
+import "reflect"
+
+// Elem returns the element type if it's a pointer, slice or array, whatever the number of wrapping levels
+func Elem(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	return typ
+}
@@ -0,0 +1,76 @@
+package tago
+
+import "strings"
+
+// Variables sets the variables used to resolve ${NAME} placeholders in instruction values, at parse time.
+// This allows tuning tag behavior by configuration without recompiling the models.
+//
+// A default can be given with ${NAME:-default}, used when the variable is not defined, or when no variables are set.
+// Placeholders of undefined variables without default are left untouched.
+//
+// Example:
+//
+//	type MyModel struct {
+//		Orders []Order `gorm2:"preload=true;limit=${MAX_PRELOAD:-10}"`
+//	}
+//	t := &TaGo{Name: "gorm2"}
+//	t.Variables(map[string]string{"MAX_PRELOAD": "50"})
+//	tags := t.Get(&MyModel{}) // map[limit=50:[Orders] preload=true:[Orders]]
+func (t *TaGo) Variables(vars map[string]string) *TaGo {
+	return t.VariableResolver(func(name string) (string, bool) {
+		value, exists := vars[name]
+		return value, exists
+	})
+}
+
+// VariableResolver sets a function resolving ${NAME} placeholders in instruction values, at parse time.
+// The function returns false if the variable is not defined.
+//
+// Example, resolve from the environment:
+//
+//	t.VariableResolver(os.LookupEnv)
+func (t *TaGo) VariableResolver(resolver func(name string) (string, bool)) *TaGo {
+	t.configChanged()
+	t.resolver = resolver
+	return t
+}
+
+// Replace the ${NAME} and ${NAME:-default} placeholders of a value
+func (t TaGo) interpolate(value string) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(value[:start])
+
+		name, fallback, hasFallback := strings.Cut(value[start+2:end], ":-")
+		resolved, exists := "", false
+		if t.resolver != nil {
+			resolved, exists = t.resolver(strings.TrimSpace(name))
+		}
+		if exists {
+			b.WriteString(resolved)
+		} else if hasFallback {
+			b.WriteString(fallback)
+		} else {
+			// Leave unknown variables untouched so the problem is visible
+			b.WriteString(value[start : end+1])
+		}
+
+		value = value[end+1:]
+	}
+	b.WriteString(value)
+	return b.String()
+}
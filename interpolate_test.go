@@ -0,0 +1,56 @@
+package tago
+
+import "testing"
+
+type interpolateModel struct {
+	Orders []string `gorm2:"preload=true;limit=${MAX_PRELOAD:-10}"`
+	Name   string   `gorm2:"column=${NAME_COLUMN}"`
+}
+
+func TestInterpolate(t *testing.T) {
+	vars := map[string]string{"NAME": "name", "EMPTY": ""}
+	resolver := func(name string) (string, bool) {
+		value, exists := vars[name]
+		return value, exists
+	}
+
+	tests := []struct {
+		name     string
+		resolver func(string) (string, bool)
+		value    string
+		want     string
+	}{
+		{"no placeholder", resolver, "column=name", "column=name"},
+		{"variable", resolver, "column=${NAME}", "column=name"},
+		{"white space", resolver, "column=${ NAME }", "column=name"},
+		{"several", resolver, "${NAME}_${NAME}", "name_name"},
+		{"empty variable", resolver, "${EMPTY:-default}", ""},
+		{"default", resolver, "limit=${MISSING:-10}", "limit=10"},
+		{"empty default", resolver, "limit=${MISSING:-}", "limit="},
+		{"undefined", resolver, "limit=${MISSING}", "limit=${MISSING}"},
+		{"unterminated", resolver, "limit=${NAME", "limit=${NAME"},
+		{"no resolver", nil, "column=${NAME}", "column=${NAME}"},
+		{"default without resolver", nil, "limit=${MISSING:-10};${NAME:-name}", "limit=10;name"},
+	}
+	for _, test := range tests {
+		tg := TaGo{Name: "gorm2", resolver: test.resolver}
+		if got := tg.interpolate(test.value); got != test.want {
+			t.Errorf("interpolate(%s: %q) = %q, want %q", test.name, test.value, got, test.want)
+		}
+	}
+}
+
+func TestVariables(t *testing.T) {
+	// Defaults apply without variables too
+	tg := &TaGo{Name: "gorm2"}
+	want := Instructions{"preload=true": {"Orders"}, "limit=10": {"Orders"}, "column=${NAME_COLUMN}": {"Name"}}
+	if got := tg.Get(&interpolateModel{}); !got.Equal(want) {
+		t.Errorf("Get without variables = %v, want %v", got, want)
+	}
+
+	tg.Variables(map[string]string{"MAX_PRELOAD": "50", "NAME_COLUMN": "full_name"})
+	want = Instructions{"preload=true": {"Orders"}, "limit=50": {"Orders"}, "column=full_name": {"Name"}}
+	if got := tg.Get(&interpolateModel{}); !got.Equal(want) {
+		t.Errorf("Get with variables = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package tago
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSONParser parses tags whose entire value is a JSON object, for values containing the reserved characters
+// of the default grammar (";", "="). Other tags are parsed by DefaultParser. Set it with SetParser.
+//
+// Keys of nested objects are flattened with dots, arrays are joined with commas, null gives a flag,
+// and instructions keep the order of the document:
+//
+//	`meta:"{\"preload\": true, \"where\": \"a=1;b=2\", \"roles\": [\"admin\", \"owner\"], \"index\": {\"name\": \"idx\"}}"`
+//	// preload=true, where=a=1;b=2, roles=admin,owner, index.name=idx
+var JSONParser Parser = ParserFunc(func(tag string) ([]Instruction, error) {
+	trimmed := strings.TrimSpace(tag)
+	if !strings.HasPrefix(trimmed, "{") {
+		return DefaultParser.Parse(tag)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	instructions := make([]Instruction, 0)
+	// Offset of the errors in the tag rather than in the trimmed tag
+	leading := len(tag) - len(strings.TrimLeft(tag, " \t\n\r"))
+	if err := parseJSONObject(decoder, "", &instructions); err != nil {
+		pos := int(decoder.InputOffset())
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			// Offset is the number of bytes read, the invalid one included
+			pos = int(syntaxErr.Offset) - 1
+		}
+		return instructions, &ParseError{Raw: tag, Pos: leading + pos, Err: fmt.Errorf("invalid JSON tag: %w", err)}
+	}
+	if decoder.More() {
+		return instructions, &ParseError{Raw: tag, Pos: leading + int(decoder.InputOffset()), Err: errors.New("invalid JSON tag: data after the object")}
+	}
+	return instructions, nil
+})
+
+// Parse a JSON object from its opening brace, appending its flattened instructions
+func parseJSONObject(decoder *json.Decoder, prefix string, instructions *[]Instruction) error {
+	if token, err := decoder.Token(); err != nil {
+		return err
+	} else if token != json.Delim('{') {
+		return fmt.Errorf("expected an object, got %v", token)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := prefix + token.(string)
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		raw = bytes.TrimSpace(raw)
+
+		switch {
+		case len(raw) > 0 && raw[0] == '{':
+			nested := json.NewDecoder(bytes.NewReader(raw))
+			nested.UseNumber()
+			if err := parseJSONObject(nested, key+".", instructions); err != nil {
+				return err
+			}
+		case string(raw) == "null":
+			*instructions = append(*instructions, Instruction(key))
+		default:
+			value, err := jsonValue(raw)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			*instructions = append(*instructions, Instruction(key+"="+value))
+		}
+	}
+
+	_, err := decoder.Token()
+	return err
+}
+
+// String value of a JSON scalar or array (joined with commas)
+func jsonValue(raw json.RawMessage) (string, error) {
+	if raw[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return "", err
+		}
+		values := make([]string, len(items))
+		for i, item := range items {
+			value, err := jsonValue(bytes.TrimSpace(item))
+			if err != nil {
+				return "", err
+			}
+			values[i] = value
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	case map[string]any:
+		return "", errors.New("nested object in an array")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,78 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Limits bound the reflection work of a single parse, to protect against pathological type graphs
+// (e.g. types registered by plugins). A zero limit means no limit.
+type Limits struct {
+	// Maximum number of fields visited, nested fields included
+	MaxFields int
+
+	// Maximum number of distinct instructions in the result
+	MaxInstructions int
+
+	// Maximum length of a raw tag, in bytes
+	MaxTagLength int
+}
+
+// WithLimits sets the limits of the traversal. When a limit is exceeded, the traversal stops:
+// Compile returns an error, Get / GetNested return the tags found until then and raise a Warning.
+//
+// Example:
+//
+//	tags, err := t.Compile(model, tago.WithLimits(tago.Limits{MaxFields: 5000, MaxTagLength: 1024}))
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+// State of a single parse, shared by the recursive calls
+type parseState struct {
+	// Number of fields visited
+	fields int
+
+	// First limit exceeded
+	err error
+
+	// Struct types being traversed in the current branch
+	visiting map[reflect.Type]bool
+
+	// Every struct type traversed, recorded for the cache only (see InvalidateType)
+	traversed map[reflect.Type]bool
+}
+
+// Mark a struct type as being traversed
+func (s *parseState) enter(typ reflect.Type) {
+	if s.visiting == nil {
+		s.visiting = make(map[reflect.Type]bool)
+	}
+	s.visiting[typ] = true
+	if s.traversed != nil {
+		s.traversed[typ] = true
+	}
+}
+
+// Mark a struct type as traversed
+func (s *parseState) leave(typ reflect.Type) {
+	delete(s.visiting, typ)
+}
+
+// Count a visited field, return false if the traversal must stop
+func (s *parseState) visit(owner reflect.Type, path FieldName, tagLength int, limits Limits) bool {
+	if s.err != nil {
+		return false
+	}
+	s.fields++
+
+	switch {
+	case limits.MaxFields > 0 && s.fields > limits.MaxFields:
+		s.err = fmt.Errorf("tago: %s %s: more than %d fields visited", owner, path, limits.MaxFields)
+	case limits.MaxTagLength > 0 && tagLength > limits.MaxTagLength:
+		s.err = fmt.Errorf("tago: %s %s: tag of %d bytes, limit is %d", owner, path, tagLength, limits.MaxTagLength)
+	}
+	return s.err == nil
+}
@@ -0,0 +1,76 @@
+package tago
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer: instructions are logged as a group, one attribute per instruction
+// (sorted) with the list of its fields
+//
+// Example:
+//
+//	slog.Info("parsed", "tags", tags)
+//	// level=INFO msg=parsed tags.preload=true="[Address Orders]" tags.unique="[Email]"
+func (t Instructions) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(t))
+	for _, key := range t.Keys() {
+		fields := make([]string, len(t[key]))
+		for i, field := range t[key] {
+			fields[i] = field.String()
+		}
+		attrs = append(attrs, slog.Any(string(key), fields))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// SetLogger sets the logger receiving internal warnings (deprecated keys, malformed instructions) at warn level,
+// and the struct fields skipped during traversal at debug level. nil to remove it.
+//
+// Example:
+//
+//	t.SetLogger(slog.Default().With("component", "tago"))
+func (t *TaGo) SetLogger(logger *slog.Logger) *TaGo {
+	t.logger = logger
+	return t
+}
+
+// Log a warning to the logger, if any
+func (t TaGo) logWarning(w Warning) {
+	if t.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("tag", t.Name),
+		slog.String("field", w.Field.String()),
+		slog.String("instruction", string(w.Instruction)),
+	}
+	if w.Type != nil {
+		attrs = append(attrs, slog.String("type", w.Type.String()))
+	}
+	t.logger.LogAttrs(context.Background(), slog.LevelWarn, "tago: "+w.Message, attrs...)
+}
+
+// Wrap the tracer of the options so skipped fields are logged to the logger, if any
+func (t TaGo) withLogger(o options) options {
+	if t.logger == nil || !t.logger.Enabled(context.Background(), slog.LevelDebug) {
+		return o
+	}
+
+	tracer := o.tracer
+	o.tracer = func(e TraceEvent) {
+		if e.Kind == TraceSkip {
+			t.logger.LogAttrs(context.Background(), slog.LevelDebug, "tago: field skipped",
+				slog.String("tag", t.Name),
+				slog.String("type", e.Type.String()),
+				slog.String("field", e.Field.String()),
+				slog.String("reason", e.Reason),
+			)
+		}
+		if tracer != nil {
+			tracer(e)
+		}
+	}
+	return o
+}
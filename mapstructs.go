@@ -0,0 +1,134 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MapStructs copies values between two struct types whose fields share the value of an instruction key,
+// e.g. both tagged `map=customer_id`: a tag-driven alternative to copier libraries.
+// Nested structs without the key are traversed transparently on both sides. Fields mapped to a struct, a pointer
+// to a struct or a slice of structs on both sides are mapped recursively, other values are assigned or converted
+// (numbers, strings parsed to the destination type). Destination fields without source, and func or chan fields,
+// are left untouched.
+// dst must be a non-nil pointer to a struct, src a struct or a pointer to a struct.
+//
+// Example:
+//
+//	type CustomerRow struct {
+//		ID   int64  `map:"map=customer_id"`
+//		Name string `map:"map=name"`
+//	}
+//	type Customer struct {
+//		CustomerID string `map:"map=customer_id"`
+//		Name       string `map:"map=name"`
+//	}
+//	err := TaGo{Name: "map"}.MapStructs(&customer, row, "map")
+func (t TaGo) MapStructs(dst any, src any, key string) error {
+	if err := checkSettable(dst); err != nil {
+		return err
+	}
+	source := structValue(reflect.ValueOf(src))
+	if !source.IsValid() {
+		return notStruct(fmt.Sprintf("%T", src))
+	}
+
+	key = t.normalizeInstruction(Instruction(key)).Key()
+	return errors.Join(t.mapStruct(reflect.ValueOf(dst).Elem(), source, key, "", make(typeFields))...)
+}
+
+func (t TaGo) mapStruct(dst reflect.Value, src reflect.Value, key string, prefix string, fields typeFields) []error {
+	sources := make(map[string]reflect.Value)
+	t.indexMapped(src, key, fields, sources, map[reflect.Type]bool{})
+
+	var errs []error
+	t.eachMapped(dst, key, fields, map[reflect.Type]bool{}, func(name string, field reflect.Value) {
+		value, exists := sources[name]
+		if !exists {
+			return
+		}
+		if err := t.mapValue(field, value, key, prefix+name, fields); err != nil {
+			errs = append(errs, err...)
+		}
+	})
+	return errs
+}
+
+// Map a source value to a destination value, recursively for structs and slices of structs
+func (t TaGo) mapValue(dst reflect.Value, src reflect.Value, key string, path string, fields typeFields) []error {
+	srcStruct, dstElem := structValue(src), typeToElem(dst.Type())
+	switch {
+	case srcStruct.IsValid() && dstElem.Kind() == reflect.Struct && !isCollection(dst.Type()) && srcStruct.Type() != dst.Type():
+		target := dst
+		for target.Kind() == reflect.Ptr {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+			target = target.Elem()
+		}
+		return t.mapStruct(target, srcStruct, key, path+".", fields)
+
+	case (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && dst.Kind() == reflect.Slice &&
+		typeToElem(src.Type()).Kind() == reflect.Struct && dstElem.Kind() == reflect.Struct && src.Type() != dst.Type():
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		var errs []error
+		for i := 0; i < src.Len(); i++ {
+			errs = append(errs, t.mapValue(slice.Index(i), src.Index(i), key, fmt.Sprintf("%s[%d]", path, i), fields)...)
+		}
+		dst.Set(slice)
+		return errs
+	}
+
+	if src.Kind() == reflect.Ptr && src.IsNil() || isFuncOrChan(dst.Type()) {
+		return nil
+	}
+	if err := assign(dst, src.Interface(), ""); err != nil {
+		return []error{&PathError{Path: FieldName(path), Err: err}}
+	}
+	return nil
+}
+
+// Index the values of the fields carrying the key by key value, traversing nested structs without the key
+func (t TaGo) indexMapped(value reflect.Value, key string, fields typeFields, index map[string]reflect.Value, visiting map[reflect.Type]bool) {
+	typ := value.Type()
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i, instructions := range t.fieldsOf(fields, typ) {
+		if !typ.Field(i).IsExported() {
+			continue
+		}
+		if name, exists := lookupKey(instructions, key); exists {
+			if _, taken := index[name]; !taken {
+				index[name] = value.Field(i)
+			}
+			continue
+		}
+		if nested := structValue(value.Field(i)); nested.IsValid() && !visiting[nested.Type()] && !t.isIgnored(nested.Type()) {
+			t.indexMapped(nested, key, fields, index, visiting)
+		}
+	}
+}
+
+// Visit the settable fields carrying the key, traversing nested structs without the key (allocating nil pointers)
+func (t TaGo) eachMapped(value reflect.Value, key string, fields typeFields, visiting map[reflect.Type]bool, visit func(name string, field reflect.Value)) {
+	typ := value.Type()
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i, instructions := range t.fieldsOf(fields, typ) {
+		if !typ.Field(i).IsExported() {
+			continue
+		}
+		if name, exists := lookupKey(instructions, key); exists {
+			visit(name, value.Field(i))
+			continue
+		}
+
+		// Only plain nested structs are traversed: allocating pointers for nothing would change the destination
+		if nested := value.Field(i); nested.Kind() == reflect.Struct && !visiting[nested.Type()] && !t.isIgnored(nested.Type()) {
+			t.eachMapped(nested, key, fields, visiting, visit)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tago
+
+import (
+	"errors"
+	"testing"
+)
+
+type mapRow struct {
+	ID    int64     `map:"map=customer_id"`
+	Name  string    `map:"Map=name"`
+	Lines []mapLine `map:"map=lines"`
+	Audit struct {
+		By string `map:"map=by"`
+	}
+}
+
+type mapLine struct {
+	SKU string `map:"map=sku;legacy"`
+}
+
+type mapCustomer struct {
+	CustomerID string         `map:"map=customer_id"`
+	Name       string         `map:"map=name"`
+	Lines      []mapOrderLine `map:"map=lines"`
+	Meta       struct {
+		By string `map:"map=by"`
+	}
+}
+
+type mapOrderLine struct {
+	SKU string `map:"map=sku"`
+}
+
+func TestMapStructs(t *testing.T) {
+	tg := TaGo{Name: "map"}
+	warnings := 0
+	tg.CaseInsensitive().Deprecate("legacy", "").OnWarning(func(Warning) { warnings++ })
+
+	row := mapRow{ID: 42, Name: "Bob", Lines: []mapLine{{SKU: "a"}, {SKU: "b"}}}
+	row.Audit.By = "admin"
+
+	var customer mapCustomer
+	if err := tg.MapStructs(&customer, row, "map"); err != nil {
+		t.Fatalf("MapStructs: %v", err)
+	}
+	if customer.CustomerID != "42" || customer.Name != "Bob" || len(customer.Lines) != 2 || customer.Lines[1].SKU != "b" || customer.Meta.By != "admin" {
+		t.Errorf("MapStructs = %+v", customer)
+	}
+
+	// The fields of a type are parsed once per call, not once per element
+	if warnings != 1 {
+		t.Errorf("MapStructs: %d warnings, want 1", warnings)
+	}
+
+	if err := tg.MapStructs(customer, row, "map"); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("dst not a pointer: error = %v, want ErrNotStruct", err)
+	}
+}
@@ -0,0 +1,102 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// MetricLabels returns the metric labels of a populated model, from the fields tagged metricLabel=name, nested fields
+// included: a map compatible with prometheus.Labels. Values are formatted with FormatValue, a nil pointer gives an
+// empty value.
+// To guard the cardinality of the metrics, every labelled field must be bounded: a bool, or a field declaring its
+// values with enum=a|b|c. Values outside of the enum are errors, an empty string too unless the enum lists it
+// (enum=|a|b). Violations are reported as joined *PathError wrapping ErrUnboundedLabel, along with duplicate label names.
+//
+// Example:
+//
+//	type Request struct {
+//		Method string `api:"metricLabel=method;enum=GET|POST|PUT|DELETE"`
+//		Cached bool   `api:"metricLabel=cached"`
+//		UserID string // not a label: unbounded
+//	}
+//	labels, err := t.MetricLabels(&req) // map[cached:false method:GET]
+//	requests.With(labels).Inc()
+func (t TaGo) MetricLabels(model interface{}, opts ...Option) (map[string]string, error) {
+	labels := make(map[string]string)
+	err := t.walkLabels(model, opts, func(ctx *FieldContext, name string, enum []string) error {
+		if !ctx.Value.IsValid() {
+			labels[name] = ""
+			return nil
+		}
+		value, err := t.FormatValue(ctx.Value, "")
+		if err != nil {
+			return err
+		}
+		// Only a nil pointer bypasses the enum: a blank value must be one of its values like any other
+		if enum != nil && !isNilPointer(ctx.Value) && !slices.Contains(enum, value) {
+			return fmt.Errorf("%w: value %q isn't one of %s", ErrUnboundedLabel, value, strings.Join(enum, "|"))
+		}
+		labels[name] = value
+		return nil
+	})
+	return labels, err
+}
+
+// MetricLabelNames returns the names of the metric labels of a model in declaration order, see MetricLabels, to declare
+// the metric vectors. The fields are checked to be bounded the same way.
+//
+// Example:
+//
+//	names, err := t.MetricLabelNames(&Request{}) // [method cached]
+//	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests_total"}, names)
+func (t TaGo) MetricLabelNames(model interface{}, opts ...Option) ([]string, error) {
+	names := make([]string, 0)
+	err := t.walkLabels(model, opts, func(ctx *FieldContext, name string, enum []string) error {
+		names = append(names, name)
+		return nil
+	})
+	return names, err
+}
+
+// Visit the fields tagged metricLabel=name with their enum (nil for bool fields), reporting unbounded fields and
+// duplicate names
+func (t TaGo) walkLabels(model interface{}, opts []Option, visit func(ctx *FieldContext, name string, enum []string) error) error {
+	if model == nil || typeToElem(reflect.TypeOf(model)).Kind() != reflect.Struct {
+		return notStruct(fmt.Sprintf("%T", model))
+	}
+
+	var errs []error
+	seen := make(map[string]FieldName)
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		instructions := ctx.Instructions
+		name, exists := lookupKey(instructions, "metricLabel")
+		if !exists {
+			return
+		}
+		instruction := Instruction("metricLabel=" + name)
+
+		if previous, duplicate := seen[name]; duplicate {
+			errs = append(errs, &PathError{Path: ctx.Path, Instruction: instruction, Err: fmt.Errorf("label already declared by %s", previous)})
+			return
+		}
+		seen[name] = ctx.Path
+
+		var enum []string
+		if values, exists := lookupKey(instructions, "enum"); exists {
+			for _, value := range strings.Split(values, "|") {
+				enum = append(enum, strings.TrimSpace(value))
+			}
+		} else if fieldType := typeToElem(ctx.Field.Type); fieldType.Kind() != reflect.Bool || isCollection(ctx.Field.Type) {
+			errs = append(errs, &PathError{Path: ctx.Path, Instruction: instruction, Err: fmt.Errorf("%w: declare its values with enum=", ErrUnboundedLabel)})
+			return
+		}
+
+		if err := visit(ctx, name, enum); err != nil {
+			errs = append(errs, &PathError{Path: ctx.Path, Instruction: instruction, Err: err})
+		}
+	})
+	return errors.Join(errs...)
+}
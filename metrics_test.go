@@ -0,0 +1,51 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type metricsRequest struct {
+	Method string  `api:"metricLabel=method;enum=GET|POST"`
+	Cached bool    `api:"metricLabel=cached"`
+	Region *string `api:"metricLabel=region;enum=eu|us"`
+	Tier   string  `api:"metricLabel=tier;enum=|free|paid"`
+	UserID string
+}
+
+func TestMetricLabels(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	labels, err := tg.MetricLabels(&metricsRequest{Method: "GET", Cached: true})
+	want := map[string]string{"method": "GET", "cached": "true", "region": "", "tier": ""}
+	if err != nil || !reflect.DeepEqual(labels, want) {
+		t.Errorf("MetricLabels = %v, %v, want %v", labels, err, want)
+	}
+
+	names, err := tg.MetricLabelNames(&metricsRequest{})
+	if want := []string{"method", "cached", "region", "tier"}; err != nil || !reflect.DeepEqual(names, want) {
+		t.Errorf("MetricLabelNames = %v, %v, want %v", names, err, want)
+	}
+}
+
+func TestMetricLabelsErrors(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	// A blank value is checked against the enum like any other, only nil pointers bypass it
+	blank := ""
+	for _, request := range []metricsRequest{{Method: ""}, {Method: "PATCH"}, {Method: "GET", Region: &blank}} {
+		if _, err := tg.MetricLabels(&request); !errors.Is(err, ErrUnboundedLabel) {
+			t.Errorf("MetricLabels(%+v): error = %v, want ErrUnboundedLabel", request, err)
+		}
+	}
+
+	type unbounded struct {
+		UserID string `api:"metricLabel=user"`
+		Other  bool   `api:"metricLabel=user"`
+	}
+	_, err := tg.MetricLabelNames(&unbounded{})
+	if !errors.Is(err, ErrUnboundedLabel) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Errorf("MetricLabelNames: error = %v, want an unbounded label and a duplicate", err)
+	}
+}
@@ -0,0 +1,88 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Mount is a struct type of a model with the paths where it is mounted, see SharedTypes
+type Mount struct {
+	Type reflect.Type
+
+	// Paths of the fields holding the type, in traversal order ("" for the model itself)
+	Paths []FieldName
+
+	// Instructions of the direct fields of the type, not prefixed (as Get would return them for the type), as parsed
+	// by the traversal at the first path of the type
+	Instructions Instructions
+}
+
+// SharedTypes returns the struct types mounted under several paths of a model (e.g. BillingAddress and
+// ShippingAddress both being an Address), in traversal order, each with its instructions computed once.
+// Consumers can then build one handler per type instead of duplicating work per path.
+//
+// Example:
+//
+//	for _, mount := range t.SharedTypes(&Order{}, ".") {
+//		fmt.Println(mount.Type, mount.Paths, mount.Instructions)
+//	}
+//	// main.Address [BillingAddress ShippingAddress] map[column=city:[City]]
+func (t TaGo) SharedTypes(model interface{}, separator string, opts ...Option) []Mount {
+	shared := make([]Mount, 0)
+	for _, mount := range t.mounts(model, newOptions(separator, -1, opts)) {
+		if len(mount.Paths) > 1 {
+			shared = append(shared, mount)
+		}
+	}
+	return shared
+}
+
+// GetNestedByType returns every struct type of a model, the model included, with its own instructions (not prefixed)
+// and the paths where it is mounted: consumers processing each type once (code generation, DDL, ..) don't have to
+// strip the prefixes of the GetNested result. Mount.Paths is in traversal order, "" for the model itself.
+//
+// Example:
+//
+//	for typ, mount := range t.GetNestedByType(&Order{}, ".") {
+//		fmt.Println(typ, mount.Paths, mount.Instructions)
+//	}
+//	// main.Order [] map[preload=true:[BillingAddress]]
+//	// main.Address [BillingAddress ShippingAddress] map[column=city:[City]]
+func (t TaGo) GetNestedByType(model interface{}, separator string, opts ...Option) map[reflect.Type]Mount {
+	byType := make(map[reflect.Type]Mount)
+	for _, mount := range t.mounts(model, newOptions(separator, -1, opts)) {
+		byType[mount.Type] = mount
+	}
+	return byType
+}
+
+// Get every struct type traversed in a model with its mount paths and its own instructions, in traversal order
+func (t TaGo) mounts(model interface{}, o options) []Mount {
+	mounts := make([]Mount, 0)
+	positions := make(map[reflect.Type]int)
+	seen := make(map[reflect.Type]map[FieldName]bool)
+
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		// The owner is mounted at the path of the field minus its name
+		mountPath := FieldName(strings.TrimSuffix(strings.TrimSuffix(string(path), field.Name), o.separator))
+
+		position, exists := positions[owner]
+		if !exists {
+			position = len(mounts)
+			positions[owner] = position
+			seen[owner] = make(map[FieldName]bool)
+			mounts = append(mounts, Mount{Type: owner, Paths: make([]FieldName, 0), Instructions: make(Instructions)})
+		}
+		if !seen[owner][mountPath] {
+			seen[owner][mountPath] = true
+			mounts[position].Paths = append(mounts[position].Paths, mountPath)
+		}
+
+		// Instructions of the type, computed once from the fields of its first mount
+		if mountPath == mounts[position].Paths[0] {
+			mounts[position].Instructions.concat(fieldInstructions(field, instructions), "")
+		}
+	}
+	t.mustParseModel(model, o)
+	return mounts
+}
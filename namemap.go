@@ -0,0 +1,45 @@
+package tago
+
+import "sort"
+
+// Names maps field paths to the value of a naming instruction, see NameMap
+type Names map[FieldName]string
+
+// NameMap returns the value of the naming instruction key (e.g. "column") of every nested field carrying it, by path.
+// With its reverse lookup, it powers scan mapping and query generation from one structure.
+//
+// Example:
+//
+//	columns := t.NameMap(&User{}, "column", ".") // map[Address.City:city Email:email]
+//	columns.Reverse()["city"]                    // Address.City
+func (t TaGo) NameMap(model interface{}, key string, separator string, opts ...Option) Names {
+	names := make(Names)
+	key = t.normalizeInstruction(Instruction(key)).Key()
+	for _, field := range t.GetOrdered(model, separator, opts...) {
+		if value, exists := lookupKey(field.Instructions, key); exists {
+			names[field.Field] = value
+		}
+	}
+	return names
+}
+
+// Reverse returns the paths by name. When several fields share a name, the first one alphabetically wins.
+func (n Names) Reverse() map[string]FieldName {
+	reverse := make(map[string]FieldName, len(n))
+	for _, field := range n.Fields() {
+		if _, exists := reverse[n[field]]; !exists {
+			reverse[n[field]] = field
+		}
+	}
+	return reverse
+}
+
+// Fields returns the paths of the map, sorted alphabetically
+func (n Names) Fields() []FieldName {
+	fields := make([]FieldName, 0, len(n))
+	for field := range n {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i] < fields[j] })
+	return fields
+}
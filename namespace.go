@@ -0,0 +1,142 @@
+package tago
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Namespace returns the namespace of the instruction, "" if it has none: db:index=true -> db
+func (i Instruction) Namespace() string {
+	namespace, _ := i.splitNamespace()
+	return namespace
+}
+
+// Local returns the instruction without its namespace: db:index=true -> index=true
+func (i Instruction) Local() Instruction {
+	_, local := i.splitNamespace()
+	return local
+}
+
+// Split the namespace prefix of the key, a namespace is an identifier followed by ":"
+func (i Instruction) splitNamespace() (string, Instruction) {
+	key := i.Key()
+	namespace, _, found := strings.Cut(key, ":")
+	if !found || namespace == "" || strings.IndexFunc(namespace, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' }) >= 0 {
+		return "", i
+	}
+	return namespace, Instruction(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(i)), namespace+":")))
+}
+
+// Expand the namespace groups of the parts of a tag, "db:index=true,unique" -> db:index=true and db:unique.
+// The group is the whole instruction after "ns:", whether its first item has a value or not ("api:readOnly,hidden").
+// Its instructions are separated by the commas outside of quotes and parentheses and all belong to the namespace
+// of the group, so a value with a comma must be quoted (db:default='a,b') or its options given in parentheses
+// (db:index=idx(priority:2,unique)). An item which isn't an instruction (no key, or a key:value option like priority:2)
+// continues the value of the previous one: db:index=idx,priority:2 is db:index=idx,priority:2.
+func expandNamespaceGroups(parts []tagPart) []tagPart {
+	expanded := make([]tagPart, 0, len(parts))
+	for _, part := range parts {
+		namespace, local := Instruction(part.instruction()).splitNamespace()
+		if namespace == "" {
+			expanded = append(expanded, part)
+			continue
+		}
+
+		first := len(expanded)
+		for _, item := range splitGroup(string(local)) {
+			key, value, hasValue := strings.Cut(strings.TrimSpace(item), "=")
+			key = strings.TrimSpace(key)
+			switch {
+			case key == "" && !hasValue:
+				// Trailing or doubled comma
+				continue
+			case len(expanded) > first && !isGroupKey(key):
+				previous := &expanded[len(expanded)-1]
+				if previous.HasValue {
+					previous.Value += "," + strings.TrimSpace(item)
+				} else {
+					previous.Key += "," + strings.TrimSpace(item)
+				}
+				continue
+			}
+			expanded = append(expanded, tagPart{Key: namespace + ":" + key, Value: strings.TrimSpace(value), HasValue: hasValue, Pos: part.Pos})
+		}
+		if len(expanded) == first {
+			expanded = append(expanded, part)
+		}
+	}
+	return expanded
+}
+
+// Whether the key of a namespace group item starts a new instruction: letters, digits, "_" and "-"
+func isGroupKey(key string) bool {
+	return key != "" && strings.IndexFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-'
+	}) < 0
+}
+
+// Split the value of a namespace group around the commas outside of quotes and parentheses
+func splitGroup(value string) []string {
+	items := make([]string, 0, 1)
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case opensOptionQuote(value, i):
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ',' && depth == 0:
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, value[start:])
+}
+
+// Namespace returns the instructions of a namespace, without their namespace prefix, for teams grouping
+// instructions within a single tag. The empty namespace returns the instructions without namespace.
+// The instructions of a namespace can be grouped, separated by commas: "db:index=true,unique" is
+// "db:index=true;db:unique", values with commas are then quoted (db:default='a,b'). A group may start with a flag
+// ("api:readOnly,hidden"), and key:value options continue the previous value ("db:index=idx,priority:2").
+//
+// Example:
+//
+//	type User struct {
+//		Email string `gorm2:"db:index=true,unique;api:readOnly"`
+//	}
+//	tags := t.Get(&User{})
+//	tags.Namespace("db")  // map[index=true:[Email] unique:[Email]]
+//	tags.Namespace("api") // map[readOnly:[Email]]
+func (t Instructions) Namespace(namespace string) Instructions {
+	result := make(Instructions)
+	for instruction, fields := range t {
+		if instruction.Namespace() != namespace {
+			continue
+		}
+		local := instruction.Local()
+		for _, field := range fields {
+			if !containsField(result[local], field) {
+				result[local] = append(result[local], field)
+			}
+		}
+	}
+	return result
+}
+
+// Namespaces returns the namespaces used by the instructions, sorted, without the empty one
+func (t Instructions) Namespaces() []string {
+	namespaces := make([]string, 0)
+	for _, instruction := range t.Keys() {
+		if namespace := instruction.Namespace(); namespace != "" && (len(namespaces) == 0 || namespaces[len(namespaces)-1] != namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
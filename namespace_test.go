@@ -0,0 +1,102 @@
+package tago
+
+import (
+	"reflect"
+	"testing"
+)
+
+type namespaceUser struct {
+	Email string `gorm2:"db:index=true,unique;api:readOnly"`
+	Name  string `gorm2:"db:default='a,b',index=idx(priority:2,unique),;roles=admin,owner"`
+	Note  string `gorm2:"db:sep=\\;,size=10"`
+}
+
+type namespaceFlags struct {
+	Email string `gorm2:"db:unique,index=true"`
+	Name  string `gorm2:"api:readOnly,hidden"`
+	City  string `gorm2:"db:index=idx,priority:2,unique"`
+	Zip   string `gorm2:"db:index=idx,priority:1;api:readOnly , hidden=false,"`
+}
+
+func TestNamespaceGroups(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tags := tg.Get(&namespaceUser{})
+
+	want := Instructions{
+		"index=true":                   {"Email"},
+		"unique":                       {"Email"},
+		"default='a,b'":                {"Name"},
+		"index=idx(priority:2,unique)": {"Name"},
+		"sep=;":                        {"Note"},
+		"size=10":                      {"Note"},
+	}
+	if got := tags.Namespace("db"); !got.Equal(want) {
+		t.Errorf("Namespace(db) = %v, want %v", got, want)
+	}
+	if got := tags.Namespace(""); !got.Equal(Instructions{"roles=admin,owner": {"Name"}}) {
+		t.Errorf("Namespace() = %v", got)
+	}
+	if got := tags.Namespaces(); !reflect.DeepEqual(got, []string{"api", "db"}) {
+		t.Errorf("Namespaces = %v", got)
+	}
+
+	// The fast path of HasKey sees the grouped instructions too
+	if !tg.HasKey(&namespaceUser{}, "db:unique") || !tg.HasKey(&namespaceUser{}, "db:size") {
+		t.Errorf("HasKey doesn't find grouped instructions")
+	}
+}
+
+func TestNamespaceGroupShapes(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tags := tg.Get(&namespaceFlags{})
+
+	tests := []struct {
+		namespace string
+		want      Instructions
+	}{
+		{"db", Instructions{
+			"unique":               {"Email", "City"},
+			"index=true":           {"Email"},
+			"index=idx,priority:2": {"City"},
+			"index=idx,priority:1": {"Zip"},
+		}},
+		{"api", Instructions{
+			"readOnly":     {"Name", "Zip"},
+			"hidden":       {"Name"},
+			"hidden=false": {"Zip"},
+		}},
+	}
+	for _, test := range tests {
+		if got := tags.Namespace(test.namespace); !got.Equal(test.want) {
+			t.Errorf("Namespace(%s) = %v, want %v", test.namespace, got, test.want)
+		}
+	}
+
+	// The fast path of HasKey agrees with the parser
+	for _, key := range []string{"db:unique", "db:index", "api:hidden", "api:readOnly"} {
+		if !tg.HasKey(&namespaceFlags{}, key) {
+			t.Errorf("HasKey(%s) = false", key)
+		}
+	}
+	if tg.HasKey(&namespaceFlags{}, "db:priority") {
+		t.Errorf("HasKey(db:priority) = true, priority is an option of the index")
+	}
+}
+
+func TestInstructionNamespace(t *testing.T) {
+	tests := []struct {
+		instruction Instruction
+		namespace   string
+		local       Instruction
+	}{
+		{"db:index=true", "db", "index=true"},
+		{"index=a:b", "", "index=a:b"},
+		{"a-b:index", "", "a-b:index"},
+		{":index", "", ":index"},
+	}
+	for _, test := range tests {
+		if namespace, local := test.instruction.Namespace(), test.instruction.Local(); namespace != test.namespace || local != test.local {
+			t.Errorf("%s: Namespace, Local = %q, %q, want %q, %q", test.instruction, namespace, local, test.namespace, test.local)
+		}
+	}
+}
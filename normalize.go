@@ -0,0 +1,34 @@
+package tago
+
+// Normalize registers a normalizer applied to every instruction during parsing, after aliases, placeholders
+// and case normalization (see Alias, Variables, CaseInsensitive), so handlers match consistent instructions.
+// Normalizers are applied in registration order. Flags (instructions without value) have an empty value,
+// returning a non-empty value gives them one; returning an empty key drops the instruction.
+//
+// Example:
+//
+//	// preload=yes, preload=on -> preload=true
+//	t.Normalize(func(key, value string) (string, string) {
+//		if value == "yes" || value == "on" {
+//			value = "true"
+//		}
+//		return key, value
+//	})
+func (t *TaGo) Normalize(normalizer func(key string, value string) (string, string)) *TaGo {
+	t.configChanged()
+	t.normalizers = append(t.normalizers, normalizer)
+	return t
+}
+
+// Apply the registered normalizers to a parsed instruction, false if it must be dropped
+func (t TaGo) normalize(part *tagPart) bool {
+	for _, normalizer := range t.normalizers {
+		key := part.Key
+		part.Key, part.Value = normalizer(part.Key, part.Value)
+		if part.Key == "" && key != "" {
+			return false
+		}
+		part.HasValue = part.HasValue || part.Value != ""
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package tago
+
+import "reflect"
+
+// Option changes the behavior of a single Get / GetNested call
+//
+// Example:
+//
+//	tags := t.GetNested(&MyModel{}, ".", tago.WithMaxDepth(2), tago.WithEmbeddedFlatten(true))
+type Option func(*options)
+
+type options struct {
+	// Separator between the parent field name and the nested field name
+	separator string
+
+	// Maximum nesting level to traverse, 0 for top-level fields only, -1 for no limit
+	maxDepth int
+
+	// Promote the fields of embedded structs, without the embedded field name as prefix
+	flattenEmbedded bool
+
+	// Called for every traversal event (see WithTracer)
+	tracer func(TraceEvent)
+
+	// Limits of the traversal (see WithLimits), and the state of the current call
+	limits Limits
+	state  *parseState
+
+	// Order of the fields of each instruction (see WithFieldOrder)
+	fieldOrder FieldOrder
+
+	// Fields skipped with their subtree (see WithExclude / WithExcludeFunc)
+	excludedPaths map[FieldName]bool
+	excludeFuncs  []func(path FieldName, field reflect.StructField) bool
+
+	// Fields must pass every filter to be parsed and traversed (see WithFieldFilter)
+	fieldFilters []func(field reflect.StructField, depth int) bool
+
+	// Called for every field visited by the traversal with its parsed instructions, for the features built on top of
+	// it (FieldMap, ..), so they don't parse the tags again
+	onField func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction)
+
+	// Skips a field with its subtree when it returns a reason, for the features filtering on instructions (GetForVersion, ..)
+	skip func(path FieldName, field reflect.StructField, owner reflect.Type, instructions []Instruction) string
+
+	// Allocate the nil pointers to structs to reach their fields, for the features walking values (see WithAllocate)
+	allocate bool
+
+	// Bypass the parse cache (see WithCache)
+	noCache bool
+}
+
+// Build the options of a call from its defaults and the given options
+func newOptions(separator string, maxDepth int, opts []Option) options {
+	o := options{
+		separator: separator,
+		maxDepth:  maxDepth,
+		state:     &parseState{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithSeparator sets the separator between parent and nested field names (overrides the GetNested separator argument)
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.separator = separator
+	}
+}
+
+// WithMaxDepth limits the nesting level traversed: 0 for top-level fields only, 1 to include the fields of direct nested structs, ..
+// A negative depth means no limit
+func WithMaxDepth(depth int) Option {
+	return func(o *options) {
+		if depth < 0 {
+			depth = -1
+		}
+		o.maxDepth = depth
+	}
+}
+
+// WithEmbeddedFlatten promotes the fields of embedded (anonymous) structs, like encoding/json does:
+// they are returned without the embedded field name as prefix, e.g. "ID" instead of "Base.ID"
+func WithEmbeddedFlatten(flatten bool) Option {
+	return func(o *options) {
+		o.flattenEmbedded = flatten
+	}
+}
+
+// WithExclude skips the fields at the given paths, and their whole subtree: neither their tags nor the tags of
+// their nested fields are returned, and their types aren't traversed. Paths use the separator of the call.
+//
+// Example:
+//
+//	tags := t.GetNested(&Event{}, ".", tago.WithExclude("RawPayload", "Meta.Debug"))
+func WithExclude(paths ...FieldName) Option {
+	return func(o *options) {
+		if o.excludedPaths == nil {
+			o.excludedPaths = make(map[FieldName]bool)
+		}
+		for _, path := range paths {
+			o.excludedPaths[path] = true
+		}
+	}
+}
+
+// WithExcludeFunc skips the fields, and their whole subtree, for which the predicate returns true (see WithExclude)
+//
+// Example, skip every field of type Blob:
+//
+//	tago.WithExcludeFunc(func(path tago.FieldName, field reflect.StructField) bool {
+//		return field.Type == reflect.TypeOf(Blob{})
+//	})
+func WithExcludeFunc(predicate func(path FieldName, field reflect.StructField) bool) Option {
+	return func(o *options) {
+		o.excludeFuncs = append(o.excludeFuncs, predicate)
+	}
+}
+
+// Check whether a field must be skipped with its subtree
+func (o options) excluded(path FieldName, field reflect.StructField) bool {
+	if o.excludedPaths[path] {
+		return true
+	}
+	for _, predicate := range o.excludeFuncs {
+		if predicate(path, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAllocate makes the features walking the values of a model (ApplyContext, SetDefaults, Sanitize, ..) allocate
+// the nil pointers to structs they traverse, so handlers can set the fields behind them. Without it, these fields
+// are visited with an invalid Value. Handlers are told either way, see FieldContext.Allocated and NilParent.
+// The model must be given as a pointer for its pointers to be settable.
+//
+// Example:
+//
+//	err := t.SetDefaults(&config, tago.WithAllocate(true)) // config.Database is allocated to set its defaults
+func WithAllocate(allocate bool) Option {
+	return func(o *options) {
+		o.allocate = allocate
+	}
+}
+
+// WithFieldFilter only keeps the fields for which the filter returns true: it is applied to every field,
+// at every depth (0 for the top-level fields), before parsing and recursion. A rejected field is skipped
+// along with its subtree. Several filters can be given, a field must pass all of them.
+//
+// Example, only exported fields having a json tag:
+//
+//	tago.WithFieldFilter(func(field reflect.StructField, depth int) bool {
+//		return field.IsExported() && field.Tag.Get("json") != ""
+//	})
+func WithFieldFilter(filter func(field reflect.StructField, depth int) bool) Option {
+	return func(o *options) {
+		o.fieldFilters = append(o.fieldFilters, filter)
+	}
+}
+
+// Check whether a field passes the field filters
+func (o options) accepted(field reflect.StructField, depth int) bool {
+	for _, filter := range o.fieldFilters {
+		if !filter(field, depth) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package tago
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FieldOrder is the order of the fields of each instruction, see WithFieldOrder
+type FieldOrder int
+
+const (
+	// Fields in traversal order: declaration order, nested fields right after their parent (default)
+	DeclarationOrder FieldOrder = iota
+
+	// Fields sorted alphabetically, parents always come before their nested fields ("A" < "A.B")
+	Alphabetical
+
+	// Fields sorted by depth (top-level fields first), then in declaration order
+	// Ancestors always come before their descendants, e.g. to create records parent-first
+	ByDepth
+
+	// Fields sorted by depth, deepest first, then in declaration order
+	// Descendants always come before their ancestors, e.g. to delete records children-first
+	ByDepthReverse
+)
+
+// WithFieldOrder sorts the fields of each instruction in the given order, so e.g. preloads are applied
+// parent-before-child whatever the model declaration looks like.
+//
+// Example:
+//
+//	tags := t.GetNested(&User{}, ".", tago.WithFieldOrder(tago.ByDepth))
+//	// map[preload=true:[Address Orders Address.Country Orders.Items]]
+func WithFieldOrder(order FieldOrder) Option {
+	return func(o *options) {
+		o.fieldOrder = order
+	}
+}
+
+// Sort the fields of every instruction in the given order
+func (t Instructions) sortFields(order FieldOrder, separator string) {
+	if order == DeclarationOrder {
+		return
+	}
+	for _, fields := range t {
+		sortFields(fields, order, separator)
+	}
+}
+
+// Sort fields in place, in the given order (stable, so declaration order is kept between equal fields)
+func sortFields(fields []FieldName, order FieldOrder, separator string) {
+	switch order {
+	case Alphabetical:
+		sort.SliceStable(fields, func(i, j int) bool { return fields[i] < fields[j] })
+	case ByDepth:
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fieldDepth(fields[i], separator) < fieldDepth(fields[j], separator)
+		})
+	case ByDepthReverse:
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fieldDepth(fields[i], separator) > fieldDepth(fields[j], separator)
+		})
+	}
+}
+
+// Nesting level of a field path, 0 for a top-level field
+// Without separator, the depth is inferred from the non identifier characters of the path (see FieldsOrdered)
+func fieldDepth(field FieldName, separator string) int {
+	if separator != "" {
+		return strings.Count(string(field), separator)
+	}
+
+	depth, inSeparator := 0, false
+	for _, r := range string(field) {
+		isIdentifier := r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+		if !isIdentifier && !inSeparator {
+			depth++
+		}
+		inSeparator = !isIdentifier
+	}
+	return depth
+}
+
+// FieldsOrdered returns a sorted copy of the fields of an instruction, see FieldOrder.
+// With ByDepth ancestors come before their descendants, with ByDepthReverse after, which is what
+// consumers applying cascading operations (create / delete) need.
+//
+// The separator is inferred: any sequence of characters that can't be part of a Go identifier
+// ("." , "/", "->", ..) separates two levels.
+//
+// Example:
+//
+//	tags.FieldsOrdered("cascade=true", tago.ByDepthReverse) // [Orders.Items Orders Address]
+func (t Instructions) FieldsOrdered(instruction Instruction, order FieldOrder) []FieldName {
+	fields := append([]FieldName{}, t[instruction]...)
+	sortFields(fields, order, "")
+	return fields
+}
@@ -0,0 +1,302 @@
+package tago
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LoadOverlay reads instruction overlays from a JSON document mapping "package.Type.Field" to a list of instructions.
+// They are merged at parse time like the tags registered with RegisterExternal, so behavior can be tuned
+// without code changes. The type can be given with its package name (models.User) or its full path (github.com/acme/models.User).
+// Instructions are taken as is, a ";" or "\" in a value needs no escaping. Nothing is applied if a key is invalid.
+//
+// Example:
+//
+//	{
+//		"models.User.Email":   ["column=email", "unique"],
+//		"models.User.Address": ["preload=true"]
+//	}
+func (t *TaGo) LoadOverlay(r io.Reader) error {
+	overlay := make(map[string][]string)
+	if err := json.NewDecoder(r).Decode(&overlay); err != nil {
+		return fmt.Errorf("tago: overlay: %w", err)
+	}
+	return t.addOverlay(overlay)
+}
+
+// LoadOverlayFile reads instruction overlays from a JSON (.json) or YAML (.yaml, .yml) file, see LoadOverlay.
+// Only a subset of YAML is supported, a flat mapping of keys to lists of instructions, without external dependency;
+// other YAML syntax (nested mappings, block scalars, anchors, ..) is reported as an error:
+//
+//	# tago.yaml
+//	models.User.Email:
+//	  - column=email
+//	  - unique
+//	models.User.Address: [preload=true]
+func (t *TaGo) LoadOverlayFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		overlay, err := parseYAMLOverlay(file)
+		if err != nil {
+			return fmt.Errorf("tago: overlay %s: %w", path, err)
+		}
+		return t.addOverlay(overlay)
+	default:
+		return t.LoadOverlay(file)
+	}
+}
+
+func (t *TaGo) addOverlay(overlay map[string][]string) error {
+	t.configChanged()
+	if t.overlays == nil {
+		t.overlays = make(map[string]map[string]string)
+	}
+
+	// Validate every key first, an invalid overlay isn't partially applied
+	for key := range overlay {
+		if typeName, field, found := cutLast(key, "."); !found || typeName == "" || field == "" {
+			return fmt.Errorf("tago: overlay: invalid key %q, expected package.Type.Field", key)
+		}
+	}
+
+	for key, instructions := range overlay {
+		typeName, field, _ := cutLast(key, ".")
+		if t.overlays[typeName] == nil {
+			t.overlays[typeName] = make(map[string]string)
+		}
+
+		// Instructions are taken as is, escape them so a ";" in a value doesn't split it
+		escaped := make([]string, len(instructions))
+		for i, instruction := range instructions {
+			escaped[i] = escapeTagValue(instruction)
+		}
+		tag := strings.Join(escaped, ";")
+		if existing := t.overlays[typeName][field]; existing != "" {
+			tag = existing + ";" + tag
+		}
+		t.overlays[typeName][field] = tag
+	}
+	return nil
+}
+
+// Get the overlay tag of a field, looking the owner type up by full path then by package name
+func (t TaGo) overlayTag(owner reflect.Type, field string) string {
+	if len(t.overlays) == 0 || owner.Name() == "" {
+		return ""
+	}
+	if tag, exists := t.overlays[owner.PkgPath()+"."+owner.Name()][field]; exists {
+		return tag
+	}
+	return t.overlays[owner.String()][field]
+}
+
+// Parse the YAML subset of overlay files: "key:" followed by "- item" lines, or "key: [item, item]", or "key: item".
+// Keys and items can be quoted ("a: b", 'a, b'). Anything else (nested mappings, block scalars, anchors, ..) is
+// rejected rather than misread.
+func parseYAMLOverlay(r io.Reader) (map[string][]string, error) {
+	overlay := make(map[string][]string)
+	current := ""
+	itemIndent := -1
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line, err := stripYAMLComment(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.HasPrefix(line[indent:], "\t") {
+			return nil, fmt.Errorf("line %d: tabs can't be used for indentation", lineNumber)
+		}
+
+		// List item of the current key, all at the same indentation
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			switch {
+			case current == "":
+				return nil, fmt.Errorf("line %d: list item without key", lineNumber)
+			case itemIndent >= 0 && indent != itemIndent:
+				return nil, fmt.Errorf("line %d: unexpected indentation, nested lists aren't supported", lineNumber)
+			}
+			itemIndent = indent
+
+			item, err := yamlScalar(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			overlay[current] = append(overlay[current], item)
+			continue
+		}
+
+		if indent > 0 {
+			return nil, fmt.Errorf("line %d: unexpected indentation, nested mappings aren't supported", lineNumber)
+		}
+		rawKey, value, found := cutYAMLKey(trimmed)
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key: value", lineNumber)
+		}
+		key, err := yamlScalar(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		current, itemIndent = key, -1
+		if _, exists := overlay[current]; !exists {
+			overlay[current] = make([]string, 0)
+		}
+
+		var items []string
+		switch {
+		case value == "":
+			// Items on the next lines
+		case strings.HasPrefix(value, "["):
+			items, err = yamlFlowSequence(value)
+		default:
+			var item string
+			item, err = yamlScalar(value)
+			items = []string{item}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		overlay[current] = append(overlay[current], items...)
+	}
+	return overlay, scanner.Err()
+}
+
+// Remove the comment of a YAML line: a # at the start or after white space, outside of quotes
+func stripYAMLComment(line string) (string, error) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case opensQuote(line, i):
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], nil
+		}
+	}
+	if quote != 0 {
+		return "", errors.New("unterminated quoted string")
+	}
+	return line, nil
+}
+
+// Cut a "key: value" line at the first ": " (or final ":") outside of quotes
+func cutYAMLKey(line string) (key, value string, found bool) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case opensQuote(line, i):
+			quote = c
+		case c == ':' && (i+1 == len(line) || line[i+1] == ' '):
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// Whether the quote at s[i] starts a quoted scalar: quotes inside plain scalars (it's) are kept as is
+func opensQuote(s string, i int) bool {
+	return (s[i] == '"' || s[i] == '\'') && (i == 0 || strings.IndexByte(" [,", s[i-1]) >= 0)
+}
+
+// Items of a flow sequence: [item, 'item, with comma', "item"]
+func yamlFlowSequence(value string) ([]string, error) {
+	if !strings.HasSuffix(value, "]") {
+		return nil, errors.New("unterminated flow sequence, it must end on the same line")
+	}
+	inner := value[1 : len(value)-1]
+
+	items := make([]string, 0)
+	var quote byte
+	start := 0
+	for i := 0; i <= len(inner); i++ {
+		if i < len(inner) {
+			switch c := inner[i]; {
+			case quote == '"' && c == '\\':
+				i++
+				continue
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+				continue
+			case opensQuote(inner, i):
+				quote = c
+				continue
+			case c != ',':
+				continue
+			}
+		}
+		if raw := strings.TrimSpace(inner[start:i]); raw != "" {
+			item, err := yamlScalar(raw)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		start = i + 1
+	}
+	return items, nil
+}
+
+// Value of a YAML scalar: double-quoted (with escapes), single-quoted (a quote doubled inside) or plain.
+// Plain scalars starting with a YAML indicator (nested collections, block scalars, anchors, tags, ..) are rejected.
+func yamlScalar(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", nil
+	case s[0] == '"':
+		value, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted string %s", s)
+		}
+		return value, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' || strings.Contains(strings.ReplaceAll(s[1:len(s)-1], "''", ""), "'") {
+			return "", fmt.Errorf("invalid single-quoted string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case strings.ContainsRune("[]{}&*!|>%@`", rune(s[0])):
+		return "", fmt.Errorf("unsupported YAML syntax %q, only strings and lists of strings are supported", s)
+	case strings.Contains(s, ": ") || strings.HasSuffix(s, ":"):
+		return "", fmt.Errorf("unsupported YAML syntax %q, nested mappings aren't supported (quote the value)", s)
+	}
+	return s, nil
+}
+
+// Cut s around the last instance of sep
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,133 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAMLOverlay(t *testing.T) {
+	input := `# tago.yaml
+models.User.Email:
+  - column=email
+  - unique # trailing comment
+models.User.Address: [preload=true, 'default=a, b', "pattern=^#[0-9]+$"]
+models.User.Name: column=name
+"models.User.Note": 'default=it''s: fine'
+models.User.Quote: default=it's
+models.User.Empty:
+`
+	got, err := parseYAMLOverlay(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseYAMLOverlay: %v", err)
+	}
+	want := map[string][]string{
+		"models.User.Email":   {"column=email", "unique"},
+		"models.User.Address": {"preload=true", "default=a, b", "pattern=^#[0-9]+$"},
+		"models.User.Name":    {"column=name"},
+		"models.User.Note":    {"default=it's: fine"},
+		"models.User.Quote":   {"default=it's"},
+		"models.User.Empty":   {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAMLOverlay =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestParseYAMLOverlayUnsupported(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"nested mapping", "models.User:\n  Email:\n    - unique\n", "nested mappings aren't supported"},
+		{"nested list", "models.User.Email:\n  - unique\n    - column=email\n", "nested lists aren't supported"},
+		{"mapping value", "models.User.Email: a: b\n", "nested mappings aren't supported"},
+		{"block scalar", "models.User.Email: |\n  unique\n", "unsupported YAML syntax"},
+		{"anchor", "models.User.Email: &tags [unique]\n", "unsupported YAML syntax"},
+		{"multiline flow", "models.User.Email: [unique,\n  column=email]\n", "unterminated flow sequence"},
+		{"unterminated quote", "models.User.Email: 'unique\n", "unterminated quoted string"},
+		{"item without key", "- unique\n", "list item without key"},
+		{"tab indentation", "models.User.Email:\n\t- unique\n", "tabs can't be used"},
+		{"no key", "models.User.Email\n", "expected key: value"},
+	}
+	for _, test := range tests {
+		_, err := parseYAMLOverlay(strings.NewReader(test.input))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.name, err, test.err)
+		}
+	}
+}
+
+type overlayUser struct {
+	Email string
+	Name  string `gorm2:"column=name"`
+}
+
+func TestLoadOverlay(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	err := tg.LoadOverlay(strings.NewReader(`{
+		"tago.overlayUser.Email": ["column=email", "unique"],
+		"tago.overlayUser.Name":  ["size=64"]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadOverlay: %v", err)
+	}
+
+	got := tg.Get(&overlayUser{})
+	want := Instructions{
+		"column=email": {"Email"},
+		"unique":       {"Email"},
+		"column=name":  {"Name"},
+		"size=64":      {"Name"},
+	}
+	if !got.Equal(want) {
+		t.Errorf("Get with overlay = %v, want %v", got, want)
+	}
+
+	if err := tg.LoadOverlay(strings.NewReader(`{"Email": ["unique"]}`)); err == nil {
+		t.Error("LoadOverlay with a key without type: expected an error")
+	}
+}
+
+func TestLoadOverlayInvalidKey(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	// Nothing is applied when a key is invalid, whatever the order the keys are read in
+	for i := 0; i < 10; i++ {
+		err := tg.LoadOverlay(strings.NewReader(`{
+			"tago.overlayUser.Email": ["unique"],
+			"tago.overlayUser.Name":  ["size=64"],
+			"Email":                  ["index"]
+		}`))
+		if err == nil {
+			t.Fatal("LoadOverlay with an invalid key: expected an error")
+		}
+	}
+	if got, want := tg.Get(&overlayUser{}), (Instructions{"column=name": {"Name"}}); !got.Equal(want) {
+		t.Errorf("Get after an invalid overlay = %v, want %v", got, want)
+	}
+}
+
+func TestLoadOverlayEscapes(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	err := tg.LoadOverlay(strings.NewReader(`{
+		"tago.overlayUser.Email": ["sep=;", "unique"],
+		"tago.overlayUser.Name":  ["regex=\\d+\\", "check=a\\;b"]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadOverlay: %v", err)
+	}
+
+	got := tg.Get(&overlayUser{})
+	want := Instructions{
+		"sep=;":       {"Email"},
+		"unique":      {"Email"},
+		"column=name": {"Name"},
+		`regex=\d+\`:  {"Name"},
+		`check=a\;b`:  {"Name"},
+	}
+	if !got.Equal(want) {
+		t.Errorf("Get with overlay = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package tago
+
+// Override returns a copy of the instructions with the overrides applied, per field and per key:
+//
+//   - an override replaces the instructions of the same key on the same field (preload=false replaces preload=true)
+//   - an override with a key the field doesn't have is added to the field
+//   - an override with the value "-" removes the instructions of that key from the field (preload=-)
+//   - instructions of keys and fields without override are kept as is
+//
+// Example:
+//
+//	tags := t.GetNested(&User{}, ".") // map[preload=true:[Address Orders]]
+//	tenant := tago.NewInstructions().Field("Orders").Set("preload", "false").Build()
+//	tags.Override(tenant) // map[preload=false:[Orders] preload=true:[Address]]
+func (t Instructions) Override(overrides Instructions) Instructions {
+	// Keys overridden for each field
+	overridden := make(map[FieldName]map[string]bool)
+	for instruction, fields := range overrides {
+		for _, field := range fields {
+			if overridden[field] == nil {
+				overridden[field] = make(map[string]bool)
+			}
+			overridden[field][instruction.Key()] = true
+		}
+	}
+
+	result := make(Instructions, len(t))
+	for instruction, fields := range t {
+		kept := make([]FieldName, 0, len(fields))
+		for _, field := range fields {
+			if !overridden[field][instruction.Key()] {
+				kept = append(kept, field)
+			}
+		}
+		if len(kept) > 0 {
+			result[instruction] = kept
+		}
+	}
+
+	for instruction, fields := range overrides {
+		if instruction.Value() == "-" {
+			continue
+		}
+		for _, field := range fields {
+			if !containsField(result[instruction], field) {
+				result[instruction] = append(result[instruction], field)
+			}
+		}
+	}
+	return result
+}
+
+// GetWithOverrides returns the instructions of a model (see Get) with the given overrides applied (see Instructions.Override)
+// Runtime overrides always take precedence over the tags declared on the model.
+// Use WithMaxDepth(-1) to include nested fields.
+//
+// Example:
+//
+//	overrides := tago.NewInstructions().Field("Address").Set("preload", "false").Build()
+//	tags := t.GetWithOverrides(&User{}, overrides, tago.WithMaxDepth(-1))
+func (t TaGo) GetWithOverrides(model interface{}, overrides Instructions, opts ...Option) Instructions {
+	return t.Get(model, opts...).Override(overrides)
+}
+
+func containsField(fields []FieldName, field FieldName) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
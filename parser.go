@@ -0,0 +1,63 @@
+package tago
+
+import "strings"
+
+// Parser parses the tag of a field (the part between the quotes of `name:"..."`) into instructions, "key=value" or "key".
+// A custom Parser can be set with SetParser for tags which don't follow the default grammar (see grammar.go):
+// aliases, variables, normalizers, traversal, prefixing and Apply work the same on the instructions it returns.
+// An error is reported as a Warning of the field, the instructions returned along with it are kept. The Err of the
+// Warning is a *ParseError: parsers can return one to give the position of the error, other errors are wrapped.
+type Parser interface {
+	Parse(tag string) ([]Instruction, error)
+}
+
+// ParserFunc is a function implementing Parser
+type ParserFunc func(tag string) ([]Instruction, error)
+
+func (f ParserFunc) Parse(tag string) ([]Instruction, error) {
+	return f(tag)
+}
+
+// DefaultParser parses tags according to the grammar of grammar.go: "key=value;flag;key2=value2"
+var DefaultParser Parser = ParserFunc(func(tag string) ([]Instruction, error) {
+	parts := parseTag(tag)
+	instructions := make([]Instruction, len(parts))
+	for i, part := range parts {
+		instructions[i] = Instruction(part.instruction())
+	}
+	return instructions, nil
+})
+
+// SetParser replaces the parser of the tags, nil restores the default one (see Parser)
+//
+// Example:
+//
+//	// `meta:"preload:true, column:email"`
+//	t.SetParser(ParserFunc(func(tag string) ([]Instruction, error) {
+//		var instructions []Instruction
+//		for _, item := range strings.Split(tag, ",") {
+//			key, value, _ := strings.Cut(item, ":")
+//			instructions = append(instructions, NewInstruction(strings.TrimSpace(key), strings.TrimSpace(value)))
+//		}
+//		return instructions, nil
+//	}))
+func (t *TaGo) SetParser(parser Parser) *TaGo {
+	t.configChanged()
+	t.parser = parser
+	return t
+}
+
+// Parse a tag into its parts with the configured parser
+func (t TaGo) tagParts(tag string) ([]tagPart, error) {
+	if t.parser == nil {
+		return parseTag(tag), nil
+	}
+
+	instructions, err := t.parser.Parse(tag)
+	parts := make([]tagPart, 0, len(instructions))
+	for _, instruction := range instructions {
+		key, value, hasValue := strings.Cut(string(instruction), "=")
+		parts = append(parts, tagPart{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value), HasValue: hasValue})
+	}
+	return parts, err
+}
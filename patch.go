@@ -0,0 +1,84 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ApplyPatch writes a partial update into a model, only into the fields tagged `patchable=true`.
+// Keys are resolved like Unflatten: `name=` instruction of the fields (their Go name by default), nested fields
+// with dotted keys ("address.city") or nested maps ({"address": {"city": ..}}). Values are converted like Unflatten.
+// Unknown keys, fields not patchable and invalid values are reported, the other keys are still applied.
+// model must be a non-nil pointer to a struct.
+//
+// Example:
+//
+//	type User struct {
+//		Name  string `gorm2:"name=name;patchable=true"`
+//		Email string `gorm2:"name=email"`
+//	}
+//	err := t.ApplyPatch(&user, map[string]any{"name": "Bob"})
+func (t TaGo) ApplyPatch(model interface{}, patch map[string]any) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+	return errors.Join(t.applyPatch(reflect.ValueOf(model).Elem(), patch, "", make(typeFields))...)
+}
+
+func (t TaGo) applyPatch(root reflect.Value, patch map[string]any, prefix string, fields typeFields) []error {
+	// Sorted keys so errors are reported in a stable order
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		path := prefix + key
+
+		// Check the key without allocating the nil pointers on the way, rejected keys leave the model untouched
+		probe, instructions, err := t.resolveKey(root, path, ".", "name", fields, false)
+		if err != nil {
+			errs = append(errs, &PathError{Path: FieldName(path), Err: err})
+			continue
+		}
+		patchable := isPatchable(instructions)
+
+		// Nested map on a struct which isn't patchable as a whole: patch its fields
+		if nested, isMap := patch[key].(map[string]any); isMap && !patchable && typeToElem(probe.Type()).Kind() == reflect.Struct {
+			errs = append(errs, t.applyPatch(root, nested, path+".", fields)...)
+			continue
+		}
+
+		if !patchable {
+			errs = append(errs, &PathError{Path: FieldName(path), Err: errors.New("field is not patchable")})
+			continue
+		}
+
+		// Convert on a detached value first, so an invalid value doesn't allocate anything either
+		converted := reflect.New(probe.Type()).Elem()
+		layout, _ := lookupKey(instructions, "format")
+		if err := assign(converted, patch[key], layout); err != nil {
+			errs = append(errs, &PathError{Path: FieldName(path), Err: err})
+			continue
+		}
+		fieldValue, _, _ := t.resolveKey(root, path, ".", "name", fields, true)
+		fieldValue.Set(converted)
+	}
+	return errs
+}
+
+// Whether a field is tagged patchable=true (or patchable)
+func isPatchable(instructions []Instruction) bool {
+	for _, instruction := range instructions {
+		if instruction.Key() == "patchable" {
+			patchable, err := strconv.ParseBool(instruction.Value())
+			return !strings.Contains(string(instruction), "=") || err == nil && patchable
+		}
+	}
+	return false
+}
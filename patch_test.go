@@ -0,0 +1,48 @@
+package tago
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type patchAddress struct {
+	City string `gorm2:"name=city;patchable"`
+	Zip  string `gorm2:"name=zip"`
+}
+
+type patchUser struct {
+	Name    string        `gorm2:"name=name;Patchable=TRUE"`
+	Email   string        `gorm2:"name=email;patchable=false"`
+	Born    time.Time     `gorm2:"name=born;patchable=true;format=2006-01-02"`
+	Address *patchAddress `gorm2:"name=address"`
+}
+
+func TestApplyPatch(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tg.CaseInsensitive()
+
+	var user patchUser
+	err := tg.ApplyPatch(&user, map[string]any{
+		"name":    "Bob",
+		"born":    "1990-05-01",
+		"address": map[string]any{"city": "Paris"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+	if user.Name != "Bob" || user.Born.Format("2006-01-02") != "1990-05-01" || user.Address == nil || user.Address.City != "Paris" {
+		t.Errorf("ApplyPatch = %+v", user)
+	}
+
+	// Fields not patchable are reported and left untouched, the pointers on the way aren't allocated
+	user = patchUser{}
+	err = tg.ApplyPatch(&user, map[string]any{"email": "bob@example.com", "address.zip": "75000", "missing": 1})
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) || !errors.Is(err, ErrUnknownField) {
+		t.Errorf("ApplyPatch error = %v, want *PathError and ErrUnknownField", err)
+	}
+	if user.Email != "" || user.Address != nil {
+		t.Errorf("ApplyPatch modified fields not patchable: %+v", user)
+	}
+}
@@ -0,0 +1,76 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+)
+
+// FieldInstructions are the instructions of a field in the order they are written in its tag
+type FieldInstructions struct {
+	Field        FieldName
+	Instructions []Instruction
+}
+
+// GetOrdered returns the instructions of every tagged field of a model, nested fields included,
+// fields in discovery order and instructions in tag order, for tags where the order carries meaning (`trim;lowercase;validate=email`).
+// Fields without instruction are omitted.
+//
+// Example:
+//
+//	type User struct {
+//		Email string `gorm2:"trim;lowercase;validate=email"`
+//	}
+//	t.GetOrdered(&User{}, ".") // [{Email [trim lowercase validate=email]}]
+func (t TaGo) GetOrdered(model interface{}, separator string, opts ...Option) []FieldInstructions {
+	fields := make([]FieldInstructions, 0)
+
+	o := newOptions(separator, -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		if len(instructions) > 0 {
+			fields = append(fields, FieldInstructions{Field: path, Instructions: instructions})
+		}
+	}
+	t.mustParseModel(model, o)
+
+	return fields
+}
+
+// ApplyFieldPipeline runs, for each field of a model, the handlers of its instructions in tag order.
+// Handlers are registered by instruction key and receive the instruction (with its value) in the context.
+// An error stops the pipeline of the field, other fields still run; errors are joined, prefixed with the field path.
+// Instructions without handler are ignored. The separator of the paths is "." unless WithSeparator is given.
+//
+// Example:
+//
+//	type User struct {
+//		Email string `gorm2:"trim;lowercase;validate=email"`
+//	}
+//	err := t.ApplyFieldPipeline(&user, map[string]func(FieldContext) error{
+//		"trim":      trim,
+//		"lowercase": lowercase,
+//		"validate":  validate, // ctx.Instruction.Value() == "email"
+//	})
+func (t TaGo) ApplyFieldPipeline(model interface{}, handlers map[string]func(ctx FieldContext) error, opts ...Option) error {
+	normalized := make(map[string]func(ctx FieldContext) error, len(handlers))
+	for key, handler := range handlers {
+		normalized[t.normalizeInstruction(Instruction(key)).Key()] = handler
+	}
+
+	var errs []error
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		for _, instruction := range ctx.Instructions {
+			handler, exists := normalized[instruction.Key()]
+			if !exists {
+				continue
+			}
+
+			call := *ctx
+			call.Instruction = instruction
+			if err := handler(call); err != nil {
+				errs = append(errs, &PathError{Path: ctx.Path, Instruction: instruction, Err: err})
+				return
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package tago
+
+import (
+	"path"
+	"strings"
+)
+
+// PreloadPaths returns the nested paths carrying the instruction, down to maxDepth (-1: no limit), de-duplicated,
+// parents before their children, tailored for ORM Preload / joins configuration.
+// Patterns select the paths with globs matched segment by segment: "*" matches one segment (and the wildcards of
+// path.Match within a segment), "**" any number of segments. Without pattern, every path is returned.
+//
+// Example:
+//
+//	for _, p := range t.PreloadPaths(&User{}, "preload=true", ".", 2, "Orders", "Orders.*") {
+//		db = db.Preload(p.String()) // Orders, Orders.Items
+//	}
+func (t TaGo) PreloadPaths(model interface{}, instruction Instruction, separator string, maxDepth int, patterns ...string) []FieldName {
+	tags := t.mustParseModel(model, newOptions(separator, maxDepth, nil))
+
+	paths := make([]FieldName, 0)
+	seen := make(map[FieldName]bool)
+	for _, field := range tags[t.normalizeInstruction(instruction)] {
+		if seen[field] || !matchesAny(patterns, field, separator) {
+			continue
+		}
+		seen[field] = true
+		paths = append(paths, field)
+	}
+	return paths
+}
+
+func matchesAny(patterns []string, field FieldName, separator string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matchGlob(split(pattern, separator), split(field.String(), separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match path segments against pattern segments, "**" matching any number of segments
+func matchGlob(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlob(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+func split(s string, separator string) []string {
+	if separator == "" {
+		return []string{s}
+	}
+	return strings.Split(s, separator)
+}
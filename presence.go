@@ -0,0 +1,45 @@
+package tago
+
+import "reflect"
+
+// Present returns the set of paths of the fields tagged optional=true which were explicitly provided in a decoded
+// payload, nested fields included, to drive partial updates. A field is present when it is:
+//
+//	a pointer, map, slice or interface    non-nil
+//	a nullable struct (sql.NullString, ..) valid: it has a Valid bool field set to true
+//	any other value                        non-zero, as a zero value can't be told apart from a missing one
+//
+// Use pointers or nullable types for the fields whose zero value is meaningful.
+//
+// Example:
+//
+//	type UserPatch struct {
+//		Name  *string        `api:"optional"`
+//		Phone sql.NullString `api:"optional"`
+//		Age   *int           `api:"optional"`
+//	}
+//	json.Unmarshal([]byte(`{"Name": "Bob", "Phone": null}`), &patch)
+//	t.Present(&patch) // map[Name:true]
+func (t TaGo) Present(model interface{}, opts ...Option) map[FieldName]bool {
+	present := make(map[FieldName]bool)
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		value, exists := lookupKey(ctx.Instructions, "optional")
+		if exists && value != "false" && ctx.Value.IsValid() && isPresent(ctx.Value) {
+			present[ctx.Path] = true
+		}
+	})
+	return present
+}
+
+// Check whether a field value was explicitly provided, see Present
+func isPresent(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return !value.IsNil()
+	case reflect.Struct:
+		if valid := value.FieldByName("Valid"); valid.IsValid() && valid.Kind() == reflect.Bool {
+			return valid.Bool()
+		}
+	}
+	return !value.IsZero()
+}
@@ -0,0 +1,61 @@
+package tago
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type presenceAddress struct {
+	City *string `api:"optional"`
+	Zip  string  `api:"optional"`
+}
+
+type presencePatch struct {
+	Name    *string        `api:"optional"`
+	Phone   sql.NullString `api:"optional"`
+	Age     *int           `api:"optional"`
+	Tags    []string       `api:"optional=true"`
+	Meta    map[string]any `api:"optional"`
+	Extra   any            `api:"optional"`
+	Count   int            `api:"optional"`
+	Email   *string        `api:"optional=false"`
+	Plain   *string
+	Address *presenceAddress
+}
+
+func TestPresent(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	tests := []struct {
+		name    string
+		payload string
+		want    map[FieldName]bool
+	}{
+		{"empty", `{}`, map[FieldName]bool{}},
+		{"nulls", `{"Name": null, "Phone": null, "Tags": null, "Meta": null, "Extra": null}`, map[FieldName]bool{}},
+		{"zero values", `{"Name": "", "Age": 0, "Tags": [], "Meta": {}, "Extra": false, "Count": 0}`, map[FieldName]bool{
+			"Name": true, "Age": true, "Tags": true, "Meta": true, "Extra": true,
+		}},
+		{"values", `{"Name": "Bob", "Count": 2, "Email": "a", "Plain": "b"}`, map[FieldName]bool{"Name": true, "Count": true}},
+		{"nested", `{"Address": {"City": "", "Zip": "75001"}}`, map[FieldName]bool{"Address.City": true, "Address.Zip": true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var patch presencePatch
+			if err := json.Unmarshal([]byte(test.payload), &patch); err != nil {
+				t.Fatal(err)
+			}
+			if got := tg.Present(&patch); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Present(%s) = %v, want %v", test.payload, got, test.want)
+			}
+		})
+	}
+
+	// Nullable structs are present when valid, even holding a zero value
+	patch := presencePatch{Phone: sql.NullString{Valid: true}}
+	if got := tg.Present(patch); !reflect.DeepEqual(got, map[FieldName]bool{"Phone": true}) {
+		t.Errorf("Present with a valid empty NullString = %v", got)
+	}
+}
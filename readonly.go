@@ -0,0 +1,121 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// EnforceReadOnly rejects the changes made to the fields tagged readOnly=true between two values of the same model,
+// nested fields included, for update handlers binding user input onto a copy of a stored model.
+// Values are compared deeply; a field behind a nil pointer counts as its zero value. Each change is reported as
+// a *PathError wrapping ErrReadOnly, joined. See RevertReadOnly to undo the changes instead.
+//
+// Example:
+//
+//	type User struct {
+//		ID    int64  `api:"readOnly"`
+//		Email string
+//	}
+//	updated := stored
+//	json.NewDecoder(r.Body).Decode(&updated)
+//	if err := t.EnforceReadOnly(stored, updated); errors.Is(err, tago.ErrReadOnly) {
+//		http.Error(w, err.Error(), http.StatusUnprocessableEntity) // tago: ID: read-only field changed
+//	}
+func (t TaGo) EnforceReadOnly(original any, updated any, opts ...Option) error {
+	changed, err := t.readOnlyChanges(original, updated, opts)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(changed))
+	for i, field := range changed {
+		errs[i] = &PathError{Path: field.Path, Err: ErrReadOnly}
+	}
+	return errors.Join(errs...)
+}
+
+// RevertReadOnly sets back the fields tagged readOnly=true of updated to their value in original, and returns their
+// paths, see EnforceReadOnly. updated must be a non-nil pointer to a struct.
+//
+// Example:
+//
+//	reverted, err := t.RevertReadOnly(stored, &updated) // [ID]
+func (t TaGo) RevertReadOnly(original any, updated any, opts ...Option) ([]FieldName, error) {
+	if err := checkSettable(updated); err != nil {
+		return nil, err
+	}
+	changed, err := t.readOnlyChanges(original, updated, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := make([]FieldName, 0, len(changed))
+	for _, field := range changed {
+		if !field.Value.CanSet() {
+			continue
+		}
+		if field.original.IsValid() {
+			field.Value.Set(field.original)
+		} else {
+			field.Value.SetZero()
+		}
+		reverted = append(reverted, field.Path)
+	}
+	return reverted, nil
+}
+
+// Field tagged readOnly whose value changed, with its original value (invalid behind a nil pointer)
+type readOnlyChange struct {
+	FieldContext
+	original reflect.Value
+}
+
+// Find the fields tagged readOnly whose value differs between original and updated, in traversal order
+func (t TaGo) readOnlyChanges(original any, updated any, opts []Option) ([]readOnlyChange, error) {
+	originalValue, updatedValue := structValue(reflect.ValueOf(original)), structValue(reflect.ValueOf(updated))
+	if !originalValue.IsValid() || !updatedValue.IsValid() {
+		return nil, notStruct(fmt.Sprintf("%T, %T", original, updated))
+	}
+	if originalValue.Type() != updatedValue.Type() {
+		return nil, fmt.Errorf("tago: can't compare %s with %s", originalValue.Type(), updatedValue.Type())
+	}
+
+	// Values of the read-only fields of the original, by path
+	readOnly := func(ctx *FieldContext) bool {
+		value, exists := lookupKey(ctx.Instructions, "readOnly")
+		return exists && value != "false"
+	}
+	originals := make(map[FieldName]reflect.Value)
+	t.walkContexts(original, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if readOnly(ctx) {
+			originals[ctx.Path] = ctx.Value
+		}
+	})
+
+	var changed []readOnlyChange
+	t.walkContexts(updated, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if !readOnly(ctx) {
+			return
+		}
+		before, after := originals[ctx.Path], ctx.Value
+		if !equalOrZero(before, after, ctx.Field.Type) {
+			changed = append(changed, readOnlyChange{FieldContext: *ctx, original: before})
+		}
+	})
+	return changed, nil
+}
+
+// Compare two field values deeply, an invalid value standing for the zero value of the type
+func equalOrZero(a reflect.Value, b reflect.Value, typ reflect.Type) bool {
+	if !a.IsValid() {
+		a = reflect.Zero(typ)
+	}
+	if !b.IsValid() {
+		b = reflect.Zero(typ)
+	}
+	if !a.CanInterface() || !b.CanInterface() {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,105 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type readOnlyAudit struct {
+	CreatedBy string `api:"readOnly"`
+	Note      string
+}
+
+type readOnlyUser struct {
+	ID     int64    `api:"readOnly"`
+	Email  string   `api:"readOnly=false"`
+	Roles  []string `api:"readOnly=true"`
+	Name   string
+	Audit  *readOnlyAudit
+	hidden int `api:"readOnly"`
+}
+
+func TestEnforceReadOnly(t *testing.T) {
+	tg := TaGo{Name: "api"}
+	stored := readOnlyUser{ID: 1, Email: "a", Roles: []string{"admin"}, Name: "Bob", Audit: &readOnlyAudit{CreatedBy: "root"}}
+
+	tests := []struct {
+		name    string
+		updated readOnlyUser
+		paths   []FieldName
+	}{
+		{"unchanged", stored, nil},
+		{"writable fields", readOnlyUser{ID: 1, Email: "b", Roles: []string{"admin"}, Name: "Alice", Audit: &readOnlyAudit{CreatedBy: "root", Note: "n"}}, nil},
+		{"same values, other slice", readOnlyUser{ID: 1, Roles: append([]string{}, "admin"), Audit: &readOnlyAudit{CreatedBy: "root"}}, nil},
+		{"unexported", readOnlyUser{ID: 1, Roles: []string{"admin"}, Audit: &readOnlyAudit{CreatedBy: "root"}, hidden: 1}, nil},
+		{"changed", readOnlyUser{ID: 2, Roles: []string{"user"}, Audit: &readOnlyAudit{CreatedBy: "me"}}, []FieldName{"ID", "Roles", "Audit.CreatedBy"}},
+		{"nil pointer is a zero value", readOnlyUser{ID: 1, Roles: []string{"admin"}}, []FieldName{"Audit.CreatedBy"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := tg.EnforceReadOnly(stored, &test.updated)
+			if len(test.paths) == 0 {
+				if err != nil {
+					t.Errorf("EnforceReadOnly = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrReadOnly) {
+				t.Fatalf("EnforceReadOnly = %v, want ErrReadOnly", err)
+			}
+			for _, path := range test.paths {
+				if !strings.Contains(err.Error(), string(path)+": ") {
+					t.Errorf("EnforceReadOnly = %v, want an error for %s", err, path)
+				}
+			}
+			if got := strings.Count(err.Error(), "\n") + 1; got != len(test.paths) {
+				t.Errorf("EnforceReadOnly = %v, want %d errors", err, len(test.paths))
+			}
+		})
+	}
+
+	// A zero original against a nil pointer isn't a change
+	if err := tg.EnforceReadOnly(readOnlyUser{Audit: &readOnlyAudit{}}, readOnlyUser{}); err != nil {
+		t.Errorf("EnforceReadOnly of a zero nested value = %v, want nil", err)
+	}
+}
+
+func TestRevertReadOnly(t *testing.T) {
+	tg := TaGo{Name: "api"}
+	stored := readOnlyUser{ID: 1, Roles: []string{"admin"}, Audit: &readOnlyAudit{CreatedBy: "root"}}
+
+	updated := readOnlyUser{ID: 2, Email: "b", Roles: []string{"user"}, Name: "Alice", Audit: &readOnlyAudit{CreatedBy: "me", Note: "n"}}
+	reverted, err := tg.RevertReadOnly(&stored, &updated)
+	if err != nil {
+		t.Fatalf("RevertReadOnly: %v", err)
+	}
+	if want := []FieldName{"ID", "Roles", "Audit.CreatedBy"}; !reflect.DeepEqual(reverted, want) {
+		t.Errorf("RevertReadOnly = %v, want %v", reverted, want)
+	}
+	want := readOnlyUser{ID: 1, Email: "b", Roles: []string{"admin"}, Name: "Alice", Audit: &readOnlyAudit{CreatedBy: "root", Note: "n"}}
+	if !reflect.DeepEqual(updated, want) {
+		t.Errorf("RevertReadOnly set %+v, want %+v", updated, want)
+	}
+
+	// Behind a nil pointer in the original, the field is reset to its zero value
+	updated = readOnlyUser{Audit: &readOnlyAudit{CreatedBy: "me"}}
+	if _, err := tg.RevertReadOnly(readOnlyUser{}, &updated); err != nil || updated.Audit.CreatedBy != "" {
+		t.Errorf("RevertReadOnly = %v, %+v, want CreatedBy reset", err, updated.Audit)
+	}
+}
+
+func TestReadOnlyErrors(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	if err := tg.EnforceReadOnly(nil, readOnlyUser{}); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("EnforceReadOnly(nil) = %v, want ErrNotStruct", err)
+	}
+	if err := tg.EnforceReadOnly(readOnlyUser{}, readOnlyAudit{}); err == nil || !strings.Contains(err.Error(), "can't compare") {
+		t.Errorf("EnforceReadOnly of different types = %v", err)
+	}
+	if _, err := tg.RevertReadOnly(readOnlyUser{}, readOnlyUser{}); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("RevertReadOnly into a struct = %v, want ErrNotStruct", err)
+	}
+}
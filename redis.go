@@ -0,0 +1,153 @@
+package tago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ToHash converts a populated model to the field/value map of a Redis hash (HSET key field value ..).
+// Hash fields are named after the redis= instruction of the fields (their Go name by default), fields tagged "-" are
+// skipped. Nested structs are flattened like Flatten does, their fields prefixed with the parent name and the
+// separator ("address.city"). Values are formatted with FormatValue and the format= instruction of their field;
+// fields tagged encoding=json are stored as JSON instead, which also keeps a nested struct in a single hash field.
+// Nil pointers are omitted, as Redis has no null. Maps and slices of structs have no text form: tag them encoding=json.
+// See FromHash for the reverse and HashTTLs for the expiry of the hash fields.
+//
+// Example:
+//
+//	type Session struct {
+//		UserID  int64          `cache:"redis=uid"`
+//		Expires time.Time      `cache:"redis=exp;format=2006-01-02T15:04:05Z07:00"`
+//		Claims  map[string]any `cache:"redis=claims;encoding=json"`
+//		Device  Device         `cache:"redis=device"` // Name string `cache:"redis=name"`
+//	}
+//	hash, err := t.ToHash(&session, ".") // map[claims:{"admin":true} device.name:ios exp:2024-05-01T10:00:00Z uid:42]
+//	rdb.HSet(ctx, "session:"+id, hash)
+func (t TaGo) ToHash(model interface{}, separator string) (map[string]string, error) {
+	hash := make(map[string]string)
+	err := t.walkHash(model, separator, func(key string, value reflect.Value, instructions []Instruction) error {
+		encoding, _ := lookupKey(instructions, "encoding")
+		switch encoding {
+		case "json":
+			encoded, err := json.Marshal(value.Interface())
+			if err != nil {
+				return err
+			}
+			hash[key] = string(encoded)
+		case "", "text":
+			layout, _ := lookupKey(instructions, "format")
+			formatted, err := t.FormatValue(value, layout)
+			if err != nil {
+				return err
+			}
+			hash[key] = formatted
+		default:
+			return fmt.Errorf("unknown encoding %q, expected json or text", encoding)
+		}
+		return nil
+	})
+	return hash, err
+}
+
+// FromHash sets the fields of a model from the field/value map of a Redis hash (HGETALL), the reverse of ToHash.
+// Values are parsed to the type of their field, with the layout of their format= instruction, or decoded from JSON
+// for the fields tagged encoding=json. Pointers are allocated as needed. model must be a non-nil pointer to a struct;
+// unknown hash fields and invalid values are reported as joined *PathError.
+//
+// Example:
+//
+//	var session Session
+//	err := t.FromHash(rdb.HGetAll(ctx, "session:"+id).Val(), ".", &session)
+func (t TaGo) FromHash(hash map[string]string, separator string, model interface{}) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+	root := reflect.ValueOf(model).Elem()
+
+	// Sorted keys so errors are reported in a stable order
+	keys := make([]string, 0, len(hash))
+	for key := range hash {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	fields := make(typeFields)
+	for _, key := range keys {
+		fieldValue, instructions, err := t.resolveKey(root, key, separator, "redis", fields, true)
+		if err == nil {
+			if encoding, _ := lookupKey(instructions, "encoding"); encoding == "json" {
+				err = json.Unmarshal([]byte(hash[key]), fieldValue.Addr().Interface())
+			} else {
+				layout, _ := lookupKey(instructions, "format")
+				err = assign(fieldValue, hash[key], layout)
+			}
+		}
+		if err != nil {
+			errs = append(errs, &PathError{Path: FieldName(key), Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HashTTLs returns the time to live of the hash fields ToHash writes for a model, from the ttl= instruction of their
+// field (a time.ParseDuration value), to expire them individually (HEXPIRE, Redis 7.4+).
+// Invalid durations are reported as joined *PathError.
+//
+// Example:
+//
+//	type Session struct {
+//		Token string `cache:"redis=token;ttl=15m"`
+//	}
+//	ttls, err := t.HashTTLs(&session, ".") // map[token:15m0s]
+//	for field, ttl := range ttls {
+//		rdb.HExpire(ctx, "session:"+id, ttl, field)
+//	}
+func (t TaGo) HashTTLs(model interface{}, separator string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	err := t.walkHash(model, separator, func(key string, value reflect.Value, instructions []Instruction) error {
+		ttl, exists := lookupKey(instructions, "ttl")
+		if !exists {
+			return nil
+		}
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return err
+		}
+		if duration <= 0 {
+			return fmt.Errorf("invalid ttl %q, expected a positive duration", ttl)
+		}
+		ttls[key] = duration
+		return nil
+	})
+	return ttls, err
+}
+
+// Visit the hash fields of a model by key: the leaves, and the nested structs tagged encoding=json, skipping nil pointers.
+// Errors returned by visit are collected as *PathError.
+func (t TaGo) walkHash(model interface{}, separator string, visit func(key string, value reflect.Value, instructions []Instruction) error) error {
+	value := structValue(reflect.ValueOf(model))
+	if !value.IsValid() {
+		return notStruct(fmt.Sprintf("%T", model))
+	}
+
+	var errs []error
+	t.walkFlat(value, "", separator, "redis", make(typeFields), map[reflect.Type]bool{}, func(key string, owner reflect.Type, value reflect.Value, instructions []Instruction, nested bool) bool {
+		if encoding, _ := lookupKey(instructions, "encoding"); nested && encoding != "json" {
+			return true
+		}
+		if value.Kind() == reflect.Ptr {
+			// Nil pointer, Redis has no null
+			return false
+		}
+		if err := visit(key, value, instructions); err != nil {
+			errs = append(errs, &PathError{Path: FieldName(key), Err: err})
+		}
+		return false
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type redisDevice struct {
+	Name string `cache:"redis=name"`
+	OS   string `cache:"redis=os"`
+}
+
+type redisSession struct {
+	UserID  int64          `cache:"redis=uid;ttl=1h"`
+	Token   string         `cache:"redis=token;ttl=15m"`
+	Expires time.Time      `cache:"redis=exp;format=2006-01-02"`
+	Claims  map[string]any `cache:"redis=claims;encoding=json"`
+	Device  redisDevice    `cache:"redis=device"`
+	Backup  *redisDevice   `cache:"redis=backup;encoding=json"`
+	Spare   *redisDevice   `cache:"redis=spare"`
+	Ratio   float64
+	Secret  string `cache:"-"`
+}
+
+func TestToHash(t *testing.T) {
+	tg := TaGo{Name: "cache"}
+	session := redisSession{
+		UserID:  42,
+		Token:   "abc",
+		Expires: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Claims:  map[string]any{"admin": true},
+		Device:  redisDevice{Name: "ios", OS: "17"},
+		Backup:  &redisDevice{Name: "mac"},
+		Ratio:   0.5,
+		Secret:  "s",
+	}
+
+	hash, err := tg.ToHash(&session, ".")
+	if err != nil {
+		t.Fatalf("ToHash: %v", err)
+	}
+	want := map[string]string{
+		"uid":         "42",
+		"token":       "abc",
+		"exp":         "2024-05-01",
+		"claims":      `{"admin":true}`,
+		"device.name": "ios",
+		"device.os":   "17",
+		"backup":      `{"Name":"mac","OS":""}`,
+		"Ratio":       "0.5",
+	}
+	if !reflect.DeepEqual(hash, want) {
+		t.Errorf("ToHash = %v, want %v", hash, want)
+	}
+
+	// Round trip
+	var back redisSession
+	if err := tg.FromHash(hash, ".", &back); err != nil {
+		t.Fatalf("FromHash: %v", err)
+	}
+	session.Secret = ""
+	if !reflect.DeepEqual(back, session) {
+		t.Errorf("FromHash(ToHash) = %+v, want %+v", back, session)
+	}
+}
+
+func TestFromHash(t *testing.T) {
+	tg := TaGo{Name: "cache"}
+
+	// Pointers are allocated as needed
+	var session redisSession
+	if err := tg.FromHash(map[string]string{"spare/name": "android", "uid": "7"}, "/", &session); err != nil {
+		t.Fatalf("FromHash: %v", err)
+	}
+	if session.UserID != 7 || session.Spare == nil || session.Spare.Name != "android" {
+		t.Errorf("FromHash = %+v, spare %+v", session, session.Spare)
+	}
+}
+
+func TestHashErrors(t *testing.T) {
+	tg := TaGo{Name: "cache"}
+
+	if _, err := tg.ToHash(nil, "."); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("ToHash(nil) = %v, want ErrNotStruct", err)
+	}
+	if err := tg.FromHash(nil, ".", redisSession{}); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("FromHash into a struct = %v, want ErrNotStruct", err)
+	}
+
+	type unknownEncoding struct {
+		A string `cache:"encoding=xml"`
+	}
+	if _, err := tg.ToHash(&unknownEncoding{A: "a"}, "."); err == nil || !strings.Contains(err.Error(), `unknown encoding "xml"`) {
+		t.Errorf("ToHash with an unknown encoding = %v", err)
+	}
+
+	// Errors are sorted by hash field, the other fields are still set
+	var session redisSession
+	err := tg.FromHash(map[string]string{"uid": "x", "missing": "1", "claims": "{", "token": "t"}, ".", &session)
+	if err == nil {
+		t.Fatal("FromHash with invalid fields: expected an error")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "claims") || !strings.Contains(lines[1], "missing") || !strings.Contains(lines[2], "uid") {
+		t.Errorf("FromHash = %v, want the errors of claims, missing and uid", err)
+	}
+	if session.Token != "t" {
+		t.Errorf("FromHash = %+v, want Token set", session)
+	}
+}
+
+func TestHashTTLs(t *testing.T) {
+	tg := TaGo{Name: "cache"}
+
+	ttls, err := tg.HashTTLs(&redisSession{}, ".")
+	if err != nil {
+		t.Fatalf("HashTTLs: %v", err)
+	}
+	if want := map[string]time.Duration{"uid": time.Hour, "token": 15 * time.Minute}; !reflect.DeepEqual(ttls, want) {
+		t.Errorf("HashTTLs = %v, want %v", ttls, want)
+	}
+
+	type invalid struct {
+		A string `cache:"ttl=soon"`
+		B string `cache:"ttl=-1s"`
+	}
+	if _, err := tg.HashTTLs(&invalid{}, "."); err == nil || !strings.Contains(err.Error(), "A: ") || !strings.Contains(err.Error(), `invalid ttl "-1s"`) {
+		t.Errorf("HashTTLs with invalid durations = %v", err)
+	}
+}
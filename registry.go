@@ -0,0 +1,100 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Registry holds models registered under names, to fetch their instructions from a name received at run time
+// (generic admin or CRUD endpoints). Models are parsed once, at registration. A Registry is safe for concurrent use.
+//
+// Example:
+//
+//	registry := NewRegistry(TaGo{Name: "gorm2"})
+//	registry.Register("User", &User{})
+//
+//	tags, exists := registry.Instructions("User")
+//	model, exists := registry.New("User") // *User
+type Registry struct {
+	tag  TaGo
+	opts []Option
+
+	mu     sync.RWMutex
+	models map[string]registeredModel
+}
+
+type registeredModel struct {
+	typ  reflect.Type
+	tags Instructions
+}
+
+// NewRegistry returns an empty Registry parsing models with GetNested and the given options ("." separator by default)
+func NewRegistry(t TaGo, opts ...Option) *Registry {
+	return &Registry{tag: t, opts: opts, models: make(map[string]registeredModel)}
+}
+
+// Register parses a model and registers it under a name. It fails if the name is taken or the model can't be parsed.
+func (r *Registry) Register(name string, model any) error {
+	tags, err := r.tag.Compile(model, append([]Option{WithSeparator(".")}, r.opts...)...)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.models[name]; exists {
+		return fmt.Errorf("tago: model %q already registered", name)
+	}
+	r.models[name] = registeredModel{typ: typeToElem(reflect.TypeOf(model)), tags: tags}
+	return nil
+}
+
+// MustRegister is Register panicking on error, for registrations at startup
+func (r *Registry) MustRegister(name string, model any) *Registry {
+	if err := r.Register(name, model); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Instructions returns the instructions of a registered model (a copy, safe to modify)
+func (r *Registry) Instructions(name string) (Instructions, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model, exists := r.models[name]
+	if !exists {
+		return nil, false
+	}
+	return model.tags.clone(), true
+}
+
+// Type returns the struct type of a registered model
+func (r *Registry) Type(name string) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model, exists := r.models[name]
+	return model.typ, exists
+}
+
+// New returns a pointer to a new zero value of a registered model, e.g. to decode a request body into
+func (r *Registry) New(name string) (any, bool) {
+	typ, exists := r.Type(name)
+	if !exists {
+		return nil, false
+	}
+	return reflect.New(typ).Interface(), true
+}
+
+// Names returns the names of the registered models, sorted
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.models))
+	for name := range r.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,160 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Graph of the relationships between the struct types reachable from a model, see Relations
+type Graph struct {
+	// Struct types, in discovery order (the model first)
+	Nodes []reflect.Type
+
+	// Struct-typed fields, in discovery order
+	Edges []Relation
+}
+
+// Relation is a struct-typed field of a struct type
+type Relation struct {
+	From  reflect.Type
+	To    reflect.Type
+	Field string
+
+	// Instructions of the field, in tag order (foreignKey=, preload=true, ..)
+	Instructions []Instruction
+
+	// The field is a slice or an array of the type (one-to-many)
+	Many bool
+
+	// The field is embedded
+	Embedded bool
+
+	// The field leads back to a type being traversed: it closes a cycle
+	Cycle bool
+}
+
+// Relations returns the graph of the struct types reachable from a model and of the fields between them, with their
+// instructions, to visualize (see Graph.DOT) and validate model relationships. Each type is traversed once,
+// ignored and leaf types (see Ignore) are not part of the graph.
+//
+// Example:
+//
+//	graph := t.Relations(&User{})
+//	for _, relation := range graph.Edges {
+//		fmt.Println(relation) // User.Orders -> []Order [foreignKey=UserID]
+//	}
+func (t TaGo) Relations(model interface{}) Graph {
+	graph := Graph{}
+	if model == nil {
+		return graph
+	}
+	root := typeToElem(reflect.TypeOf(model))
+	if root.Kind() != reflect.Struct {
+		return graph
+	}
+
+	visited := make(map[reflect.Type]bool)
+	visiting := make(map[reflect.Type]bool)
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		visited[typ], visiting[typ] = true, true
+		defer delete(visiting, typ)
+		graph.Nodes = append(graph.Nodes, typ)
+
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			instructions := t.parseInstructions(field, typ, FieldName(field.Name))
+			if containsInstruction(instructions, Skip) {
+				continue
+			}
+
+			target := t.resolveType(field.Type)
+			if target == nil || target.Kind() != reflect.Struct || t.isIgnored(target) {
+				continue
+			}
+
+			graph.Edges = append(graph.Edges, Relation{
+				From:         typ,
+				To:           target,
+				Field:        field.Name,
+				Instructions: instructions,
+				Many:         isCollection(field.Type),
+				Embedded:     field.Anonymous,
+				Cycle:        visiting[target],
+			})
+			if !visited[target] {
+				walk(target)
+			}
+		}
+	}
+	walk(root)
+
+	return graph
+}
+
+// Whether a field type is a slice or an array, through pointers
+func isCollection(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array
+}
+
+func (r Relation) String() string {
+	target := r.To.String()
+	if r.Many {
+		target = "[]" + target
+	}
+	s := r.From.String() + "." + r.Field + " -> " + target
+	if len(r.Instructions) > 0 {
+		parts := make([]string, len(r.Instructions))
+		for i, instruction := range r.Instructions {
+			parts[i] = string(instruction)
+		}
+		s += " [" + strings.Join(parts, " ") + "]"
+	}
+	if r.Cycle {
+		s += " (cycle)"
+	}
+	return s
+}
+
+// DOT returns the graph in the Graphviz DOT language: one node per type, one edge per field labeled with
+// its name and instructions. One-to-many edges have a crow foot, embedded ones are dotted, cycles are red.
+//
+//	dot -Tsvg models.dot > models.svg
+func (g Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph models {\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "  %s;\n", strconv.Quote(node.String()))
+	}
+	for _, edge := range g.Edges {
+		label := edge.Field
+		for _, instruction := range edge.Instructions {
+			label += `\n` + string(instruction)
+		}
+
+		attributes := []string{"label=" + quoteDOT(label)}
+		if edge.Many {
+			attributes = append(attributes, "arrowhead=crow")
+		}
+		if edge.Embedded {
+			attributes = append(attributes, "style=dotted")
+		}
+		if edge.Cycle {
+			attributes = append(attributes, "color=red")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", strconv.Quote(edge.From.String()), strconv.Quote(edge.To.String()), strings.Join(attributes, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Quote a DOT string, keeping the \n line breaks of labels
+func quoteDOT(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,122 @@
+package tago
+
+import (
+	"errors"
+	"strings"
+)
+
+// Violation is a rule of a checking feature broken by a field, see Report
+type Violation struct {
+	// Path of the field, nested fields being prefixed with their parents ("Address.City"), empty for the model itself
+	Path FieldName `json:"path"`
+
+	// Rule broken: the instruction key (required, maxLen, ..)
+	Rule string `json:"rule"`
+
+	// Instruction declaring the rule, as written in the tag
+	Instruction Instruction `json:"instruction,omitempty"`
+
+	// Expected and actual values, when they make sense for the rule (maxLen: "64" and "70")
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+
+	Message string `json:"message"`
+
+	// Error behind the violation, wrapping the sentinel of the feature if any (ErrLimitExceeded, ..)
+	err error
+}
+
+// Err returns the violation as a *PathError
+func (v Violation) Err() error {
+	err := v.err
+	if err == nil {
+		err = errors.New(v.Message)
+	}
+	return &PathError{Path: v.Path, Instruction: v.Instruction, Err: err}
+}
+
+func (v Violation) String() string {
+	return strings.TrimPrefix(v.Err().Error(), "tago: ")
+}
+
+// Report is the result of the checking features (RequiredReport, LimitsReport, tagotest.ModelReport, ..): the
+// violations found, in discovery order, with a JSON form meant for API error responses so that every consumer renders
+// them the same way. It is an error unwrapping to the *PathError of each violation, see Err.
+//
+// Example:
+//
+//	report := t.RequiredReport(&req)
+//	report.Merge(t.LimitsReport(&req))
+//	if !report.Valid() {
+//		w.WriteHeader(http.StatusUnprocessableEntity)
+//		json.NewEncoder(w).Encode(report)
+//		// {"violations":[{"path":"Email","rule":"required","instruction":"required=true","expected":"a value",
+//		//   "actual":"zero value","message":"required field is missing"}]}
+//	}
+type Report struct {
+	Violations []Violation `json:"violations"`
+}
+
+// Valid reports whether the report holds no violation
+func (r Report) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Add appends a violation to the report
+func (r *Report) Add(violation Violation) {
+	r.Violations = append(r.Violations, violation)
+}
+
+// Merge appends the violations of another report
+func (r *Report) Merge(other Report) {
+	r.Violations = append(r.Violations, other.Violations...)
+}
+
+// Paths returns the paths of the fields violating a rule, in order, each once
+func (r Report) Paths() []FieldName {
+	paths := make([]FieldName, 0, len(r.Violations))
+	for _, violation := range r.Violations {
+		if !containsField(paths, violation.Path) {
+			paths = append(paths, violation.Path)
+		}
+	}
+	return paths
+}
+
+// Err returns the report as an error, nil if it is valid
+func (r Report) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return r
+}
+
+// Error joins the errors of the violations, one per line
+func (r Report) Error() string {
+	if r.Valid() {
+		return ""
+	}
+	return errors.Join(r.Unwrap()...).Error()
+}
+
+// Unwrap returns the *PathError of each violation, for errors.Is and errors.As
+func (r Report) Unwrap() []error {
+	errs := make([]error, len(r.Violations))
+	for i, violation := range r.Violations {
+		errs[i] = violation.Err()
+	}
+	return errs
+}
+
+// Add a violation with the error behind it
+func (r *Report) addErr(path FieldName, instruction Instruction, expected string, actual string, err error) {
+	r.Add(Violation{
+		Path:        path,
+		Rule:        instruction.Key(),
+		Instruction: instruction,
+		Expected:    expected,
+		Actual:      actual,
+		Message:     err.Error(),
+		err:         err,
+	})
+}
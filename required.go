@@ -0,0 +1,49 @@
+package tago
+
+import (
+	"errors"
+	"strconv"
+)
+
+// CheckRequired returns the paths of the fields tagged `required=true` (or `required`) holding their zero value,
+// nested fields included, as a lightweight precondition check before persistence.
+// A nil pointer is missing, a pointer to a zero value is not: it was explicitly set.
+// Fields below a nil pointer or inside slices and maps aren't checked.
+//
+// Example:
+//
+//	type User struct {
+//		Email string  `gorm2:"required=true"`
+//		Age   *int    `gorm2:"required=true"`
+//		Home  Address // Address.City is required
+//	}
+//	t.CheckRequired(User{Email: "bob@example.com"}) // [Age Home.City]
+func (t TaGo) CheckRequired(model interface{}, opts ...Option) []FieldName {
+	return t.RequiredReport(model, opts...).Paths()
+}
+
+// RequiredReport returns the violations of the required fields of a model, see CheckRequired, for API error responses.
+//
+// Example:
+//
+//	t.RequiredReport(User{}).Violations[0] // {Path: Email, Rule: required, Expected: a value, Actual: zero value, ..}
+func (t TaGo) RequiredReport(model interface{}, opts ...Option) Report {
+	var report Report
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		value, exists := lookupKey(ctx.Instructions, "required")
+		if !exists || !ctx.Value.IsValid() {
+			return
+		}
+		if required, err := strconv.ParseBool(value); err != nil || !required {
+			return
+		}
+		if ctx.Value.IsZero() {
+			actual := "zero value"
+			if isNilPointer(ctx.Value) {
+				actual = "nil"
+			}
+			report.addErr(ctx.Path, Instruction("required="+value), "a value", actual, errors.New("required field is missing"))
+		}
+	})
+	return report
+}
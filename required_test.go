@@ -0,0 +1,73 @@
+package tago
+
+import (
+	"reflect"
+	"testing"
+)
+
+type requiredAddress struct {
+	City string `gorm2:"required=true"`
+}
+
+type requiredUser struct {
+	Email    string `gorm2:"required=true"`
+	Nickname string `gorm2:"required"`
+	Age      *int   `gorm2:"required=true"`
+	Bio      string `gorm2:"required=false"`
+	Note     string `gorm2:"required=maybe"`
+	Home     requiredAddress
+	Work     *requiredAddress
+	Previous []requiredAddress
+}
+
+func TestCheckRequired(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	zero := 0
+
+	tests := []struct {
+		name  string
+		model any
+		want  []FieldName
+	}{
+		{"empty", requiredUser{}, []FieldName{"Email", "Nickname", "Age", "Home.City"}},
+		{"pointer", &requiredUser{}, []FieldName{"Email", "Nickname", "Age", "Home.City"}},
+		{"pointer to a zero value", requiredUser{Email: "a", Nickname: "b", Age: &zero, Home: requiredAddress{City: "Paris"}}, nil},
+		{"nested pointer", requiredUser{Email: "a", Nickname: "b", Age: &zero, Home: requiredAddress{City: "Paris"}, Work: &requiredAddress{}},
+			[]FieldName{"Work.City"}},
+		{"slices aren't checked", requiredUser{Email: "a", Nickname: "b", Age: &zero, Home: requiredAddress{City: "Paris"}, Previous: []requiredAddress{{}}},
+			nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := tg.CheckRequired(test.model)
+			if len(got) == 0 && len(test.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("CheckRequired = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRequiredReport(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	report := tg.RequiredReport(requiredUser{Nickname: "b", Home: requiredAddress{City: "Paris"}})
+	want := []Violation{
+		{Path: "Email", Rule: "required", Instruction: "required=true", Expected: "a value", Actual: "zero value"},
+		{Path: "Age", Rule: "required", Instruction: "required=true", Expected: "a value", Actual: "nil"},
+	}
+	if len(report.Violations) != len(want) {
+		t.Fatalf("RequiredReport = %+v, want %d violations", report.Violations, len(want))
+	}
+	for i, violation := range report.Violations {
+		violation.Message, violation.err = "", nil
+		if violation != want[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, violation, want[i])
+		}
+	}
+	if report.Err() == nil {
+		t.Error("RequiredReport.Err() = nil, want an error")
+	}
+}
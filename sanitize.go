@@ -0,0 +1,125 @@
+package tago
+
+import (
+	"html"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Sanitizer transforms a string value, arg is the value of its instruction (e.g. "120" for maxLen=120, "true" for a flag)
+type Sanitizer func(value string, arg string) string
+
+// DefaultSanitizers are the sanitizers available to Sanitize without registration
+//
+//	trim        remove leading and trailing white space
+//	lower       lower case
+//	upper       upper case
+//	maxLen=N    truncate to N characters
+//	stripHTML   remove HTML tags and unescape entities
+var DefaultSanitizers = map[string]Sanitizer{
+	"trim":  func(value, _ string) string { return strings.TrimSpace(value) },
+	"lower": func(value, _ string) string { return strings.ToLower(value) },
+	"upper": func(value, _ string) string { return strings.ToUpper(value) },
+	"maxLen": func(value, arg string) string {
+		n, err := strconv.Atoi(arg)
+		if runes := []rune(value); err == nil && n >= 0 && len(runes) > n {
+			return string(runes[:n])
+		}
+		return value
+	},
+	"stripHTML": func(value, _ string) string { return stripHTML(value) },
+}
+
+// RegisterSanitizer registers a custom sanitizer for the instruction key, used by Sanitize.
+// It replaces the default sanitizer of the same key, if any.
+//
+// Example:
+//
+//	t.RegisterSanitizer("slug", func(value, _ string) string {
+//		return strings.ReplaceAll(strings.ToLower(value), " ", "-")
+//	})
+func (t *TaGo) RegisterSanitizer(key string, sanitizer Sanitizer) *TaGo {
+	if t.sanitizers == nil {
+		t.sanitizers = make(map[string]Sanitizer)
+	}
+	t.sanitizers[key] = sanitizer
+	return t
+}
+
+// Sanitize applies the sanitizer instructions of the string fields of a model, nested fields included,
+// in tag order: `trim=true;lower=true;maxLen=120`. A sanitizer instruction with the value "false" is disabled.
+// Pointers to strings and slices of strings are sanitized too. See DefaultSanitizers and RegisterSanitizer.
+// model must be a non-nil pointer to a struct.
+//
+// maxLen= is also a size limit (see SizeLimits): sanitizing a request truncates its strings to the limit, so
+// CheckLimits called afterwards doesn't reject them. Call CheckLimits first to reject oversized strings instead.
+//
+// Example:
+//
+//	type User struct {
+//		Email string `gorm2:"trim;lower"`
+//		Bio   string `gorm2:"stripHTML;maxLen=120"`
+//	}
+//	err := t.Sanitize(&user)
+func (t TaGo) Sanitize(model interface{}, opts ...Option) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if !ctx.Value.IsValid() || !ctx.Value.CanSet() {
+			return
+		}
+		for _, instruction := range ctx.Instructions {
+			sanitizer, exists := t.sanitizer(instruction.Key())
+			if !exists || instruction.Value() == "false" {
+				continue
+			}
+			sanitizeValue(ctx.Value, func(s string) string { return sanitizer(s, instruction.Value()) })
+		}
+	})
+	return nil
+}
+
+// Get the sanitizer of an instruction key, registered or default
+func (t TaGo) sanitizer(key string) (Sanitizer, bool) {
+	if sanitizer, exists := t.sanitizers[key]; exists {
+		return sanitizer, true
+	}
+	sanitizer, exists := DefaultSanitizers[key]
+	return sanitizer, exists
+}
+
+// Apply fn to a string value, the string a pointer points to, or the strings of a slice
+func sanitizeValue(value reflect.Value, fn func(string) string) {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(fn(value.String()))
+	case reflect.Ptr:
+		if !value.IsNil() {
+			sanitizeValue(value.Elem(), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			sanitizeValue(value.Index(i), fn)
+		}
+	}
+}
+
+// Remove the HTML tags of a string and unescape its entities: "<b>Tom &amp; Jerry</b>" -> "Tom & Jerry"
+func stripHTML(s string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return html.UnescapeString(b.String())
+}
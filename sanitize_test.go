@@ -0,0 +1,102 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sanitizeProfile struct {
+	Website string `gorm2:"trim;lower"`
+}
+
+type sanitizeUser struct {
+	Email    string   `gorm2:"trim;lower"`
+	Code     string   `gorm2:"upper=true"`
+	Bio      string   `gorm2:"stripHTML;maxLen=12"`
+	Title    string   `gorm2:"maxLen=10;trim"`
+	Raw      string   `gorm2:"trim=false"`
+	Nickname *string  `gorm2:"trim"`
+	Tags     []string `gorm2:"trim;lower"`
+	Slug     string   `gorm2:"slug"`
+	Profile  sanitizeProfile
+	Count    int `gorm2:"trim"`
+}
+
+func TestSanitize(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tg.RegisterSanitizer("slug", func(value, _ string) string {
+		return strings.ReplaceAll(strings.ToLower(value), " ", "-")
+	})
+
+	nickname := "  Bob "
+	user := sanitizeUser{
+		Email:    "  Bob@Example.COM ",
+		Code:     "fr",
+		Bio:      "<b>Tom &amp; Jerry</b> forever",
+		Title:    "  a long title",
+		Raw:      "  raw ",
+		Nickname: &nickname,
+		Tags:     []string{" Go ", "TAGS"},
+		Slug:     "Hello World",
+		Profile:  sanitizeProfile{Website: " HTTPS://Example.com "},
+		Count:    3,
+	}
+	if err := tg.Sanitize(&user); err != nil {
+		t.Fatalf("Sanitize: %v", err)
+	}
+
+	sanitizedNickname := "Bob"
+	want := sanitizeUser{
+		Email: "bob@example.com",
+		Code:  "FR",
+		Bio:   "Tom & Jerry ",
+		// Sanitizers run in tag order: truncated, then trimmed
+		Title:    "a long t",
+		Raw:      "  raw ",
+		Nickname: &sanitizedNickname,
+		Tags:     []string{"go", "tags"},
+		Slug:     "hello-world",
+		Profile:  sanitizeProfile{Website: "https://example.com"},
+		Count:    3,
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("Sanitize = %+v, want %+v", user, want)
+	}
+}
+
+func TestSanitizers(t *testing.T) {
+	tests := []struct {
+		sanitizer string
+		value     string
+		arg       string
+		want      string
+	}{
+		{"trim", " \ta b\n", "true", "a b"},
+		{"lower", "ÉCOLE", "true", "école"},
+		{"upper", "école", "true", "ÉCOLE"},
+		{"maxLen", "héllo", "2", "hé"},
+		{"maxLen", "héllo", "10", "héllo"},
+		{"maxLen", "héllo", "0", ""},
+		{"maxLen", "héllo", "-1", "héllo"},
+		{"maxLen", "héllo", "many", "héllo"},
+		{"stripHTML", `<p class="a">1 &lt; 2</p><br/>`, "true", "1 < 2"},
+		{"stripHTML", "a > b", "true", "a > b"},
+	}
+	for _, test := range tests {
+		if got := DefaultSanitizers[test.sanitizer](test.value, test.arg); got != test.want {
+			t.Errorf("%s(%q, %q) = %q, want %q", test.sanitizer, test.value, test.arg, got, test.want)
+		}
+	}
+}
+
+func TestSanitizeErrors(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	for _, model := range []any{nil, sanitizeUser{}, (*sanitizeUser)(nil)} {
+		if err := tg.Sanitize(model); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("Sanitize(%T) = %v, want ErrNotStruct", model, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tago
+
+import "reflect"
+
+// Select returns the names of the fields carrying the marker instruction, nested fields included, in discovery order:
+// the value of their nameKey instruction (e.g. column=), or their path if they don't have one.
+// Handy to drive SELECT column lists or API field filtering from the model.
+//
+// Example:
+//
+//	type User struct {
+//		ID    uint64 `gorm2:"column=id;listView=true"`
+//		Email string `gorm2:"column=email;listView=true"`
+//		Bio   string `gorm2:"column=bio"`
+//	}
+//	t.Select(&User{}, "listView=true", "column") // [id email]
+func (t TaGo) Select(model interface{}, instruction Instruction, nameKey string, opts ...Option) []string {
+	instruction = t.normalizeInstruction(instruction)
+	nameKey = t.normalizeInstruction(Instruction(nameKey)).Key()
+	names := make([]string, 0)
+
+	o := newOptions(".", -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		if !containsInstruction(instructions, instruction) {
+			return
+		}
+		if name, exists := lookupKey(instructions, nameKey); exists {
+			names = append(names, name)
+		} else {
+			names = append(names, path.String())
+		}
+	}
+	t.mustParseModel(model, o)
+
+	return names
+}
+
+func containsInstruction(instructions []Instruction, instruction Instruction) bool {
+	for _, i := range instructions {
+		if i == instruction {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package tago
+
+import (
+	"reflect"
+	"testing"
+)
+
+type selectAddress struct {
+	City    string `gorm2:"column=city;listView=true"`
+	Country string `gorm2:"listView=true"`
+}
+
+type selectUser struct {
+	ID      uint64 `gorm2:"column=id;listView=true"`
+	Email   string `gorm2:"column=email;listView=true"`
+	Bio     string `gorm2:"column=bio"`
+	Admin   bool   `gorm2:"column=admin;listView=false"`
+	Address selectAddress
+	Secret  string `gorm2:"-"`
+}
+
+type selectCaseUser struct {
+	ID    uint64 `gorm2:"Column=id;ListView=TRUE"`
+	Email string `gorm2:"COLUMN=email;listview=true"`
+}
+
+func TestSelect(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	tests := []struct {
+		name        string
+		model       any
+		instruction Instruction
+		nameKey     string
+		opts        []Option
+		want        []string
+	}{
+		{"names", &selectUser{}, "listView=true", "column", nil, []string{"id", "email", "city", "Address.Country"}},
+		{"paths", selectUser{}, "listView=true", "", nil, []string{"ID", "Email", "Address.City", "Address.Country"}},
+		{"other value", &selectUser{}, "listView=false", "column", nil, []string{"admin"}},
+		{"max depth", &selectUser{}, "listView=true", "column", []Option{WithMaxDepth(0)}, []string{"id", "email"}},
+		{"no match", &selectUser{}, "hidden", "column", nil, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tg.Select(test.model, test.instruction, test.nameKey, test.opts...); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Select(%s, %s) = %v, want %v", test.instruction, test.nameKey, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectCaseInsensitive(t *testing.T) {
+	tg := &TaGo{Name: "gorm2"}
+	tg.CaseInsensitive()
+
+	// The marker and the name key are normalized like the tags
+	if got, want := tg.Select(&selectCaseUser{}, "LISTVIEW=True", "COLUMN"), []string{"id", "email"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Select = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package tago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// SizeLimit is the size constraints of a field, see SizeLimits. A zero limit means no limit.
+type SizeLimit struct {
+	// Maximum number of items of a slice, array or map (maxItems=)
+	MaxItems int
+
+	// Maximum length of a string, in characters (maxLen=)
+	MaxLen int
+
+	// Maximum size of a string or []byte, or of the JSON encoding of other values, in bytes (maxBytes=)
+	MaxBytes int
+}
+
+// SizeLimits returns the size constraints declared on the fields of a model with the maxItems=, maxLen= and maxBytes=
+// instructions, nested fields included, by path. Invalid limits (not a positive integer) are reported as a Report.
+//
+// maxLen= is also a sanitizer (see Sanitize) truncating strings to the limit: check the limits of a request before
+// sanitizing it to reject oversized strings, after to accept them truncated.
+//
+// Example:
+//
+//	type UploadRequest struct {
+//		Name string   `api:"maxLen=128"`
+//		Tags []string `api:"maxItems=20"`
+//		Data []byte   `api:"maxBytes=1048576"`
+//	}
+//	limits, err := t.SizeLimits(&UploadRequest{}) // map[Data:{0 0 1048576} Name:{0 128 0} Tags:{20 0 0}]
+func (t TaGo) SizeLimits(model interface{}, opts ...Option) (map[FieldName]SizeLimit, error) {
+	limits := make(map[FieldName]SizeLimit)
+	var report Report
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		if limit := t.sizeLimit(ctx, &report); limit != (SizeLimit{}) {
+			limits[ctx.Path] = limit
+		}
+	})
+	return limits, report.Err()
+}
+
+// CheckLimits enforces the size constraints of a decoded request (see SizeLimits), nested fields included: a
+// payload-size protection complementing validation. Fields behind a nil pointer aren't checked. Every field exceeding a
+// limit is reported as a *PathError wrapping ErrLimitExceeded, along with invalid limits; the error is a Report,
+// see LimitsReport.
+//
+// Example:
+//
+//	if err := t.CheckLimits(&req); err != nil {
+//		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+//		return
+//	}
+//	// tago: Tags: maxItems=20: limit exceeded: 25 items
+func (t TaGo) CheckLimits(model interface{}, opts ...Option) error {
+	return t.LimitsReport(model, opts...).Err()
+}
+
+// LimitsReport returns the violations of the size constraints of a decoded request, see CheckLimits.
+// Exceeded limits give the limit as expected value and the size as actual value.
+//
+// Example:
+//
+//	t.LimitsReport(&req).Violations // [{Path: Tags, Rule: maxItems, Instruction: maxItems=20, Expected: 20, Actual: 25, ..}]
+func (t TaGo) LimitsReport(model interface{}, opts ...Option) Report {
+	var report Report
+	t.walkContexts(model, newOptions(".", -1, opts), func(ctx *FieldContext) {
+		invalid := len(report.Violations)
+		limit := t.sizeLimit(ctx, &report)
+		if len(report.Violations) > invalid || limit == (SizeLimit{}) || !ctx.Value.IsValid() {
+			return
+		}
+
+		value := ctx.Value
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return
+			}
+			value = value.Elem()
+		}
+		check := func(key string, limit int, actual int, unit string) {
+			if actual > limit {
+				expected := strconv.Itoa(limit)
+				report.addErr(ctx.Path, Instruction(key+"="+expected), expected, strconv.Itoa(actual), fmt.Errorf("%w: %d %s", ErrLimitExceeded, actual, unit))
+			}
+		}
+		misapplied := func(key string, limit int, err error) {
+			report.addErr(ctx.Path, Instruction(key+"="+strconv.Itoa(limit)), "", "", err)
+		}
+
+		if limit.MaxItems > 0 {
+			switch value.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				check("maxItems", limit.MaxItems, value.Len(), "items")
+			default:
+				misapplied("maxItems", limit.MaxItems, fmt.Errorf("%s has no items", value.Type()))
+			}
+		}
+		if limit.MaxLen > 0 {
+			if value.Kind() != reflect.String {
+				misapplied("maxLen", limit.MaxLen, fmt.Errorf("%s is not a string", value.Type()))
+			} else {
+				check("maxLen", limit.MaxLen, utf8.RuneCountInString(value.String()), "characters")
+			}
+		}
+		if limit.MaxBytes > 0 {
+			if size, err := byteSize(value); err != nil {
+				misapplied("maxBytes", limit.MaxBytes, err)
+			} else {
+				check("maxBytes", limit.MaxBytes, size, "bytes")
+			}
+		}
+	})
+	return report
+}
+
+// Size constraints of a field, zero if it has none. Invalid limits are added to the report.
+func (t TaGo) sizeLimit(ctx *FieldContext, report *Report) SizeLimit {
+	var limit SizeLimit
+	instructions := ctx.Instructions
+	keys := []string{"maxItems", "maxLen", "maxBytes"}
+	for i, target := range []*int{&limit.MaxItems, &limit.MaxLen, &limit.MaxBytes} {
+		key := keys[i]
+		value, exists := lookupKey(instructions, key)
+		if !exists {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			report.addErr(ctx.Path, Instruction(key+"="+value), "", "", errors.New("invalid limit, expected a positive integer"))
+			continue
+		}
+		*target = n
+	}
+	return limit
+}
+
+// Size in bytes of a value: its length for strings and []byte, the length of its JSON encoding otherwise
+func byteSize(value reflect.Value) (int, error) {
+	switch {
+	case value.Kind() == reflect.String:
+		return value.Len(), nil
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+		return value.Len(), nil
+	case !value.CanInterface():
+		return 0, fmt.Errorf("%s can't be measured", value.Type())
+	}
+	encoded, err := json.Marshal(value.Interface())
+	return len(encoded), err
+}
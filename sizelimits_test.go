@@ -0,0 +1,117 @@
+package tago
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type limitsItem struct {
+	Label string `api:"maxLen=3"`
+}
+
+type limitsRequest struct {
+	Name  string            `api:"maxLen=5"`
+	Tags  []string          `api:"maxItems=2"`
+	Data  []byte            `api:"maxBytes=4"`
+	Meta  map[string]string `api:"maxBytes=10"`
+	Item  *limitsItem
+	Count int `api:"maxLen=2"`
+}
+
+func TestSizeLimits(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	limits, err := tg.SizeLimits(&limitsRequest{})
+	if err != nil {
+		t.Fatalf("SizeLimits: %v", err)
+	}
+	want := map[FieldName]SizeLimit{
+		"Name":       {MaxLen: 5},
+		"Tags":       {MaxItems: 2},
+		"Data":       {MaxBytes: 4},
+		"Meta":       {MaxBytes: 10},
+		"Item.Label": {MaxLen: 3},
+		"Count":      {MaxLen: 2},
+	}
+	if !reflect.DeepEqual(limits, want) {
+		t.Errorf("SizeLimits = %v, want %v", limits, want)
+	}
+
+	type invalid struct {
+		A string `api:"maxLen=0"`
+		B string `api:"maxItems=many"`
+	}
+	if _, err := tg.SizeLimits(&invalid{}); err == nil || !strings.Contains(err.Error(), "maxLen=0") || !strings.Contains(err.Error(), "maxItems=many") {
+		t.Errorf("SizeLimits with invalid limits: %v", err)
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	tests := []struct {
+		name    string
+		request limitsRequest
+		paths   []FieldName
+	}{
+		{"within limits", limitsRequest{Name: "héllo", Tags: []string{"a", "b"}, Data: []byte("abcd"), Meta: map[string]string{"a": "b"}}, nil},
+		{"nil pointer", limitsRequest{Item: nil}, nil},
+		{"exceeded", limitsRequest{
+			Name: "abcdef",
+			Tags: []string{"a", "b", "c"},
+			Data: []byte("abcde"),
+			Meta: map[string]string{"key": "value"},
+			Item: &limitsItem{Label: "abcd"},
+		}, []FieldName{"Name", "Tags", "Data", "Meta", "Item.Label"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := tg.LimitsReport(&test.request)
+			var paths []FieldName
+			for _, violation := range report.Violations {
+				if violation.Path == "Count" {
+					continue
+				}
+				paths = append(paths, violation.Path)
+				if !errors.Is(violation.Err(), ErrLimitExceeded) {
+					t.Errorf("violation %s: error = %v, want ErrLimitExceeded", violation.Path, violation.Err())
+				}
+			}
+			if !reflect.DeepEqual(paths, test.paths) {
+				t.Errorf("LimitsReport paths = %v, want %v", paths, test.paths)
+			}
+		})
+	}
+
+	// A limit on a value it doesn't apply to is reported
+	if err := tg.CheckLimits(&limitsRequest{}); err == nil || !strings.Contains(err.Error(), "int is not a string") {
+		t.Errorf("CheckLimits with maxLen on an int: %v", err)
+	}
+}
+
+func TestSanitizeMaxLenLimit(t *testing.T) {
+	tg := TaGo{Name: "api"}
+
+	type request struct {
+		Name string `api:"trim;maxLen=5"`
+	}
+
+	// Checked first, the oversized string is rejected
+	req := request{Name: " abcdefgh "}
+	if err := tg.CheckLimits(&req); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("CheckLimits before Sanitize: error = %v, want ErrLimitExceeded", err)
+	}
+
+	// Sanitized first, it's truncated to the limit and accepted
+	if err := tg.Sanitize(&req); err != nil {
+		t.Fatalf("Sanitize: %v", err)
+	}
+	if req.Name != "abcde" {
+		t.Errorf("Sanitize = %q, want %q", req.Name, "abcde")
+	}
+	if err := tg.CheckLimits(&req); err != nil {
+		t.Errorf("CheckLimits after Sanitize: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package tago
+
+import "sort"
+
+// Stats summarizes instructions, see Instructions.Stats
+type Stats struct {
+	// Number of distinct instructions
+	Instructions int
+
+	// Number of fields per instruction key, all values included
+	Keys map[string]int
+
+	// Number of distinct fields carrying at least one instruction
+	TaggedFields int
+
+	// Deepest nesting level of a tagged field, 0 if only top-level fields are tagged
+	MaxDepth int
+
+	// Keys set more than once on the same field (with different values), sorted, by field
+	Duplicates map[FieldName][]string
+}
+
+// Stats returns a summary of the instructions, for dashboards or to assert model complexity budgets in tests.
+// The nesting level of fields is inferred from their path, see FieldsOrdered.
+//
+// Example:
+//
+//	stats := t.GetNested(&User{}, ".").Stats()
+//	if stats.MaxDepth > 3 {
+//		t.Errorf("User is nested too deeply: %d levels", stats.MaxDepth)
+//	}
+func (t Instructions) Stats() Stats {
+	stats := Stats{
+		Instructions: len(t),
+		Keys:         make(map[string]int),
+		Duplicates:   make(map[FieldName][]string),
+	}
+
+	keysByField := make(map[FieldName]map[string]int)
+	for instruction, fields := range t {
+		key := instruction.Key()
+		for _, field := range fields {
+			stats.Keys[key]++
+
+			if keysByField[field] == nil {
+				keysByField[field] = make(map[string]int)
+			}
+			keysByField[field][key]++
+
+			if depth := fieldDepth(field, ""); depth > stats.MaxDepth {
+				stats.MaxDepth = depth
+			}
+		}
+	}
+
+	stats.TaggedFields = len(keysByField)
+	for field, keys := range keysByField {
+		for key, count := range keys {
+			if count > 1 {
+				stats.Duplicates[field] = append(stats.Duplicates[field], key)
+			}
+		}
+		sort.Strings(stats.Duplicates[field])
+	}
+	return stats
+}
@@ -0,0 +1,91 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetNestedUnder returns the custom tags of the subtree rooted at the given field path only (see GetNested):
+// the tags of the field itself and of all its nested fields. Field names are full paths from the model,
+// as GetNested would return them, so results can be combined.
+// An error is returned if the model isn't a struct (ErrNotStruct), the path doesn't exist in the model
+// (ErrUnknownField) or is at or under a field tagged "-" (ErrSkippedField); a limit
+// exceeded (see WithLimits) is returned along with the tags found until then.
+//
+// Useful on very large models when only a branch is needed.
+//
+// Example:
+//
+//	tags, err := t.GetNestedUnder(&Order{}, "Items", ".")
+//	// map[preload=true:[Items Items.Product]]
+func (t TaGo) GetNestedUnder(model interface{}, path FieldName, separator string, opts ...Option) (Instructions, error) {
+	if model == nil {
+		return nil, notStruct("nil model")
+	}
+	o := t.withLogger(newOptions(separator, -1, opts))
+	modelType := typeToElem(reflect.TypeOf(model))
+
+	owner, field, depth, err := t.resolvePath(modelType, path, o.separator)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags of the root field itself (named with its full path), a field tagged "-" has no subtree to return
+	fieldTags := t.parseField(field, owner, path)
+	if _, skipped := fieldTags[Skip]; skipped {
+		return nil, &PathError{Path: path, Instruction: Skip, Err: ErrSkippedField}
+	}
+	prefix := strings.TrimSuffix(string(path), field.Name)
+	tags := make(Instructions)
+	tags.concat(fieldTags, prefix)
+
+	// Then its nested fields
+	fieldType := t.resolveType(field.Type)
+	if fieldType != nil && fieldType.Kind() == reflect.Struct && !t.isIgnored(fieldType) && (o.maxDepth < 0 || depth < o.maxDepth) {
+		tags.concat(t.getNested(fieldType, string(path)+o.separator, depth+1, o), "")
+	}
+
+	tags.sortFields(o.fieldOrder, o.separator)
+	return tags, o.state.err
+}
+
+// Find the field at the given path from a struct type, the struct type declaring it and its depth (0 for a field of
+// the model). Promoted fields are reached through their embedded struct, like in GetNested paths.
+func (t TaGo) resolvePath(modelType reflect.Type, path FieldName, separator string) (reflect.Type, reflect.StructField, int, error) {
+	if modelType.Kind() != reflect.Struct {
+		return nil, reflect.StructField{}, 0, notStruct(modelType)
+	}
+	if path == "" {
+		return nil, reflect.StructField{}, 0, fmt.Errorf("tago: empty path")
+	}
+
+	segments := strings.Split(string(path), separator)
+	if separator == "" {
+		segments = []string{string(path)}
+	}
+
+	owner := modelType
+	var field reflect.StructField
+	for i, segment := range segments {
+		// Only direct fields: GetNested names the promoted ones after their embedded struct, whose "-" must be checked
+		found := false
+		field, found = owner.FieldByName(segment)
+		if !found || len(field.Index) != 1 {
+			return nil, reflect.StructField{}, 0, &PathError{Path: path, Err: fmt.Errorf("%w %s in %s", ErrUnknownField, segment, owner)}
+		}
+
+		if i == len(segments)-1 {
+			break
+		}
+		if t.isSkipped(field, owner) {
+			return nil, reflect.StructField{}, 0, &PathError{Path: path, Instruction: Skip, Err: fmt.Errorf("%w %s in %s", ErrSkippedField, segment, owner)}
+		}
+		next := t.resolveType(field.Type)
+		if next == nil || next.Kind() != reflect.Struct {
+			return nil, reflect.StructField{}, 0, &PathError{Path: path, Err: fmt.Errorf("field %s of %s: %w", segment, owner, ErrNotStruct)}
+		}
+		owner = next
+	}
+	return owner, field, len(segments) - 1, nil
+}
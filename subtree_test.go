@@ -0,0 +1,117 @@
+package tago
+
+import (
+	"errors"
+	"testing"
+)
+
+type subtreeBase struct {
+	Version int `gorm2:"version=true"`
+}
+
+type subtreeHidden struct {
+	Token string `gorm2:"secret=true"`
+}
+
+type subtreeOrder struct {
+	subtreeBase
+	subtreeHidden `gorm2:"-"`
+	ID            int           `gorm2:"primaryKey"`
+	Items         []subtreeItem `gorm2:"preload=true"`
+	Internal      subtreeCache  `gorm2:"-"`
+}
+
+type subtreeCache struct {
+	Inner subtreeProduct `gorm2:"x=1"`
+}
+
+type subtreeItem struct {
+	Product subtreeProduct `gorm2:"preload=true"`
+	Count   int            `gorm2:"min=1"`
+}
+
+type subtreeProduct struct {
+	Name string `gorm2:"column=name"`
+}
+
+func TestGetNestedUnder(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	tests := []struct {
+		path      FieldName
+		separator string
+		opts      []Option
+		want      Instructions
+	}{
+		{"Items", ".", nil, Instructions{
+			"preload=true": {"Items", "Items.Product"},
+			"min=1":        {"Items.Count"},
+			"column=name":  {"Items.Product.Name"},
+		}},
+		{"Items.Product", ".", nil, Instructions{
+			"preload=true": {"Items.Product"},
+			"column=name":  {"Items.Product.Name"},
+		}},
+		{"subtreeBase.Version", ".", nil, Instructions{
+			"version=true": {"subtreeBase.Version"},
+		}},
+		{"Items/Product", "/", nil, Instructions{
+			"preload=true": {"Items/Product"},
+			"column=name":  {"Items/Product/Name"},
+		}},
+
+		// The depth is the one of the field in the model, whatever the separator
+		{"Items.Product", ".", []Option{WithMaxDepth(1)}, Instructions{
+			"preload=true": {"Items.Product"},
+		}},
+		{"Items", "", []Option{WithMaxDepth(1)}, Instructions{
+			"preload=true": {"Items", "ItemsProduct"},
+			"min=1":        {"ItemsCount"},
+		}},
+	}
+	for _, test := range tests {
+		got, err := tg.GetNestedUnder(&subtreeOrder{}, test.path, test.separator, test.opts...)
+		if err != nil {
+			t.Errorf("GetNestedUnder(%q): %v", test.path, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("GetNestedUnder(%q) = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
+
+func TestGetNestedUnderErrors(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	if _, err := tg.GetNestedUnder(nil, "Items", "."); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("nil model: error = %v, want ErrNotStruct", err)
+	}
+	if _, err := tg.GetNestedUnder(42, "Items", "."); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("int model: error = %v, want ErrNotStruct", err)
+	}
+	if _, err := tg.GetNestedUnder(&subtreeOrder{}, "Items.Missing", "."); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("unknown field: error = %v, want ErrUnknownField", err)
+	}
+	if _, err := tg.GetNestedUnder(&subtreeOrder{}, "ID.Value", "."); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("path through a scalar: error = %v, want ErrNotStruct", err)
+	}
+
+	// Promoted fields are only reached through their embedded struct, as GetNested names them
+	if _, err := tg.GetNestedUnder(&subtreeOrder{}, "Version", "."); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("promoted field: error = %v, want ErrUnknownField", err)
+	}
+
+	// Fields tagged "-" have no subtree, neither do their nested fields
+	for _, path := range []FieldName{"Internal", "Internal.Inner", "subtreeHidden", "subtreeHidden.Token"} {
+		if tags, err := tg.GetNestedUnder(&subtreeOrder{}, path, "."); !errors.Is(err, ErrSkippedField) {
+			t.Errorf("GetNestedUnder(%q) = %v, %v, want ErrSkippedField", path, tags, err)
+		}
+	}
+
+	// Limits exceeded by the traversal are returned
+	tags, err := tg.GetNestedUnder(&subtreeOrder{}, "Items", ".", WithLimits(Limits{MaxFields: 1}))
+	if err == nil {
+		t.Errorf("MaxFields exceeded: expected an error, got %v", tags)
+	}
+}
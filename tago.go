@@ -1,8 +1,13 @@
 package tago
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Create a struct to handle custom tags
@@ -18,6 +23,74 @@ import (
 // 	fmt.Println(tags) // map[preload=true:[Field1] otherOption=value:[Field1]]
 type TaGo struct {
 	Name string
+
+	// Struct types whose fields are not traversed by GetNested (see Ignore / IgnoreFunc)
+	ignored     map[reflect.Type]struct{}
+	ignoreFuncs []func(reflect.Type) bool
+
+	// Don't treat DefaultLeafTypes as leaves (see DisableDefaultLeaves)
+	noDefaultLeaves bool
+
+	// Struct types treated as leaves by name, on top of DefaultLeafTypes (see AddLeafTypes)
+	leafTypes map[string]struct{}
+
+	// Custom traversal of some field types (see RegisterExpander)
+	expanders map[string]Expander
+
+	// Parser of the tags, the grammar of grammar.go if nil (see SetParser)
+	parser Parser
+
+	// Resolve ${NAME} placeholders in instruction values (see Variables / VariableResolver)
+	resolver func(name string) (string, bool)
+
+	// Instruction keys replaced by their canonical key during parsing (see Alias)
+	aliases map[string]string
+
+	// Lower-case instruction keys and normalize boolean values (see CaseInsensitive)
+	caseInsensitive bool
+
+	// Applied to every instruction during parsing (see Normalize)
+	normalizers []func(key string, value string) (string, string)
+
+	// Custom sanitizers by instruction key (see RegisterSanitizer)
+	sanitizers map[string]Sanitizer
+
+	// Reversible transforms by instruction key (see RegisterTransform)
+	transforms map[string]transform
+
+	// Deprecated instruction keys and their replacement hint, reported to onWarning (see Deprecate / OnWarning)
+	deprecated map[string]string
+	onWarning  func(Warning)
+
+	// Evaluator of the expressions in instruction values, DefaultEvaluator if nil (see SetEvaluator)
+	evaluator Evaluator
+
+	// Tags attached to the fields of types we can't modify, by type and field name (see RegisterExternal)
+	external map[reflect.Type]map[string]string
+
+	// Tags loaded from overlay files, by type name and field name (see LoadOverlay)
+	overlays map[string]map[string]string
+
+	// Notified of every parse and apply, for metrics (see SetHooks)
+	hooks Hooks
+
+	// Receives warnings and skipped fields (see SetLogger)
+	logger *slog.Logger
+
+	// Parse results by model type and options, nil if disabled (see EnableCache)
+	cache *parseCache
+
+	// Configuration of the parsing, changed by the setters so cached results of another configuration aren't reused
+	generation uint64
+
+	// Called by Emit, by model type (see Watch)
+	watchers *watcherSet
+
+	// Descriptions of the instruction keys, for Help / HelpFor (see Describe)
+	descriptions map[string]string
+
+	// Formatters of leaf values by type, for the value-oriented features (see RegisterFormatter)
+	formatters map[reflect.Type]Formatter
 }
 
 // Ex: "preload=true" -> [Field1, Field1.Subfield2, ..]
@@ -26,6 +99,17 @@ type Instructions map[Instruction][]FieldName
 // ex: preload=true
 type Instruction string
 
+// Skip is the instruction listing the fields tagged "-": they are skipped entirely, no other instruction
+// is parsed for them and their nested fields aren't traversed
+//
+// Example:
+//
+//	type MyModel struct {
+//	    Internal Cache `gorm2:"-"`
+//	}
+//	t.GetNested(&MyModel{}, ".") // map[-:[Internal]]
+const Skip Instruction = "-"
+
 func (i Instruction) Key() string {
 	parts := strings.SplitN(string(i), "=", 2)
 	return strings.TrimSpace(parts[0])
@@ -42,6 +126,29 @@ func (i Instruction) Value() string {
 	return "true"
 }
 
+// Lookup returns the value of the instruction with the given key, and whether it exists
+// Mostly useful on the instructions of a single field (see GetFromField). If several instructions
+// share the key, the value of the first one in alphabetical order is returned.
+//
+// Example:
+// 	tags := t.GetFromField(field) // map[column=email:[Email] unique:[Email]]
+// 	tags.Lookup("column") // "email", true
+// 	tags.Lookup("unique") // "true", true
+func (t Instructions) Lookup(key string) (string, bool) {
+	found := false
+	var value Instruction
+	for instruction := range t {
+		if instruction.Key() == key && (!found || instruction < value) {
+			value = instruction
+			found = true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return value.Value(), true
+}
+
 // ex: Field1, Field1.Subfield2
 type FieldName string
 
@@ -69,63 +176,131 @@ func (t *Instructions) concat(other Instructions, prefix string) {
 // From a model field, extract the custom tag and return a map of instructions to field names
 // Model field is of type reflect.StructField Name - Tags
 func (t TaGo) GetFromField(modelField reflect.StructField) Instructions{
-	tags := make(Instructions)
+	return t.parseField(modelField, nil, FieldName(modelField.Name))
+}
+
+// Parse the custom tag of a field, see GetFromField
+// owner is the struct type declaring the field (nil if unknown) and path the full path of the field, both used for warnings
+func (t TaGo) parseField(modelField reflect.StructField, owner reflect.Type, path FieldName) Instructions {
+	return fieldInstructions(modelField, t.parseInstructions(modelField, owner, path))
+}
+
+// Map the parsed instructions of a field to its name
+func fieldInstructions(modelField reflect.StructField, instructions []Instruction) Instructions {
+	tags := make(Instructions, len(instructions))
+	for _, instruction := range instructions {
+		tags[instruction] = []FieldName{FieldName(modelField.Name)}
+	}
+	return tags
+}
+
+// Parse the custom tag of a field into its instructions, in tag order
+// The same instruction written twice on a field is only listed once
+func (t TaGo) parseInstructions(modelField reflect.StructField, owner reflect.Type, path FieldName) []Instruction {
+	instructions := make([]Instruction, 0)
 
 	// Extract the t.Name:"tag1=value1;tag2=value2" part
-	if tagsAsString := modelField.Tag.Get(t.Name); tagsAsString != "" {
+	// (merged with the tags registered with RegisterExternal for the owner type)
+	tagsAsString := t.rawTag(modelField, owner)
+	if tagsAsString == "" {
+		return instructions
+	}
 
-		// We have all the values for this tag, so we need to split them by ';'
-		instructions := strings.SplitSeq(tagsAsString, ";")
-		for instruction := range instructions {
-			// Extract key and value, e.g. "preload=true"
-			parts := strings.SplitN(instruction, "=", 2)
+	// A tag "-" skips the field entirely, like encoding/json: it is only listed under the Skip marker
+	if strings.TrimSpace(tagsAsString) == string(Skip) {
+		return append(instructions, Skip)
+	}
 
-			// Remove any extra spaces
-			for i := range parts {
-				parts[i] = strings.TrimSpace(parts[i])
-			}
+	// Parse the tag into instructions, see the grammar in grammar.go (or the configured Parser)
+	parts, err := t.tagParts(tagsAsString)
+	if err != nil {
+		parseErr, ok := err.(*ParseError)
+		if !ok {
+			parseErr = &ParseError{Raw: tagsAsString, Pos: -1, Err: err}
+		}
+		parseErr.Type, parseErr.Field = owner, path
+		t.warn(Warning{Type: owner, Field: path, Message: parseErr.message(), Err: parseErr})
+	}
 
-			// Join back with '=' in case the value had '=' in it
-			instructionString := strings.Join(parts, "=")
-			
-			// If the tag value is empty, skip it
-			if instructionString == "" {
-				continue
-			}
+	// db:index=true,unique -> db:index=true;db:unique
+	parts = expandNamespaceGroups(parts)
 
-			instruction := Instruction(instructionString)
+	seen := make(map[Instruction]bool)
+	for _, part := range parts {
+		// Warn about deprecated keys (before aliases are replaced, aliases can be deprecated too)
+		t.warnDeprecated(part.Key, Instruction(part.instruction()), owner, path)
 
-			// If instruction doesn't already exist, create it
-			if _, exists := tags[instruction]; !exists {
-				tags[instruction] = make([]FieldName, 0)
-			}
+		// Replace aliased keys by their canonical key
+		part.Key = t.canonicalKey(part.Key)
+
+		// Resolve the ${NAME} placeholders of the value
+		if part.HasValue {
+			part.Value = t.interpolate(part.Value)
+		}
+
+		// Preload=TRUE -> preload=true when keys are case-insensitive
+		part.Key, part.Value = t.normalizeCase(part.Key, part.Value)
 
-			// Add the field name to the list of fields for this instruction
-			tags[instruction] = append(tags[instruction], FieldName(modelField.Name))
+		// Custom normalizers, which can drop the instruction
+		if !t.normalize(&part) {
+			continue
+		}
+
+		// A value without key can't be matched by any handler
+		if part.Key == "" {
+			t.warn(Warning{Type: owner, Field: path, Instruction: Instruction(part.instruction()), Message: "missing instruction key"})
+		}
+
+		instruction := Instruction(part.instruction())
+		if !seen[instruction] {
+			seen[instruction] = true
+			instructions = append(instructions, instruction)
 		}
 	}
+	return instructions
+}
 
-	return tags
+// Whether a field is tagged "-" (see Skip), checked on its raw tag like parseInstructions does, without parsing it
+func (t TaGo) isSkipped(modelField reflect.StructField, owner reflect.Type) bool {
+	return strings.TrimSpace(t.rawTag(modelField, owner)) == string(Skip)
 }
 
-// Get the element type if it's a pointer or slice
-// E.g. *T -> T, []T -> T, []*T -> T
-func typeToElem(t reflect.Type) reflect.Type {
-	// If it's a pointer, get the element type
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+// Instructions of the fields of the struct types met by the features walking values rather than types (Redact,
+// Flatten, ..), parsed once per type and call so the warnings of a field are reported once
+type typeFields map[reflect.Type][][]Instruction
+
+// Instructions of the fields of a struct type, by field index
+func (t TaGo) fieldsOf(fields typeFields, typ reflect.Type) [][]Instruction {
+	instructions, exists := fields[typ]
+	if !exists {
+		instructions = make([][]Instruction, typ.NumField())
+		for i := range instructions {
+			field := typ.Field(i)
+			instructions[i] = t.parseInstructions(field, typ, FieldName(field.Name))
+		}
+		fields[typ] = instructions
 	}
+	return instructions
+}
 
-	// If it's a slice, get the element type
-	if t.Kind() == reflect.Slice {
-		t = t.Elem()
-
-		// If it's a pointer, get the element type ([] *T)
-		if t.Kind() == reflect.Ptr {
+// Get the element type if it's a pointer, slice or array, whatever the number of wrapping levels
+// E.g. *T -> T, []T -> T, []*T -> T, **T -> T, []*[]T -> T, *[]*T -> T, [3]T -> T
+func typeToElem(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
 			t = t.Elem()
+		default:
+			return t
 		}
 	}
-	return t
+}
+
+// Func and chan fields (and slices of them) are leaves: their tags are parsed, but they are never traversed,
+// and the value-oriented features never set them (they have no value to read from a tag, a map or another struct)
+func isFuncOrChan(t reflect.Type) bool {
+	kind := typeToElem(t).Kind()
+	return kind == reflect.Func || kind == reflect.Chan
 }
 
 // Get all the custom tags from a model, non-nested (only the top-level fields)
@@ -142,56 +317,119 @@ func typeToElem(t reflect.Type) reflect.Type {
 // 	t := TaGo{Name: "gorm2"}
 // 	tags := t.Get(&MyModel{})
 // 	fmt.Println(tags) // map[preload=true:[Field1 Field3] otherOption=value:[Field1]]]
-func (t TaGo) Get(model interface{}) Instructions {
-	tags := make(Instructions)
+//
+// Options can be given to change the default behavior, e.g. WithMaxDepth(1) to also include the fields of direct nested structs.
+func (t TaGo) Get(model interface{}, opts ...Option) Instructions {
+	return t.mustParseModel(model, newOptions(".", 0, opts))
+}
 
-	modelType := reflect.TypeOf(model)
+// Recursive function to get nested fields
+// depth is the nesting level of modelType's fields (0 for the top-level fields)
+func (t TaGo) getNested(modelType reflect.Type, prefix string, depth int, o options) Instructions{
+	tags := make(Instructions)
 
-	// Get the element type if it's a pointer or slice
-	modelType = typeToElem(modelType)
+	// Types being traversed in the current branch, to detect recursive types
+	o.state.enter(modelType)
+	defer o.state.leave(modelType)
+	o.trace(TraceEvent{Kind: TraceType, Type: modelType, Field: FieldName(strings.TrimSuffix(prefix, o.separator)), Depth: depth})
 
 	for i := 0; i < modelType.NumField(); i++ {
 		modelField := modelType.Field(i)
+		path := FieldName(prefix + modelField.Name)
 
-		// Extract the t.Name tag for the current model field
-		if fieldTags := t.GetFromField(modelField); fieldTags != nil {
-			tags.concat(fieldTags, "")
+		// Stop as soon as a limit is exceeded
+		tagLength := 0
+		if o.limits.MaxTagLength > 0 {
+			tagLength = len(t.rawTag(modelField, modelType))
+		}
+		if !o.state.visit(modelType, path, tagLength, o.limits) {
+			return tags
 		}
-	}
-	return tags
-}
 
-// Recursive function to get nested fields
-func (t TaGo) getNested(model interface{}, prefix string, separator string) Instructions{
-	tags := make(Instructions)
-	
-	modelType := reflect.TypeOf(model)
-	// Get the element type if it's a pointer or slice
-	modelType = typeToElem(modelType)
+		// Skip excluded fields along with their subtree
+		if o.excluded(path, modelField) {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "excluded"})
+			continue
+		}
+		if !o.accepted(modelField, depth) {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "rejected by field filter"})
+			continue
+		}
 
-	for i := 0; i < modelType.NumField(); i++ {
-		modelField := modelType.Field(i)
+		// Extract the custom tag from the current field, once: the hooks of the features get its instructions
+		instructions := t.parseInstructions(modelField, modelType, path)
+		if o.skip != nil {
+			if reason := o.skip(path, modelField, modelType, instructions); reason != "" {
+				o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: reason})
+				continue
+			}
+		}
 
-		// Extract the custom tag from the current field and add it to the tags slice
-		if fieldTags := t.GetFromField(modelField); fieldTags != nil {
-			tags.concat(fieldTags, prefix)
+		if o.onField != nil {
+			o.onField(path, modelField, modelType, depth, instructions)
+		}
+
+		// Add the instructions of the field to the tags
+		fieldTags := fieldInstructions(modelField, instructions)
+		tags.concat(fieldTags, prefix)
+		if o.tracer != nil {
+			traced := make(Instructions)
+			traced.concat(fieldTags, prefix)
+			o.trace(TraceEvent{Kind: TraceField, Type: modelType, Field: path, Depth: depth, RawTag: t.rawTag(modelField, modelType), Instructions: traced})
+		}
+
+		// Fields marked with "-" aren't traversed
+		if _, skipped := fieldTags[Skip]; skipped {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "skip marker"})
+			continue
 		}
 
 		// If it's a struct, get its nested fields recursively too
-		
-		// Get the element type if it's a pointer or slice
-		modelField.Type = typeToElem(modelField.Type)
 
-		if modelField.Type.String() != modelType.String() { // Avoid infinite recursion on self-referencing structs
-			if modelField.Type.Kind() == reflect.Struct {
-				// Get the nested fields with updated prefix, and append them to the main tags slice
-				t := t.getNested(reflect.New(modelField.Type).Elem().Interface(), prefix + modelField.Name+separator, separator)
+		// Get the element type if it's a pointer or slice (or the type provided by an Expander)
+		modelField.Type = t.resolveType(modelField.Type)
+		if modelField.Type == nil {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "leaf according to its expander"})
+			continue
+		}
+		if isFuncOrChan(modelField.Type) {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "func and chan fields are leaves"})
+			continue
+		}
+		if modelField.Type.Kind() == reflect.Interface {
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "interface type, its dynamic values are traversed by GetNestedDynamic"})
+			continue
+		}
+		if modelField.Type.Kind() != reflect.Struct {
+			continue
+		}
 
-				// Concat the nested tags (prefix has already been added in the recursive call)
-				tags.concat(t, "")
+		switch {
+		case o.maxDepth >= 0 && depth >= o.maxDepth:
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "max depth reached"})
+
+		// Avoid infinite recursion on self-referencing structs, directly (A.Parent *A) or not (A.B.A)
+		// Compare the types themselves rather than their names: anonymous structs have no name, and generic
+		// instantiations of different packages can print the same
+		case o.state.visiting[modelField.Type]:
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "self-referencing type"})
+
+		case t.isIgnored(modelField.Type):
+			o.trace(TraceEvent{Kind: TraceSkip, Type: modelType, Field: path, Depth: depth, Reason: "ignored type " + modelField.Type.String()})
+
+		default:
+			// Embedded structs can be flattened: their fields are promoted, without the embedded field name
+			nestedPrefix := prefix + modelField.Name + o.separator
+			if modelField.Anonymous && o.flattenEmbedded {
+				nestedPrefix = prefix
 			}
-		}
 
+			// Get the nested fields with updated prefix, and append them to the main tags slice
+			t := t.getNested(modelField.Type, nestedPrefix, depth+1, o)
+
+			// Concat the nested tags (prefix has already been added in the recursive call)
+			tags.concat(t, "")
+		}
 	}
 	return tags
 }
@@ -212,8 +450,114 @@ func (t TaGo) getNested(model interface{}, prefix string, separator string) Inst
 // 	t := TaGo{Name: "gorm2"}
 // 	tags := t.GetNested(&MyModel{}, ".")
 // 	fmt.Println(tags) // map[preload=true:[Field1 Field3 Field3.SubField1] otherOption=value:[Field1] otherOption=value2:[Field3.Subfield1]]]
-func (t TaGo) GetNested(model interface{}, separator string) Instructions {
-	return t.getNested(model, "", separator)
+//
+// Generic models are traversed with their type arguments: for Page[User] with a field `Items []T`,
+// the tags of User are returned under Items. Type parameters instantiated with an interface
+// (Page[any]) have nothing to traverse, such fields are leaves.
+//
+// Options can be given to change the default behavior, e.g. WithMaxDepth(2) to stop at the 3rd level of nesting.
+func (t TaGo) GetNested(model interface{}, separator string, opts ...Option) Instructions {
+	return t.mustParseModel(model, newOptions(separator, -1, opts))
+}
+
+// Parse the tags of a model with the given options, reporting to the hooks if any
+// The error reports an invalid model or an exceeded limit, the tags found until then are returned along with it
+func (t TaGo) parseModel(model interface{}, o options) (Instructions, error) {
+	if model == nil {
+		return make(Instructions), notStruct("nil model")
+	}
+
+	// Get the element type if it's a pointer or slice
+	modelType := typeToElem(reflect.TypeOf(model))
+	if modelType.Kind() != reflect.Struct {
+		return make(Instructions), notStruct(modelType)
+	}
+
+	// Cached result of the same type and options, if the cache is enabled and the logger won't miss anything
+	key, cacheable := o.cacheKey(t, modelType)
+	cacheable = cacheable && t.cache != nil && t.logger == nil
+	var warnings []Warning
+	if cacheable {
+		if tags, replayed, hit := t.cache.get(key); hit {
+			for _, w := range replayed {
+				t.warn(w)
+			}
+			return tags, nil
+		}
+		o.state.traversed = make(map[reflect.Type]bool)
+
+		// Record the warnings of the parse, to report them again on hits
+		onWarning := t.onWarning
+		t.onWarning = func(w Warning) {
+			warnings = append(warnings, w)
+			if onWarning != nil {
+				onWarning(w)
+			}
+		}
+	}
+	o = t.withLogger(o)
+
+	start := time.Now()
+	tags := t.getNested(modelType, "", 0, o)
+	tags.sortFields(o.fieldOrder, o.separator)
+
+	err := o.state.err
+	if err == nil && o.limits.MaxInstructions > 0 && len(tags) > o.limits.MaxInstructions {
+		err = fmt.Errorf("tago: %s: %d instructions, limit is %d", modelType, len(tags), o.limits.MaxInstructions)
+	}
+	if cacheable && err == nil {
+		t.cache.put(key, tags, o.state.traversed, warnings)
+	}
+
+	if t.hooks != nil {
+		t.hooks.OnParse(ParseEvent{
+			Type:         modelType,
+			Duration:     time.Since(start),
+			Fields:       o.state.fields,
+			Instructions: len(tags),
+		})
+	}
+	return tags, err
+}
+
+// Parse a model for the methods that can't return an error: the error is reported as a warning
+func (t TaGo) mustParseModel(model interface{}, o options) Instructions {
+	tags, err := t.parseModel(model, o)
+	if err != nil {
+		t.warn(Warning{Type: reflect.TypeOf(model), Message: err.Error()})
+	}
+	return tags
+}
+
+// Compile parses a model like GetNested ("." as separator, unless WithSeparator is given),
+// but returns an error when the model isn't a struct (ErrNotStruct), a limit set with WithLimits is exceeded
+// or a tag can't be parsed by the custom parser (*ParseError, see SetParser).
+// On error, the tags found until then are returned along with it.
+//
+// Example:
+//
+//	tags, err := t.Compile(&MyModel{}, tago.WithLimits(tago.Limits{MaxFields: 1000}))
+func (t TaGo) Compile(model interface{}, opts ...Option) (Instructions, error) {
+	// The default grammar can't fail, tags can only be invalid for a custom parser
+	if t.parser == nil {
+		return t.parseModel(model, newOptions(".", -1, opts))
+	}
+
+	// Collect the parse errors, which cached results wouldn't report
+	var parseErrs []error
+	onWarning := t.onWarning
+	t.onWarning = func(w Warning) {
+		if w.Err != nil {
+			parseErrs = append(parseErrs, w.Err)
+		}
+		if onWarning != nil {
+			onWarning(w)
+		}
+	}
+	t.cache = nil
+
+	tags, err := t.parseModel(model, newOptions(".", -1, opts))
+	return tags, errors.Join(append([]error{err}, parseErrs...)...)
 }
 
 
@@ -232,13 +576,75 @@ func (t TaGo) GetNested(model interface{}, separator string) Instructions {
 // 	}
 // 	t.Apply(instructions, instructionMapping)
 func (t TaGo) Apply(instructions Instructions, instructionMapping map[Instruction]func (field FieldName)) {
+	start, calls := time.Now(), 0
 	for instruction, action := range instructionMapping {
-		if fields, exists := instructions[instruction]; exists {
+		if fields, exists := instructions[t.normalizeInstruction(instruction)]; exists {
 			for _, field := range fields {
 				action(field)
+				calls++
 			}
 		}
 	}
+	t.reportApply(len(instructionMapping), calls, start)
+}
+
+// ApplyResult reports the configuration drift found by ApplyReport
+type ApplyResult struct {
+	// Parsed instructions without registered handler, sorted
+	Unhandled []Instruction
+
+	// Registered handlers whose instruction wasn't found, sorted
+	Unmatched []Instruction
+
+	// Number of handler calls
+	Calls int
+}
+
+// ApplyReport applies the instruction mapping like Apply, and reports the parsed instructions
+// that had no handler and the handlers that matched nothing, to detect drift between models and handlers.
+//
+// Example usage:
+// 	result := t.ApplyReport(instructions, instructionMapping)
+// 	if len(result.Unhandled) > 0 {
+// 	    log.Println("no handler for", result.Unhandled)
+// 	}
+func (t TaGo) ApplyReport(instructions Instructions, instructionMapping map[Instruction]func(field FieldName)) ApplyResult {
+	start := time.Now()
+	result := ApplyResult{}
+	handled := make(map[Instruction]bool, len(instructionMapping))
+
+	for _, instruction := range sortedInstructions(instructionMapping) {
+		normalized := t.normalizeInstruction(instruction)
+		fields, exists := instructions[normalized]
+		if !exists {
+			result.Unmatched = append(result.Unmatched, instruction)
+			continue
+		}
+		handled[normalized] = true
+		for _, field := range fields {
+			instructionMapping[instruction](field)
+			result.Calls++
+		}
+	}
+
+	for _, instruction := range instructions.Keys() {
+		if !handled[instruction] {
+			result.Unhandled = append(result.Unhandled, instruction)
+		}
+	}
+
+	t.reportApply(len(instructionMapping), result.Calls, start)
+	return result
+}
+
+// Instructions of a handler mapping, sorted alphabetically
+func sortedInstructions[T any](mapping map[Instruction]T) []Instruction {
+	instructions := make([]Instruction, 0, len(mapping))
+	for instruction := range mapping {
+		instructions = append(instructions, instruction)
+	}
+	sort.Slice(instructions, func(i, j int) bool { return instructions[i] < instructions[j] })
+	return instructions
 }
 
 // ApplyOne applies a single instruction if it exists in the instructions map
@@ -249,17 +655,52 @@ func (t TaGo) Apply(instructions Instructions, instructionMapping map[Instructio
 // 	    fmt.Println("Preloading", field)
 // 	})
 func (t TaGo) ApplyOne(instructionToCheck Instruction, instructions Instructions, action func(field FieldName)) {
-	if fields, exists := instructions[instructionToCheck]; exists {
+	start, calls := time.Now(), 0
+	if fields, exists := instructions[t.normalizeInstruction(instructionToCheck)]; exists {
 		for _, field := range fields {
 			action(field)
+			calls++
 		}
 	}
+	t.reportApply(1, calls, start)
+}
+
+// ApplyKey applies a single handler to every instruction with the given key, whatever its value.
+// Instructions are visited in alphabetical order, fields in their discovery order.
+//
+// Example usage:
+// 	instructions := t.GetNested(&MyModel{}, ".")
+// 	t.ApplyKey("column", instructions, func(field FieldName, value string) {
+// 	    fmt.Println(field, "is stored in column", value)
+// 	})
+func (t TaGo) ApplyKey(key string, instructions Instructions, action func(field FieldName, value string)) {
+	start, calls := time.Now(), 0
+	key = t.normalizeInstruction(Instruction(key)).Key()
+	for _, instruction := range instructions.Keys() {
+		if instruction.Key() != key {
+			continue
+		}
+		for _, field := range instructions[instruction] {
+			action(field, instruction.Value())
+			calls++
+		}
+	}
+	t.reportApply(1, calls, start)
 }
 
 // Check if a specific instruction exists in the instructions map
+// Like Get, only the top-level fields are considered
 func (t TaGo) Has(model interface{}, instructionToCheck Instruction) bool {
+	want := string(instructionToCheck)
+	wantKey, wantValue, wantHasValue := strings.Cut(want, "=")
+	if found, ok := t.scanTags(model, func(key string, value string, hasValue bool) bool {
+		return key == wantKey && hasValue == wantHasValue && value == wantValue
+	}); ok {
+		return found
+	}
+
 	instructions := t.Get(model)
-	_, exists := instructions[instructionToCheck]
+	_, exists := instructions[t.normalizeInstruction(instructionToCheck)]
 	return exists
 }
 
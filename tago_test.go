@@ -0,0 +1,318 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type wrappedLeaf struct {
+	Name string `gorm2:"column=name"`
+}
+
+type wrappedModel struct {
+	Double   **wrappedLeaf     `gorm2:"preload=true"`
+	Nested   []*[]wrappedLeaf  `gorm2:"preload=true"`
+	Pointers *[]*wrappedLeaf   `gorm2:"preload=true"`
+	Array    [2][]wrappedLeaf  `gorm2:"preload=true"`
+	Matrix   [][]*wrappedLeaf  `gorm2:"preload=true"`
+	Scalars  *[]*[]int         `gorm2:"column=scalars"`
+	ByName   map[string]string `gorm2:"column=by_name"`
+}
+
+func TestTypeToElem(t *testing.T) {
+	leaf := reflect.TypeOf(wrappedLeaf{})
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		want reflect.Type
+	}{
+		{"struct", leaf, leaf},
+		{"pointer", reflect.TypeOf(&wrappedLeaf{}), leaf},
+		{"pointer to pointer", reflect.TypeOf((**wrappedLeaf)(nil)), leaf},
+		{"slice", reflect.TypeOf([]wrappedLeaf{}), leaf},
+		{"slice of pointers", reflect.TypeOf([]*wrappedLeaf{}), leaf},
+		{"slice of pointers to slices", reflect.TypeOf([]*[]wrappedLeaf{}), leaf},
+		{"pointer to slice of pointers", reflect.TypeOf((*[]*wrappedLeaf)(nil)), leaf},
+		{"array", reflect.TypeOf([3]wrappedLeaf{}), leaf},
+		{"array of slices", reflect.TypeOf([3][]*wrappedLeaf{}), leaf},
+		{"scalar", reflect.TypeOf(0), reflect.TypeOf(0)},
+		{"wrapped scalar", reflect.TypeOf((*[]*[]int)(nil)), reflect.TypeOf(0)},
+		{"map", reflect.TypeOf(map[string]wrappedLeaf{}), reflect.TypeOf(map[string]wrappedLeaf{})},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := typeToElem(test.typ); got != test.want {
+				t.Errorf("typeToElem(%s) = %s, want %s", test.typ, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetNestedWrappedTypes(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	want := Instructions{
+		"preload=true":   {"Double", "Nested", "Pointers", "Array", "Matrix"},
+		"column=name":    {"Double.Name", "Nested.Name", "Pointers.Name", "Array.Name", "Matrix.Name"},
+		"column=scalars": {"Scalars"},
+		"column=by_name": {"ByName"},
+	}
+	for _, model := range []any{wrappedModel{}, &wrappedModel{}, []*wrappedModel{}, &[]*[]wrappedModel{}} {
+		if got := tg.GetNested(model, "."); !got.Equal(want) {
+			t.Errorf("GetNested(%T) = %v, want %v", model, got, want)
+		}
+	}
+}
+
+type genericUser struct {
+	Name string `gorm2:"column=name"`
+}
+
+type genericAccount struct {
+	Owner genericUser `gorm2:"preload=true"`
+}
+
+type genericPage[T any] struct {
+	Items []T `gorm2:"preload=true"`
+	Total int `gorm2:"column=total"`
+}
+
+type genericPages struct {
+	Users    genericPage[genericUser]    `gorm2:"preload=true"`
+	Accounts genericPage[genericAccount] `gorm2:"preload=true"`
+	Any      genericPage[any]            `gorm2:"preload=true"`
+}
+
+// Model and field of two distinct types printing the same (tago.sameName)
+func sameNameModel() any {
+	type sameName struct {
+		Name string `gorm2:"column=name"`
+	}
+	type inner = sameName
+	{
+		type sameName struct {
+			Inner inner `gorm2:"preload=true"`
+		}
+		return &sameName{}
+	}
+}
+
+func TestGetNestedGenericModels(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	want := Instructions{
+		"preload=true": {"Users", "Users.Items", "Accounts", "Accounts.Items", "Accounts.Items.Owner", "Any", "Any.Items"},
+		"column=total": {"Users.Total", "Accounts.Total", "Any.Total"},
+		"column=name":  {"Users.Items.Name", "Accounts.Items.Owner.Name"},
+	}
+	if got := tg.GetNested(&genericPages{}, "."); !got.Equal(want) {
+		t.Errorf("GetNested(genericPages) = %v, want %v", got, want)
+	}
+
+	want = Instructions{"preload=true": {"Items"}, "column=total": {"Total"}, "column=name": {"Items.Name"}}
+	if got := tg.GetNested(&genericPage[genericUser]{}, "."); !got.Equal(want) {
+		t.Errorf("GetNested(genericPage[genericUser]) = %v, want %v", got, want)
+	}
+}
+
+func TestGetNestedSameNamedTypes(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	model := sameNameModel()
+	typ := reflect.TypeOf(model).Elem()
+	if field := typ.Field(0).Type; field == typ || field.String() != typ.String() {
+		t.Fatalf("model %s and field %s must be distinct types printing the same", typ, field)
+	}
+
+	want := Instructions{"preload=true": {"Inner"}, "column=name": {"Inner.Name"}}
+	if got := tg.GetNested(model, "."); !got.Equal(want) {
+		t.Errorf("GetNested(%s) = %v, want %v", typ, got, want)
+	}
+}
+
+type recursiveA struct {
+	Name string      `gorm2:"column=a"`
+	B    *recursiveB `gorm2:"preload=true"`
+}
+
+type recursiveB struct {
+	Name string        `gorm2:"column=b"`
+	As   []*recursiveA `gorm2:"preload=true"`
+	C    recursiveC    `gorm2:"preload=true"`
+}
+
+type recursiveC struct {
+	Parent *recursiveB `gorm2:"preload=true"`
+	Root   recursiveA  `gorm2:"preload=true"`
+}
+
+type anonymousModel struct {
+	Address struct {
+		City    string `gorm2:"column=city"`
+		Country struct {
+			Code string `gorm2:"column=code"`
+		} `gorm2:"preload=true"`
+	} `gorm2:"preload=true"`
+	Lines []struct {
+		Text string `gorm2:"column=text"`
+	} `gorm2:"preload=true"`
+	Home, Work *struct {
+		Street string `gorm2:"column=street"`
+	}
+}
+
+func TestGetNestedAnonymousStructs(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	want := Instructions{
+		"preload=true":  {"Address", "Address.Country", "Lines"},
+		"column=city":   {"Address.City"},
+		"column=code":   {"Address.Country.Code"},
+		"column=text":   {"Lines.Text"},
+		"column=street": {"Home.Street", "Work.Street"},
+	}
+	if got := tg.GetNested(&anonymousModel{}, "."); !got.Equal(want) {
+		t.Errorf("GetNested(anonymousModel) = %v, want %v", got, want)
+	}
+}
+
+func TestGetNestedIndirectRecursion(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	tests := []struct {
+		name  string
+		model any
+		want  Instructions
+	}{
+		{"from A", &recursiveA{}, Instructions{
+			"column=a":     {"Name"},
+			"column=b":     {"B.Name"},
+			"preload=true": {"B", "B.As", "B.C", "B.C.Parent", "B.C.Root"},
+		}},
+		{"from B", &recursiveB{}, Instructions{
+			"column=a":     {"As.Name", "C.Root.Name"},
+			"column=b":     {"Name"},
+			"preload=true": {"As", "As.B", "C", "C.Parent", "C.Root", "C.Root.B"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tg.GetNested(test.model, "."); !got.Equal(test.want) {
+				t.Errorf("GetNested(%T) = %v, want %v", test.model, got, test.want)
+			}
+		})
+	}
+}
+
+type leafEvent struct {
+	Kind string `gorm2:"name=kind;map=kind"`
+}
+
+type leafModel struct {
+	Name    string             `gorm2:"name=name;map=name"`
+	OnSave  func(*leafModel)   `gorm2:"name=on_save;map=on_save;hook=save"`
+	Factory func() leafEvent   `gorm2:"name=factory;map=factory"`
+	Events  chan leafEvent     `gorm2:"name=events;map=events;buffer=8"`
+	Hooks   []func() error     `gorm2:"name=hooks;map=hooks"`
+	Done    *chan<- struct{}   `gorm2:"name=done;map=done"`
+	Event   leafEvent          `gorm2:"name=event;map=event"`
+	Sinks   map[string]func()  `gorm2:"name=sinks"`
+	Closers [2]func() error    `gorm2:"name=closers;default=x"`
+	Stop    chan struct{}      `gorm2:"default=x"`
+	Handler func(string) error `gorm2:"name=handler;default=x"`
+}
+
+func TestIsFuncOrChan(t *testing.T) {
+	tests := []struct {
+		value any
+		want  bool
+	}{
+		{func() {}, true},
+		{(func(*leafModel))(nil), true},
+		{make(chan int), true},
+		{(<-chan leafEvent)(nil), true},
+		{[]func() error{}, true},
+		{(*chan<- struct{})(nil), true},
+		{[2]func(){}, true},
+		{leafEvent{}, false},
+		{[]leafEvent{}, false},
+		{map[string]func(){}, false},
+		{"", false},
+	}
+	for _, test := range tests {
+		if got := isFuncOrChan(reflect.TypeOf(test.value)); got != test.want {
+			t.Errorf("isFuncOrChan(%T) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestFuncAndChanFieldsAreLeaves(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	// Tags are parsed, the fields aren't traversed
+	var skipped []FieldName
+	tags := tg.GetNested(&leafModel{}, ".", WithTracer(func(e TraceEvent) {
+		if e.Kind == TraceSkip && e.Reason == "func and chan fields are leaves" {
+			skipped = append(skipped, e.Field)
+		}
+	}))
+	for instruction, field := range map[Instruction]FieldName{"hook=save": "OnSave", "buffer=8": "Events", "name=done": "Done", "name=kind": "Event.Kind"} {
+		if fields := tags[instruction]; !reflect.DeepEqual(fields, []FieldName{field}) {
+			t.Errorf("GetNested()[%s] = %v, want [%s]", instruction, fields, field)
+		}
+	}
+	wantSkipped := []FieldName{"OnSave", "Factory", "Events", "Hooks", "Done", "Closers", "Stop", "Handler"}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("skipped %v, want %v", skipped, wantSkipped)
+	}
+}
+
+func TestFuncAndChanFieldsAreNeverSet(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	onSave := func(*leafModel) {}
+	events := make(chan leafEvent)
+	model := leafModel{Name: "a", OnSave: onSave, Events: events, Hooks: []func() error{nil}}
+
+	// Flatten omits them
+	var keys []string
+	for key := range tg.Flatten(&model, ".") {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if want := []string{"event.kind", "name", "sinks"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Flatten keys = %v, want %v", keys, want)
+	}
+
+	// Unflatten reports them
+	for _, key := range []string{"on_save", "events", "hooks", "done", "factory"} {
+		var back leafModel
+		err := tg.Unflatten(map[string]any{key: nil}, ".", &back)
+		if !strings.Contains(fmt.Sprint(err), "func or chan") {
+			t.Errorf("Unflatten(%s) = %v, want a func or chan error", key, err)
+		}
+	}
+
+	// MapStructs leaves them untouched, on both sides
+	dst := leafModel{OnSave: onSave, Events: events}
+	if err := tg.MapStructs(&dst, leafModel{Name: "b", Factory: func() leafEvent { return leafEvent{} }}, "map"); err != nil {
+		t.Fatalf("MapStructs: %v", err)
+	}
+	if dst.Name != "b" || dst.OnSave == nil || dst.Events != events || dst.Factory != nil {
+		t.Errorf("MapStructs set a func or chan field: %+v", dst)
+	}
+
+	// SetDefaults reports them
+	var defaults leafModel
+	err := tg.SetDefaults(&defaults)
+	for _, path := range []string{"Closers", "Stop", "Handler"} {
+		if !strings.Contains(fmt.Sprint(err), path+": default") {
+			t.Errorf("SetDefaults = %v, want an error for %s", err, path)
+		}
+	}
+	if defaults.Closers[0] != nil || defaults.Stop != nil || defaults.Handler != nil {
+		t.Errorf("SetDefaults set a func or chan field: %+v", defaults)
+	}
+}
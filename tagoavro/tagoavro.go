@@ -0,0 +1,338 @@
+// Package tagoavro generates Avro record schemas from tagged models, to export them to data lakes with the same
+// definitions as the services producing them.
+//
+// The following instructions are supported on fields:
+//
+//	name=name                    name of the Avro field (default: the json tag name, or the Go field name)
+//	name=-                       skip the field
+//	logicalType=timestamp-micros logical type of the field, see below
+//	precision=10;scale=2         precision and scale of decimal fields
+//	doc=text                     documentation of the field
+//
+// Supported logical types are date, time-millis, time-micros, timestamp-millis, timestamp-micros, uuid and decimal.
+// time.Time fields are timestamp-millis by default.
+//
+// Avro has no unsigned types: uint, uint32 and uint64 fields are longs, so uint and uint64 values above math.MaxInt64
+// don't fit. Give such fields logicalType=decimal;precision=20 (or use strings) when they may hold these values.
+//
+// Pointers are nullable (a ["null", type] union defaulting to null), embedded structs are flattened and fields tagged
+// json:"-" are skipped. Named struct types are defined once and referenced by their full name afterward, anonymous
+// structs are named after their parent type and field name.
+//
+// Usage:
+//
+//	type Event struct {
+//		ID        string    `json:"id" avro:"logicalType=uuid"`
+//		Amount    float64   `json:"amount" avro:"logicalType=decimal;precision=10;scale=2"`
+//		CreatedAt time.Time `json:"created_at" avro:"logicalType=timestamp-micros"`
+//		Comment   *string   `json:"comment" avro:"doc=Free text"`
+//	}
+//	schema, err := tagoavro.JSON(tago.TaGo{Name: "avro"}, &Event{}, "com.example.events")
+package tagoavro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KooQix/tago"
+)
+
+// Record is an Avro record schema
+type Record struct {
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace,omitempty"`
+	Fields    []Field `json:"fields"`
+}
+
+// Field is a field of an Avro record
+type Field struct {
+	Name string `json:"name"`
+
+	// Type of the field: the name of a primitive or already defined type (string), a *Record, a map for complex and
+	// logical types, or a []any union
+	Type any `json:"type"`
+
+	Doc string `json:"doc,omitempty"`
+
+	// Default value of the field, in JSON
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Generate returns the Avro record schema of a model. namespace may be empty.
+func Generate(t tago.TaGo, model any, namespace string) (*Record, error) {
+	if model == nil {
+		return nil, errors.New("tagoavro: nil model")
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagoavro: model %s is not a struct", modelType)
+	}
+
+	g := generator{
+		tag:       t,
+		namespace: namespace,
+		names:     make(map[reflect.Type]string),
+		used:      make(map[string]bool),
+	}
+	return g.record(modelType, "")
+}
+
+// JSON returns the Avro schema of a model as an indented JSON document (.avsc), see Generate
+func JSON(t tago.TaGo, model any, namespace string) ([]byte, error) {
+	record, err := Generate(t, model, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(record, "", "  ")
+}
+
+type generator struct {
+	tag       tago.TaGo
+	namespace string
+
+	// Record name of each struct type already defined, and the names already used
+	names map[reflect.Type]string
+	used  map[string]bool
+}
+
+// Define the record of a struct type, registering its name for the references to follow
+func (g *generator) record(typ reflect.Type, fallback string) (*Record, error) {
+	name := sanitize(typ.Name())
+	if name == "" {
+		name = fallback
+	}
+
+	// Avoid conflicts between types with the same name in different packages
+	base := name
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.names[typ] = name
+	g.used[name] = true
+
+	record := &Record{Type: "record", Name: name, Namespace: g.namespace, Fields: make([]Field, 0)}
+	if err := g.fields(record, typ, name, make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Add the fields of a struct type to a record, visiting holds the types being flattened to stop on embedded cycles
+func (g *generator) fields(record *Record, typ reflect.Type, parentName string, visiting map[reflect.Type]bool) error {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := g.tag.GetFromField(field)
+
+		name, hasName := tags.Lookup("name")
+		if !hasName {
+			var omitted bool
+			if name, omitted = jsonName(field); omitted {
+				continue
+			}
+		}
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs are flattened into the parent record, like encoding/json does, once per branch
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if visiting[fieldType] {
+				continue
+			}
+			if err := g.fields(record, fieldType, parentName, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		avroType, err := g.fieldType(field.Type, tags, parentName+field.Name)
+		if err != nil {
+			return fmt.Errorf("tagoavro: %s.%s: %w", typ, field.Name, err)
+		}
+		avroField := Field{Name: name, Type: avroType}
+		avroField.Doc, _ = tags.Lookup("doc")
+		if _, nullable := avroType.([]any); nullable {
+			avroField.Default = json.RawMessage("null")
+		}
+		record.Fields = append(record.Fields, avroField)
+	}
+	return nil
+}
+
+// Get the Avro type of a Go type. The instructions of the field apply to the innermost type (the items of slices,
+// the values of maps).
+func (g *generator) fieldType(typ reflect.Type, tags tago.Instructions, fallback string) (any, error) {
+	if typ.Kind() == reflect.Ptr {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		avroType, err := g.fieldType(typ, tags, fallback)
+		if err != nil {
+			return nil, err
+		}
+		return []any{"null", avroType}, nil
+	}
+
+	if logicalType, exists := tags.Lookup("logicalType"); exists && !isCollection(typ) {
+		return logical(logicalType, tags)
+	}
+
+	switch typ {
+	case timeType:
+		return logical("timestamp-millis", tags)
+	case bytesType:
+		return "bytes", nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int", nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		// Avro has no unsigned types, uint and uint64 values above math.MaxInt64 don't fit (see the package doc)
+		return "long", nil
+	case reflect.Float32:
+		return "float", nil
+	case reflect.Float64:
+		return "double", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.fieldType(typ.Elem(), tags, fallback)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map keys of type %s aren't supported, Avro map keys are strings", typ.Key())
+		}
+		values, err := g.fieldType(typ.Elem(), tags, fallback)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "map", "values": values}, nil
+	case reflect.Struct:
+		if name, defined := g.names[typ]; defined {
+			if g.namespace != "" {
+				return g.namespace + "." + name, nil
+			}
+			return name, nil
+		}
+		return g.record(typ, fallback)
+	}
+	return nil, fmt.Errorf("type %s has no Avro representation", typ)
+}
+
+// Avro type of a logical type
+func logical(logicalType string, tags tago.Instructions) (any, error) {
+	switch logicalType {
+	case "date", "time-millis":
+		return map[string]any{"type": "int", "logicalType": logicalType}, nil
+	case "time-micros", "timestamp-millis", "timestamp-micros":
+		return map[string]any{"type": "long", "logicalType": logicalType}, nil
+	case "uuid":
+		return map[string]any{"type": "string", "logicalType": logicalType}, nil
+	case "decimal":
+		precision, err := intInstruction(tags, "precision")
+		if err != nil {
+			return nil, err
+		}
+		if precision <= 0 {
+			return nil, errors.New("decimal requires a positive precision")
+		}
+		scale, err := intInstruction(tags, "scale")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "bytes", "logicalType": logicalType, "precision": precision, "scale": scale}, nil
+	}
+	return nil, fmt.Errorf("unknown logical type %q", logicalType)
+}
+
+// Value of an integer instruction, 0 if missing
+func intInstruction(tags tago.Instructions, key string) (int, error) {
+	value, exists := tags.Lookup(key)
+	if !exists {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return n, nil
+}
+
+// Check whether a type is a slice, an array or a map, []byte excepted
+func isCollection(typ reflect.Type) bool {
+	if typ == bytesType {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// Name of a field in JSON documents from its json tag, empty if it has none, and whether the field is omitted
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, exists := field.Tag.Lookup("json")
+	if !exists {
+		return "", false
+	}
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// Keep only valid Avro name characters, dropping the package paths of generic type arguments
+// Page[github.com/org/models.User] -> PageUser
+func sanitize(name string) string {
+	var b, token strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '.' || r == '/':
+			// What we read so far is a package path element
+			token.Reset()
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'):
+			token.WriteRune(r)
+		default:
+			b.WriteString(token.String())
+			token.Reset()
+		}
+	}
+	b.WriteString(token.String())
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package tagoavro
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type avroLine struct {
+	SKU string `json:"sku"`
+}
+
+type avroEvent struct {
+	ID        string     `json:"id" avro:"logicalType=uuid"`
+	Amount    float64    `json:"amount" avro:"logicalType=decimal;precision=10;scale=2"`
+	CreatedAt time.Time  `json:"created_at" avro:"logicalType=timestamp-micros"`
+	Comment   *string    `json:"comment" avro:"doc=Free text"`
+	Count     uint64     `json:"count"`
+	Small     int16      `json:"small"`
+	Lines     []avroLine `json:"lines"`
+	Main      avroLine   `json:"main"`
+	Raw       []byte     `json:"raw"`
+	Skipped   string     `json:"-"`
+	Flag      bool       `avro:"name=flag"`
+}
+
+type avroNode struct {
+	*avroNode
+	X int `json:"x"`
+}
+
+func TestJSON(t *testing.T) {
+	tg := tago.TaGo{Name: "avro"}
+
+	tests := []struct {
+		name      string
+		model     any
+		namespace string
+		want      string
+	}{
+		{"record", &avroEvent{}, "com.example", `{"type":"record","name":"avroEvent","namespace":"com.example","fields":[` +
+			`{"name":"id","type":{"logicalType":"uuid","type":"string"}},` +
+			`{"name":"amount","type":{"logicalType":"decimal","precision":10,"scale":2,"type":"bytes"}},` +
+			`{"name":"created_at","type":{"logicalType":"timestamp-micros","type":"long"}},` +
+			`{"name":"comment","type":["null","string"],"doc":"Free text","default":null},` +
+			`{"name":"count","type":"long"},` +
+			`{"name":"small","type":"int"},` +
+			`{"name":"lines","type":{"items":{"type":"record","name":"avroLine","namespace":"com.example","fields":[{"name":"sku","type":"string"}]},"type":"array"}},` +
+			`{"name":"main","type":"com.example.avroLine"},` +
+			`{"name":"raw","type":"bytes"},` +
+			`{"name":"flag","type":"boolean"}]}`},
+		// Embedded cycles are flattened once, like encoding/json does
+		{"embedded cycle", &avroNode{}, "", `{"type":"record","name":"avroNode","fields":[{"name":"x","type":"long"}]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := JSON(tg, test.model, test.namespace)
+			if err != nil {
+				t.Fatalf("JSON: %v", err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, doc); err != nil {
+				t.Fatal(err)
+			}
+			if got := compact.String(); got != test.want {
+				t.Errorf("JSON =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "avro"}
+
+	tests := []struct {
+		name  string
+		model any
+		err   string
+	}{
+		{"nil", nil, "nil model"},
+		{"not a struct", 1, "not a struct"},
+		{"unknown logical type", &struct {
+			A string `avro:"logicalType=money"`
+		}{}, `unknown logical type "money"`},
+		{"decimal without precision", &struct {
+			A float64 `avro:"logicalType=decimal"`
+		}{}, "positive precision"},
+		{"invalid scale", &struct {
+			A float64 `avro:"logicalType=decimal;precision=4;scale=two"`
+		}{}, `invalid scale "two"`},
+		{"map key", &struct {
+			A map[int]string
+		}{}, "map keys of type int"},
+		{"no Avro representation", &struct {
+			A func()
+		}{}, "no Avro representation"},
+	}
+	for _, test := range tests {
+		if _, err := Generate(tg, test.model, ""); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Generate(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+}
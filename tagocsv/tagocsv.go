@@ -0,0 +1,278 @@
+// Package tagocsv reads and writes CSV files from tagged models, the headers being declared next to the fields.
+//
+// The following instructions are supported on fields:
+//
+//	csv=Customer Name  header of the column (default: the Go field name)
+//	csv=-              skip the field
+//	format=2006-01-02  time layout of time.Time fields (default: time.RFC3339)
+//	format=%.2f        fmt verb of number fields, when writing
+//
+// Values are written with tago.TaGo.FormatValue, so the formatters registered on the TaGo apply.
+// Nested structs are flattened: their columns are prefixed with the parent header and the separator
+// ("Address.City"). Slices of structs, maps and other non scalar fields are skipped.
+//
+// Usage:
+//
+//	type Customer struct {
+//		Name      string    `report:"csv=Customer Name"`
+//		CreatedAt time.Time `report:"csv=Created;format=2006-01-02"`
+//		Address   Address
+//	}
+//	c := tagocsv.New(tago.TaGo{Name: "report"})
+//	err := c.Marshal(w, customers)
+package tagocsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// Codec reads and writes CSV rows of a model
+type Codec struct {
+	Tag tago.TaGo
+
+	// Separator between the parent header and the nested header
+	Separator string
+
+	// Comma is the field delimiter (default: ',')
+	Comma rune
+}
+
+// New returns a Codec reading the instructions of the given tag, with "." as separator
+func New(t tago.TaGo) *Codec {
+	return &Codec{Tag: t, Separator: ".", Comma: ','}
+}
+
+// Column of the CSV file, and where its value lives in the model
+type column struct {
+	header string
+	index  []int
+	format string
+}
+
+// Headers returns the headers of a model, in field declaration order
+func (c *Codec) Headers(model any) ([]string, error) {
+	columns, err := c.columns(model)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	return headers, nil
+}
+
+func (c *Codec) columns(model any) ([]column, error) {
+	if model == nil {
+		return nil, errors.New("tagocsv: nil model")
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagocsv: model %s is not a struct", modelType)
+	}
+	return c.structColumns(modelType, "", nil, map[reflect.Type]bool{}), nil
+}
+
+func (c *Codec) structColumns(typ reflect.Type, prefix string, index []int, visiting map[reflect.Type]bool) []column {
+	// Avoid infinite recursion on self-referencing structs
+	if visiting[typ] {
+		return nil
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	columns := make([]column, 0)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Unexported embedded structs are flattened like encoding/json does, their exported fields being promoted.
+		// Not behind a pointer, which can't be allocated through an unexported field.
+		embedded := field.Anonymous && field.Type.Kind() == reflect.Struct && !convert.IsScalar(field.Type)
+		if !field.IsExported() && !embedded {
+			continue
+		}
+
+		tags := c.Tag.GetFromField(field)
+		header, hasHeader := tags.Lookup("csv")
+		if header == "-" {
+			continue
+		}
+		if !hasHeader {
+			header = field.Name
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if convert.IsScalar(field.Type) {
+			format, _ := tags.Lookup("format")
+			columns = append(columns, column{header: prefix + header, index: fieldIndex, format: format})
+			continue
+		}
+
+		// Flatten nested structs (embedded structs without their name)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			nestedPrefix := prefix + header + c.Separator
+			if field.Anonymous && !hasHeader {
+				nestedPrefix = prefix
+			}
+			columns = append(columns, c.structColumns(fieldType, nestedPrefix, fieldIndex, visiting)...)
+		}
+	}
+	return columns
+}
+
+// Marshal writes the header line and one line per row, rows being a slice (or array) of structs or pointers to structs
+func (c *Codec) Marshal(w io.Writer, rows any) error {
+	value := reflect.ValueOf(rows)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Errorf("tagocsv: rows must be a slice, got %T", rows)
+	}
+
+	columns, err := c.columns(rows)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if c.Comma != 0 {
+		writer.Comma = c.Comma
+	}
+
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = col.header
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		for j, col := range columns {
+			field, ok := fieldByIndex(row, col.index)
+			if !ok {
+				// Nil pointer on the way: empty cell
+				record[j] = ""
+				continue
+			}
+			if record[j], err = c.Tag.FormatValue(field, col.format); err != nil {
+				return fmt.Errorf("tagocsv: row %d, column %q: %w", i, col.header, err)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Unmarshal reads a CSV file with a header line into rows, a pointer to a slice of structs or pointers to structs.
+// Columns are matched by header, unknown columns are ignored.
+func (c *Codec) Unmarshal(r io.Reader, rows any) error {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("tagocsv: rows must be a pointer to a slice, got %T", rows)
+	}
+	slice := value.Elem()
+
+	columns, err := c.columns(rows)
+	if err != nil {
+		return err
+	}
+	byHeader := make(map[string]column, len(columns))
+	for _, col := range columns {
+		byHeader[col.header] = col
+	}
+
+	reader := csv.NewReader(r)
+	if c.Comma != 0 {
+		reader.Comma = c.Comma
+	}
+
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Rows can be structs or pointers to structs
+		row := reflect.New(slice.Type().Elem()).Elem()
+		target := row
+		if target.Kind() == reflect.Ptr {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+
+		for i, cell := range record {
+			if i >= len(headers) {
+				break
+			}
+			col, exists := byHeader[headers[i]]
+			if !exists || cell == "" {
+				continue
+			}
+			if err := convert.Set(allocByIndex(target, col.index), cell, col.format); err != nil {
+				return fmt.Errorf("tagocsv: line %d, column %q: %w", line, col.header, err)
+			}
+		}
+		slice.Set(reflect.Append(slice, row))
+	}
+}
+
+// Get the nested field of v, return false if a nil pointer is on the way
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// Get the nested field of v, allocating nil pointers on the way
+func allocByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
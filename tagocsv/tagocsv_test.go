@@ -0,0 +1,82 @@
+package tagocsv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type csvAudit struct {
+	CreatedAt time.Time `report:"csv=Created;format=2006-01-02"`
+}
+
+type csvAddress struct {
+	City string `report:"csv=City"`
+}
+
+type csvCustomer struct {
+	csvAudit
+	Name    string  `report:"csv=Customer Name"`
+	Balance float64 `report:"format=%.2f"`
+	Address *csvAddress
+	Lines   []csvAddress
+	Notes   string `report:"csv=-"`
+	secret  string
+}
+
+func TestHeaders(t *testing.T) {
+	headers, err := New(tago.TaGo{Name: "report"}).Headers(&csvCustomer{})
+	if err != nil {
+		t.Fatalf("Headers: %v", err)
+	}
+	if want := []string{"Created", "Customer Name", "Balance", "Address.City"}; !reflect.DeepEqual(headers, want) {
+		t.Errorf("Headers = %v, want %v", headers, want)
+	}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	c := New(tago.TaGo{Name: "report"})
+	customers := []csvCustomer{
+		{csvAudit: csvAudit{CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, Name: "Ada", Balance: 1.5, Address: &csvAddress{City: "Paris"}},
+		{Name: "Bob, Jr.", Balance: 2},
+	}
+
+	var b strings.Builder
+	if err := c.Marshal(&b, customers); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := "Created,Customer Name,Balance,Address.City\n" +
+		"2024-03-01,Ada,1.50,Paris\n" +
+		"0001-01-01,\"Bob, Jr.\",2.00,\n"
+	if b.String() != want {
+		t.Errorf("Marshal =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	var rows []*csvCustomer
+	if err := c.Unmarshal(strings.NewReader(want), &rows); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(rows) != 2 || !rows[0].CreatedAt.Equal(customers[0].CreatedAt) || rows[0].Name != "Ada" || rows[0].Address == nil ||
+		rows[0].Address.City != "Paris" || rows[1].Name != "Bob, Jr." || rows[1].Address != nil {
+		t.Errorf("Unmarshal = %+v", rows)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c := New(tago.TaGo{Name: "report"})
+
+	if err := c.Marshal(&strings.Builder{}, csvCustomer{}); err == nil {
+		t.Error("Marshal of a struct: expected an error")
+	}
+	var rows []csvCustomer
+	if err := c.Unmarshal(strings.NewReader(""), rows); err == nil {
+		t.Error("Unmarshal into a slice: expected an error")
+	}
+	err := c.Unmarshal(strings.NewReader("Balance\nabc\n"), &rows)
+	if err == nil || !strings.Contains(err.Error(), `line 2, column "Balance"`) {
+		t.Errorf("Unmarshal of an invalid number: %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+// Package tagofake fills tagged models with realistic fake data, to build test fixtures from existing annotations.
+//
+// The following instructions are supported on fields:
+//
+//	fake=email    value produced by the generator "email" (see Generators and Faker.Register)
+//	fake=-        leave the field untouched
+//	min=3;max=10  bounds of numbers, or of the length of strings and slices
+//
+// Fields without fake= get a random value of their type: strings are words, numbers are within min / max (default
+// 0 to 100), times within the year before Epoch, pointers are allocated, slices and maps get SliceLength elements and
+// structs are filled recursively, up to MaxDepth nested structs (to stop on recursive types). Interfaces, funcs and
+// channels are left untouched.
+//
+// Usage:
+//
+//	type User struct {
+//		Email string   `fake:"fake=email"`
+//		Name  string   `fake:"fake=name"`
+//		Age   int      `fake:"min=18;max=99"`
+//		Tags  []string `fake:"min=1;max=3"`
+//	}
+//	var user User
+//	err := tagofake.Fill(tago.TaGo{Name: "fake"}, &user)
+package tagofake
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// Generator returns a fake value as a string, converted to the type of the field (numbers, booleans, time.Time
+// in RFC 3339, comma separated slices, ..). min and max are the values of the min and max instructions, empty when unset.
+type Generator func(r *rand.Rand, min, max string) string
+
+var (
+	firstNames = []string{"Alice", "Bob", "Chloé", "David", "Emma", "Farid", "Grace", "Hugo", "Inès", "Jules", "Kenji", "Léa"}
+	lastNames  = []string{"Martin", "Smith", "Bernard", "Garcia", "Dubois", "Müller", "Rossi", "Tanaka", "Nguyen", "Kowalski"}
+	words      = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor"}
+	domains    = []string{"example.com", "example.org", "example.net"}
+	cities     = []string{"Paris", "London", "Berlin", "Madrid", "Rome", "Tokyo", "Lisbon", "Montreal"}
+)
+
+// Epoch is the end of the period fake dates and times are picked in, fixed so that seeded fixtures are reproducible
+var Epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Generators are the generators available to every Faker without registration
+//
+//	firstName, lastName, name   person names
+//	email, url, phone           contact details
+//	word, sentence              lorem ipsum text
+//	city                        city name
+//	uuid                        random (version 4) UUID
+//	date                        date of the ten years before Epoch, RFC 3339
+var Generators = map[string]Generator{
+	"firstName": func(r *rand.Rand, _, _ string) string { return pick(r, firstNames) },
+	"lastName":  func(r *rand.Rand, _, _ string) string { return pick(r, lastNames) },
+	"name": func(r *rand.Rand, _, _ string) string {
+		return pick(r, firstNames) + " " + pick(r, lastNames)
+	},
+	"email": func(r *rand.Rand, _, _ string) string {
+		return strings.ToLower(pick(r, firstNames)+"."+pick(r, lastNames)) + strconv.Itoa(r.Intn(100)) + "@" + pick(r, domains)
+	},
+	"url": func(r *rand.Rand, _, _ string) string {
+		return "https://" + pick(r, domains) + "/" + pick(r, words)
+	},
+	"phone": func(r *rand.Rand, _, _ string) string {
+		return fmt.Sprintf("+1-555-%03d-%04d", r.Intn(1000), r.Intn(10000))
+	},
+	"word": func(r *rand.Rand, _, _ string) string { return pick(r, words) },
+	"sentence": func(r *rand.Rand, min, max string) string {
+		n := between(r, bound(min, 4), bound(max, 10))
+		sentence := make([]string, n)
+		for i := range sentence {
+			sentence[i] = pick(r, words)
+		}
+		s := strings.Join(sentence, " ")
+		return strings.ToUpper(s[:1]) + s[1:] + "."
+	},
+	"city": func(r *rand.Rand, _, _ string) string { return pick(r, cities) },
+	"uuid": func(r *rand.Rand, _, _ string) string {
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	},
+	"date": func(r *rand.Rand, _, _ string) string {
+		return before(r, 10*365*24*time.Hour).Format(time.RFC3339)
+	},
+}
+
+// Faker fills models with fake data
+type Faker struct {
+	Tag tago.TaGo
+
+	// Number of elements of the slices and maps without min / max instructions
+	SliceLength int
+
+	// Maximum number of nested structs filled, to stop on recursive types
+	MaxDepth int
+
+	rand       *rand.Rand
+	generators map[string]Generator
+}
+
+// New returns a Faker reading the instructions of the given tag, with 2 elements per slice and 3 nested levels.
+// Its values are random, see Seed for reproducible fixtures.
+func New(t tago.TaGo) *Faker {
+	return &Faker{
+		Tag:         t,
+		SliceLength: 2,
+		MaxDepth:    3,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Fill fills a model with a new Faker, see Faker.Fill
+func Fill(t tago.TaGo, model any) error {
+	return New(t).Fill(model)
+}
+
+// Seed makes the values of the Faker reproducible: the same seed fills the same model with the same values
+func (f *Faker) Seed(seed int64) *Faker {
+	f.rand = rand.New(rand.NewSource(seed))
+	return f
+}
+
+// Register registers a generator for fake=name, it replaces the default generator of the same name, if any.
+//
+// Example:
+//
+//	f.Register("sku", func(r *rand.Rand, _, _ string) string {
+//		return fmt.Sprintf("SKU-%06d", r.Intn(1000000))
+//	})
+func (f *Faker) Register(name string, generator Generator) *Faker {
+	if f.generators == nil {
+		f.generators = make(map[string]Generator)
+	}
+	f.generators[name] = generator
+	return f
+}
+
+// Get the generator of a name, registered or default
+func (f *Faker) generator(name string) (Generator, bool) {
+	if generator, exists := f.generators[name]; exists {
+		return generator, true
+	}
+	generator, exists := Generators[name]
+	return generator, exists
+}
+
+// Fill populates the exported fields of a model, nested fields included. model must be a non-nil pointer to a struct.
+// Fields already set are overwritten. Errors (unknown generator, value not convertible to the field type) are joined,
+// prefixed with the field path, the other fields are still filled.
+func (f *Faker) Fill(model any) error {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tagofake: model must be a non-nil pointer to a struct, got %T", model)
+	}
+	return errors.Join(f.fillStruct(value.Elem(), "", 0)...)
+}
+
+func (f *Faker) fillStruct(value reflect.Value, prefix string, depth int) []error {
+	var errs []error
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if !value.Field(i).CanSet() {
+			continue
+		}
+
+		tags := f.Tag.GetFromField(field)
+		name, hasName := tags.Lookup("fake")
+		if name == "-" {
+			continue
+		}
+		min, _ := tags.Lookup("min")
+		max, _ := tags.Lookup("max")
+		path := prefix + field.Name
+
+		if hasName && name != "true" {
+			generator, exists := f.generator(name)
+			if !exists {
+				errs = append(errs, fmt.Errorf("tagofake: field %s: unknown generator %q", path, name))
+				continue
+			}
+			if err := convert.Set(value.Field(i), generator(f.rand, min, max), ""); err != nil {
+				errs = append(errs, fmt.Errorf("tagofake: field %s: %w", path, err))
+			}
+			continue
+		}
+		errs = append(errs, f.fillValue(value.Field(i), path, min, max, depth)...)
+	}
+	return errs
+}
+
+// Fill a value with a random value of its type
+func (f *Faker) fillValue(value reflect.Value, path, min, max string, depth int) []error {
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		value.Set(reflect.ValueOf(before(f.rand, 365*24*time.Hour)))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		s := pick(f.rand, words)
+		for n := between(f.rand, bound(min, 0), bound(max, 0)); len(s) < n; {
+			s += " " + pick(f.rand, words)
+		}
+		if n := bound(max, 0); n > 0 && len(s) > n {
+			s = s[:n]
+		}
+		value.SetString(s)
+	case reflect.Bool:
+		value.SetBool(f.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(between(f.rand, bound(min, 0), bound(max, 100))))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value.SetUint(uint64(between(f.rand, nonNegative(bound(min, 0)), nonNegative(bound(max, 100)))))
+	case reflect.Float32, reflect.Float64:
+		low, high := boundFloat(min, 0), boundFloat(max, 100)
+		value.SetFloat(low + f.rand.Float64()*(high-low))
+	case reflect.Ptr:
+		if value.Type().Elem().Kind() == reflect.Struct && depth >= f.MaxDepth {
+			return nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return f.fillValue(value.Elem(), path, min, max, depth)
+	case reflect.Struct:
+		if depth >= f.MaxDepth {
+			return nil
+		}
+		return f.fillStruct(value, path+".", depth+1)
+	case reflect.Slice:
+		n := f.SliceLength
+		if min != "" || max != "" {
+			n = between(f.rand, nonNegative(bound(min, 0)), nonNegative(bound(max, n)))
+		}
+		slice := reflect.MakeSlice(value.Type(), n, n)
+		var errs []error
+		for i := 0; i < n; i++ {
+			errs = append(errs, f.fillValue(slice.Index(i), path+"."+strconv.Itoa(i), "", "", depth)...)
+		}
+		value.Set(slice)
+		return errs
+	case reflect.Array:
+		var errs []error
+		for i := 0; i < value.Len(); i++ {
+			errs = append(errs, f.fillValue(value.Index(i), path+"."+strconv.Itoa(i), "", "", depth)...)
+		}
+		return errs
+	case reflect.Map:
+		m := reflect.MakeMapWithSize(value.Type(), f.SliceLength)
+		var errs []error
+		for i := 0; i < f.SliceLength; i++ {
+			key, elem := reflect.New(value.Type().Key()).Elem(), reflect.New(value.Type().Elem()).Elem()
+			errs = append(errs, f.fillValue(key, path, "", "", depth)...)
+			errs = append(errs, f.fillValue(elem, path+"."+fmt.Sprint(key.Interface()), "", "", depth)...)
+			m.SetMapIndex(key, elem)
+		}
+		value.Set(m)
+		return errs
+	}
+	return nil
+}
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+// Random time of the period before Epoch, to the second
+func before(r *rand.Rand, period time.Duration) time.Time {
+	return Epoch.Add(-time.Duration(r.Int63n(int64(period)))).Truncate(time.Second)
+}
+
+// Random integer in [min, max], min when max < min
+func between(r *rand.Rand, min, max int) int {
+	if max <= min {
+		return min
+	}
+	return min + r.Intn(max-min+1)
+}
+
+// Integer value of a min / max instruction, or the default
+func bound(s string, def int) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return int(n)
+	}
+	return def
+}
+
+func boundFloat(s string, def float64) float64 {
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n
+	}
+	return def
+}
+
+// n, or 0 when negative
+func nonNegative(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,150 @@
+package tagofake
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type fakeAddress struct {
+	City string `fake:"fake=city"`
+}
+
+type fakeUser struct {
+	ID        string    `fake:"fake=uuid"`
+	Email     string    `fake:"fake=email"`
+	Name      string    `fake:"fake=name"`
+	Phone     string    `fake:"fake=phone"`
+	Bio       string    `fake:"fake=sentence;min=2;max=3"`
+	Birthday  time.Time `fake:"fake=date"`
+	CreatedAt time.Time
+	Age       int     `fake:"min=18;max=99"`
+	Score     float64 `fake:"min=1;max=2"`
+	Level     uint8   `fake:"min=-5;max=3"`
+	Code      string  `fake:"max=4"`
+	Tags      []string
+	Scores    []int `fake:"min=-2;max=-1"`
+	Labels    map[string]int
+	Address   *fakeAddress
+	Manager   *fakeUser
+	Ignored   string `fake:"fake=-"`
+	Handler   func()
+	secret    string
+}
+
+func TestFill(t *testing.T) {
+	f := New(tago.TaGo{Name: "fake"}).Seed(1)
+	user := fakeUser{Ignored: "kept"}
+	if err := f.Fill(&user); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		ok    bool
+	}{
+		{"ID", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(user.ID)},
+		{"Email", regexp.MustCompile(`^[\p{Ll}]+\.[\p{Ll}]+\d+@example\.(com|org|net)$`).MatchString(user.Email)},
+		{"Name", strings.Count(user.Name, " ") == 1},
+		{"Phone", regexp.MustCompile(`^\+1-555-\d{3}-\d{4}$`).MatchString(user.Phone)},
+		{"Bio", strings.HasSuffix(user.Bio, ".") && strings.Count(user.Bio, " ") >= 1 && strings.Count(user.Bio, " ") <= 2},
+		{"Birthday", !user.Birthday.After(Epoch) && user.Birthday.After(Epoch.AddDate(-11, 0, 0))},
+		{"CreatedAt", !user.CreatedAt.After(Epoch) && user.CreatedAt.After(Epoch.AddDate(-1, 0, -1))},
+		{"Age", user.Age >= 18 && user.Age <= 99},
+		{"Score", user.Score >= 1 && user.Score <= 2},
+		{"Level", user.Level <= 3},
+		{"Code", user.Code != "" && len(user.Code) <= 4},
+		{"Tags", len(user.Tags) == 2 && user.Tags[0] != ""},
+		{"Scores", len(user.Scores) == 0},
+		{"Labels", len(user.Labels) > 0},
+		{"Address", user.Address != nil && user.Address.City != ""},
+		{"Manager", user.Manager != nil && user.Manager.Email != ""},
+		{"Ignored", user.Ignored == "kept"},
+		{"Handler", user.Handler == nil},
+		{"secret", user.secret == ""},
+	}
+	for _, test := range tests {
+		if !test.ok {
+			t.Errorf("Fill: unexpected %s in %+v", test.field, user)
+		}
+	}
+}
+
+func TestFillDepth(t *testing.T) {
+	f := New(tago.TaGo{Name: "fake"})
+	f.MaxDepth = 2
+
+	var user fakeUser
+	if err := f.Fill(&user); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if user.Manager == nil || user.Manager.Manager == nil || user.Manager.Manager.Manager != nil {
+		t.Errorf("Fill with MaxDepth 2 = %+v, want 2 managers", user)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tg := tago.TaGo{Name: "fake"}
+
+	var first, second fakeUser
+	if err := New(tg).Seed(42).Fill(&first); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if err := New(tg).Seed(42).Fill(&second); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Fill with the same seed =\n%+v\n%+v", first, second)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	type product struct {
+		SKU   string `fake:"fake=sku"`
+		Price int    `fake:"fake=price"`
+		Email string `fake:"fake=email"`
+	}
+
+	f := New(tago.TaGo{Name: "fake"}).
+		Register("sku", func(r *rand.Rand, _, _ string) string { return "SKU-1" }).
+		Register("price", func(r *rand.Rand, _, _ string) string { return "12" }).
+		Register("email", func(r *rand.Rand, _, _ string) string { return "a@b.c" })
+
+	var p product
+	if err := f.Fill(&p); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if want := (product{SKU: "SKU-1", Price: 12, Email: "a@b.c"}); p != want {
+		t.Errorf("Fill = %+v, want %+v", p, want)
+	}
+}
+
+func TestFillErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "fake"}
+
+	for _, model := range []any{nil, fakeUser{}, (*fakeUser)(nil), new(int)} {
+		if err := Fill(tg, model); err == nil {
+			t.Errorf("Fill(%T): expected an error", model)
+		}
+	}
+
+	// Errors are joined, the other fields are still filled
+	type invalid struct {
+		A string `fake:"fake=unknown"`
+		B int    `fake:"fake=word"`
+		C string `fake:"fake=city"`
+	}
+	var model invalid
+	err := Fill(tg, &model)
+	if err == nil || !strings.Contains(err.Error(), `field A: unknown generator "unknown"`) || !strings.Contains(err.Error(), "field B:") {
+		t.Errorf("Fill = %v, want the errors of A and B", err)
+	}
+	if model.C == "" {
+		t.Errorf("Fill = %+v, want C filled", model)
+	}
+}
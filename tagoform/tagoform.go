@@ -0,0 +1,173 @@
+// Package tagoform binds HTTP query strings and forms (url.Values) to tagged models.
+//
+// The following instructions are supported on fields:
+//
+//	param=user_id      name of the parameter (default: the Go field name)
+//	param=-            skip the field
+//	default=10         value used when the parameter is missing
+//	required=true      the parameter must be present (or have a default)
+//	format=2006-01-02  time layout of time.Time fields (default: time.RFC3339)
+//
+// Values are converted to the field type (strings, numbers, bools, durations, times, encoding.TextUnmarshaler).
+// Slices are filled from repeated parameters (?id=1&id=2) or from a comma separated value (?id=1,2).
+// Nested structs are bound from prefixed parameters: "address.city" for Address.City with param=address and param=city.
+//
+// Usage:
+//
+//	type ListUsers struct {
+//		UserID uint64   `form:"param=user_id;required=true"`
+//		Limit  int      `form:"param=limit;default=10"`
+//		Roles  []string `form:"param=role"`
+//	}
+//	var query ListUsers
+//	err := tagoform.New(tago.TaGo{Name: "form"}).Bind(r.URL.Query(), &query)
+package tagoform
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/convert"
+)
+
+// Binder binds url.Values to models
+type Binder struct {
+	Tag tago.TaGo
+
+	// Separator between the parent parameter name and the nested parameter name
+	Separator string
+}
+
+// New returns a Binder reading the instructions of the given tag, with "." as separator
+func New(t tago.TaGo) *Binder {
+	return &Binder{Tag: t, Separator: "."}
+}
+
+// Bind sets the fields of dst, a pointer to a struct, from the given values.
+// Every invalid or missing required parameter is reported in the returned error.
+func (b *Binder) Bind(values url.Values, dst any) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tagoform: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	errs := make([]error, 0)
+	b.bindStruct(values, value.Elem(), "", &errs)
+	return errors.Join(errs...)
+}
+
+func (b *Binder) bindStruct(values url.Values, v reflect.Value, prefix string, errs *[]error) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Unexported embedded structs are flattened like encoding/json does, their exported fields being promoted.
+		// Not behind a pointer, which can't be allocated through an unexported field.
+		embedded := field.Anonymous && field.Type.Kind() == reflect.Struct && !convert.IsScalar(field.Type)
+		if !field.IsExported() && !embedded {
+			continue
+		}
+
+		tags := b.Tag.GetFromField(field)
+		name, hasName := tags.Lookup("param")
+		if name == "-" {
+			continue
+		}
+		if !hasName {
+			name = field.Name
+		}
+
+		// Nested structs are bound from prefixed parameters (embedded ones without prefix)
+		if !convert.IsScalar(field.Type) {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Struct {
+				continue
+			}
+
+			nestedPrefix := prefix + name + b.Separator
+			if field.Anonymous && !hasName {
+				nestedPrefix = prefix
+			}
+
+			// Only allocate nil pointers if a nested parameter is present
+			target := v.Field(i)
+			if target.Kind() == reflect.Ptr && target.IsNil() {
+				if !hasPrefix(values, nestedPrefix) {
+					continue
+				}
+				target.Set(reflect.New(fieldType))
+			}
+			for target.Kind() == reflect.Ptr {
+				target = target.Elem()
+			}
+			b.bindStruct(values, target, nestedPrefix, errs)
+			continue
+		}
+
+		key := prefix + name
+		format, _ := tags.Lookup("format")
+		params, present := values[key]
+
+		if !present || len(params) == 0 {
+			if fallback, hasDefault := tags.Lookup("default"); hasDefault {
+				params = []string{fallback}
+			} else {
+				if required, _ := tags.Lookup("required"); required == "true" {
+					*errs = append(*errs, fmt.Errorf("tagoform: parameter %q is required", key))
+				}
+				continue
+			}
+		}
+
+		if err := set(v.Field(i), params, format); err != nil {
+			*errs = append(*errs, fmt.Errorf("tagoform: parameter %q: %w", key, err))
+		}
+	}
+}
+
+// Set a field from the values of a parameter
+func set(field reflect.Value, params []string, format string) error {
+	fieldType := field.Type()
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	// Repeated parameters fill a slice one element per value
+	if len(params) > 1 && fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fieldType, len(params), len(params))
+		for i, param := range params {
+			if err := convert.Set(slice.Index(i), param, format); err != nil {
+				return err
+			}
+		}
+		target := field
+		for target.Kind() == reflect.Ptr {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+			target = target.Elem()
+		}
+		target.Set(slice)
+		return nil
+	}
+
+	// Otherwise the last value wins
+	return convert.Set(field, params[len(params)-1], format)
+}
+
+// Check whether a parameter starting with the given prefix exists
+func hasPrefix(values url.Values, prefix string) bool {
+	for key := range values {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package tagoform
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type formPage struct {
+	Limit  int `form:"param=limit;default=10"`
+	Offset int `form:"param=offset"`
+}
+
+type formAddress struct {
+	City string `form:"param=city"`
+}
+
+type formListUsers struct {
+	formPage
+	UserID  uint64       `form:"param=user_id;required=true"`
+	Roles   []string     `form:"param=role"`
+	Since   time.Time    `form:"param=since;format=2006-01-02"`
+	Address *formAddress `form:"param=address"`
+	Ignored string       `form:"param=-"`
+	secret  string
+}
+
+func TestBind(t *testing.T) {
+	binder := New(tago.TaGo{Name: "form"})
+
+	tests := []struct {
+		name  string
+		query string
+		want  formListUsers
+	}{
+		{"defaults", "user_id=1", formListUsers{formPage: formPage{Limit: 10}, UserID: 1}},
+		{"all", "user_id=2&limit=5&offset=20&role=admin&role=editor&since=2024-03-01&address.city=Paris&Ignored=x&secret=x",
+			formListUsers{
+				formPage: formPage{Limit: 5, Offset: 20},
+				UserID:   2,
+				Roles:    []string{"admin", "editor"},
+				Since:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Address:  &formAddress{City: "Paris"},
+			}},
+		{"comma separated", "user_id=3&role=admin,editor", formListUsers{formPage: formPage{Limit: 10}, UserID: 3, Roles: []string{"admin", "editor"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got formListUsers
+			if err := binder.Bind(values, &got); err != nil {
+				t.Fatalf("Bind: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Bind = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	binder := New(tago.TaGo{Name: "form"})
+
+	var users formListUsers
+	if err := binder.Bind(url.Values{}, users); err == nil {
+		t.Error("Bind into a struct: expected an error")
+	}
+
+	err := binder.Bind(url.Values{"limit": {"many"}}, &users)
+	if err == nil || !strings.Contains(err.Error(), `parameter "user_id" is required`) || !strings.Contains(err.Error(), `parameter "limit"`) {
+		t.Errorf("Bind = %v, want the errors of user_id and limit", err)
+	}
+}
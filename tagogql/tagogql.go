@@ -0,0 +1,219 @@
+// Package tagogql generates GraphQL SDL type definitions from tagged models.
+//
+// The following instructions are supported on fields:
+//
+//	gqlName=name       name of the GraphQL field (default: the Go field name in lowerCamelCase)
+//	gqlName=-          skip the field
+//	nonNull=true       non-nullable field (Type!)
+//	deprecated=reason  add a @deprecated(reason: "reason") directive
+//
+// Nested structs and slices of structs produce their own GraphQL types, embedded structs are flattened.
+//
+// Usage:
+//
+//	type User struct {
+//		ID      uint64  `gql:"nonNull=true"`
+//		Name    string  `gql:"gqlName=fullName"`
+//		Address Address
+//	}
+//	sdl, err := tagogql.SDL(tago.TaGo{Name: "gql"}, &User{})
+//	// type User {
+//	//   id: Int!
+//	//   fullName: String
+//	//   address: Address
+//	// }
+//	// ..
+package tagogql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/typeutil"
+)
+
+// SDL returns the GraphQL type definitions of the given models and of every struct type they reference
+// Types are written in discovery order.
+func SDL(t tago.TaGo, models ...any) (string, error) {
+	g := generator{
+		tag:   t,
+		names: make(map[reflect.Type]string),
+		used:  make(map[string]bool),
+	}
+
+	for _, model := range models {
+		if model == nil {
+			return "", errors.New("tagogql: nil model")
+		}
+		modelType := typeutil.Elem(reflect.TypeOf(model))
+		if modelType.Kind() != reflect.Struct {
+			return "", fmt.Errorf("tagogql: model %s is not a struct", modelType)
+		}
+		g.typeName(modelType, "")
+	}
+
+	// Types referenced while writing a type are queued, write until the queue is empty
+	var b strings.Builder
+	for i := 0; i < len(g.queue); i++ {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		g.writeType(&b, g.queue[i])
+	}
+	return b.String(), nil
+}
+
+type generator struct {
+	tag tago.TaGo
+
+	// GraphQL type name of each struct type, and the names already used
+	names map[reflect.Type]string
+	used  map[string]bool
+
+	// Struct types to write, in discovery order
+	queue []reflect.Type
+}
+
+// Get the GraphQL type name of a struct type, registering it if needed
+// Anonymous structs are named after their parent type and field name
+func (g *generator) typeName(typ reflect.Type, fallback string) string {
+	if name, exists := g.names[typ]; exists {
+		return name
+	}
+
+	name := sanitize(typ.Name())
+	if name == "" {
+		name = fallback
+	}
+
+	// Avoid conflicts between types with the same name in different packages
+	base := name
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	g.names[typ] = name
+	g.used[name] = true
+	g.queue = append(g.queue, typ)
+	return name
+}
+
+func (g *generator) writeType(b *strings.Builder, typ reflect.Type) {
+	fmt.Fprintf(b, "type %s {\n", g.names[typ])
+	g.writeFields(b, typ, g.names[typ], make(map[reflect.Type]bool))
+	b.WriteString("}\n")
+}
+
+// Write the fields of a struct type, visiting holds the struct types being flattened to stop on embedded cycles
+func (g *generator) writeFields(b *strings.Builder, typ reflect.Type, parentName string, visiting map[reflect.Type]bool) {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := g.tag.GetFromField(field)
+
+		name, hasName := tags.Lookup("gqlName")
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs are flattened into the parent type, like encoding/json does, exported or not, once per branch
+		if field.Anonymous && !hasName && typeutil.Elem(field.Type).Kind() == reflect.Struct {
+			if embedded := typeutil.Elem(field.Type); !visiting[embedded] {
+				g.writeFields(b, embedded, parentName, visiting)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if !hasName {
+			name = lowerCamel(field.Name)
+		}
+
+		gqlType := g.fieldType(field.Type, parentName+field.Name)
+		if nonNull, _ := tags.Lookup("nonNull"); nonNull == "true" {
+			gqlType += "!"
+		}
+
+		fmt.Fprintf(b, "  %s: %s", name, gqlType)
+		if reason, exists := tags.Lookup("deprecated"); exists {
+			fmt.Fprintf(b, " @deprecated(reason: %s)", strconv.Quote(reason))
+		}
+		b.WriteByte('\n')
+	}
+}
+
+// Get the GraphQL type of a Go type
+func (g *generator) fieldType(typ reflect.Type, fallback string) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.PkgPath() == "time" && typ.Name() == "Time" {
+		return "String"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int"
+	case reflect.Float32, reflect.Float64:
+		return "Float"
+	case reflect.Slice, reflect.Array:
+		// []byte is serialized as a (base64) string
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "String"
+		}
+		return "[" + g.fieldType(typ.Elem(), fallback) + "]"
+	case reflect.Struct:
+		return g.typeName(typ, fallback)
+	default:
+		return "String"
+	}
+}
+
+// Keep only valid GraphQL name characters, dropping the package paths of generic type arguments
+// Page[github.com/org/models.User] -> PageUser
+func sanitize(name string) string {
+	var b, token strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '.' || r == '/':
+			// What we read so far is a package path element
+			token.Reset()
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'):
+			token.WriteRune(r)
+		default:
+			b.WriteString(token.String())
+			token.Reset()
+		}
+	}
+	b.WriteString(token.String())
+	return b.String()
+}
+
+// ID -> id, UserName -> userName, URLPath -> urlPath
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		// Keep the last upper case letter of an acronym followed by a lower case letter: URLPath -> urlPath
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
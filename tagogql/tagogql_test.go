@@ -0,0 +1,89 @@
+package tagogql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type gqlBase struct {
+	ID uint64 `gql:"nonNull=true"`
+}
+
+type gqlAddress struct {
+	City string
+}
+
+type gqlUser struct {
+	gqlBase
+	Name      string `gql:"gqlName=fullName"`
+	Email     string `gql:"deprecated=use contacts"`
+	Addresses []gqlAddress
+	Created   time.Time
+	Raw       []byte
+	Hidden    string `gql:"gqlName=-"`
+	URLPath   string
+	secret    string
+}
+
+type gqlNode struct {
+	*gqlNode
+	X int
+}
+
+type gqlLeft struct {
+	*gqlRight
+	L string
+}
+
+type gqlRight struct {
+	*gqlLeft
+	R string
+}
+
+func TestSDL(t *testing.T) {
+	tg := tago.TaGo{Name: "gql"}
+
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{"model", &gqlUser{}, `type gqlUser {
+  id: Int!
+  fullName: String
+  email: String @deprecated(reason: "use contacts")
+  addresses: [gqlAddress]
+  created: String
+  raw: String
+  urlPath: String
+}
+
+type gqlAddress {
+  city: String
+}
+`},
+		// Embedded cycles are flattened once, like encoding/json does
+		{"embedded self pointer", &gqlNode{}, "type gqlNode {\n  x: Int\n}\n"},
+		{"embedded cycle", gqlLeft{}, "type gqlLeft {\n  r: String\n  l: String\n}\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SDL(tg, test.model)
+			if err != nil {
+				t.Fatalf("SDL: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("SDL =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+
+	if _, err := SDL(tg, nil); err == nil {
+		t.Error("SDL(nil): expected an error")
+	}
+	if _, err := SDL(tg, 1); err == nil {
+		t.Error("SDL(1): expected an error")
+	}
+}
@@ -0,0 +1,182 @@
+// Package tagomongo builds MongoDB projections and index specifications from tagged models, without depending on
+// the MongoDB driver: documents are built with the element type given as type parameter, bson.E (or primitive.E with
+// the v1 driver), so that the results convert to bson.D.
+//
+// The following instructions are supported on fields:
+//
+//	listView=true  include the field in the projection of list views
+//	index=name     add the field to the index with the given name, fields sharing a name form a compound index in
+//	               declaration order
+//	index          index the field alone
+//	unique=true    make the index of the field unique (a unique field without index= is indexed alone)
+//
+// Fields are named like the driver names them: bson tag name, or the lower cased Go field name. Nested structs are
+// traversed with dotted paths ("address.city"), fields tagged bson:",inline" are flattened and fields tagged bson:"-"
+// are skipped.
+//
+// Usage:
+//
+//	type User struct {
+//		ID      bson.ObjectID `bson:"_id" mongo:"listView=true"`
+//		Email   string        `bson:"email" mongo:"listView=true;unique=true"`
+//		Tenant  string        `bson:"tenant" mongo:"index=tenant_created"`
+//		Created time.Time     `bson:"created" mongo:"index=tenant_created"`
+//	}
+//	t := tago.TaGo{Name: "mongo"}
+//	projection, err := tagomongo.Projection[bson.E](t, &User{})
+//	cursor, err := users.Find(ctx, filter, options.Find().SetProjection(bson.D(projection)))
+//
+//	indexes, err := tagomongo.Indexes[bson.E](t, &User{})
+//	for _, index := range indexes {
+//		models = append(models, mongo.IndexModel{
+//			Keys:    bson.D(index.Keys),
+//			Options: options.Index().SetName(index.Name).SetUnique(index.Unique),
+//		})
+//	}
+package tagomongo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+// Element is the constraint satisfied by the element types of BSON documents: bson.E, primitive.E
+type Element interface {
+	~struct {
+		Key   string
+		Value any
+	}
+}
+
+// Index is the specification of an index
+type Index[E Element] struct {
+	Name string
+
+	// Indexed fields, in order, with their direction (1)
+	Keys []E
+
+	Unique bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Projection returns the projection of the fields tagged listView=true, in declaration order
+func Projection[E Element](t tago.TaGo, model any) ([]E, error) {
+	modelType, err := structType(model)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := make([]E, 0)
+	walk(t, modelType, "", map[reflect.Type]bool{}, func(path string, tags tago.Instructions) bool {
+		if listView, _ := tags.Lookup("listView"); listView == "true" {
+			projection = append(projection, E{Key: path, Value: 1})
+			return false
+		}
+		return true
+	})
+	return projection, nil
+}
+
+// Indexes returns the specifications of the indexes declared with index= and unique=true, in the order of their
+// first field
+func Indexes[E Element](t tago.TaGo, model any) ([]Index[E], error) {
+	modelType, err := structType(model)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]Index[E], 0)
+	positions := make(map[string]int)
+	walk(t, modelType, "", map[reflect.Type]bool{}, func(path string, tags tago.Instructions) bool {
+		name, indexed := tags.Lookup("index")
+		unique, _ := tags.Lookup("unique")
+		if !indexed && unique != "true" {
+			return true
+		}
+		if !indexed || name == "true" {
+			// Default name of the driver
+			name = path + "_1"
+		}
+
+		position, exists := positions[name]
+		if !exists {
+			position = len(indexes)
+			positions[name] = position
+			indexes = append(indexes, Index[E]{Name: name})
+		}
+		indexes[position].Keys = append(indexes[position].Keys, E{Key: path, Value: 1})
+		indexes[position].Unique = indexes[position].Unique || unique == "true"
+		return true
+	})
+	return indexes, nil
+}
+
+// Visit the fields of a struct type by dotted BSON path, traversing nested structs while visit returns true
+func walk(t tago.TaGo, typ reflect.Type, prefix string, visiting map[reflect.Type]bool, visit func(path string, tags tago.Instructions) bool) {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, inline, omitted := bsonName(field)
+		if omitted {
+			continue
+		}
+
+		nested := field.Type
+		for nested.Kind() == reflect.Ptr || nested.Kind() == reflect.Slice || nested.Kind() == reflect.Array {
+			nested = nested.Elem()
+		}
+		traversable := nested.Kind() == reflect.Struct && nested != timeType && !visiting[nested]
+
+		if inline && traversable {
+			walk(t, nested, prefix, visiting, visit)
+			continue
+		}
+		if visit(prefix+name, t.GetFromField(field)) && traversable {
+			walk(t, nested, prefix+name+".", visiting, visit)
+		}
+	}
+}
+
+// Name of a field in BSON documents, whether it is inlined and whether it is omitted, like the driver reads the bson tag
+func bsonName(field reflect.StructField) (string, bool, bool) {
+	tag, exists := field.Tag.Lookup("bson")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	if !exists || name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	inline := false
+	for _, option := range strings.Split(options, ",") {
+		inline = inline || option == "inline"
+	}
+	return name, inline, false
+}
+
+// Dereference the type of a model down to a struct
+func structType(model any) (reflect.Type, error) {
+	if model == nil {
+		return nil, errors.New("tagomongo: nil model")
+	}
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagomongo: model %s is not a struct", typ)
+	}
+	return typ, nil
+}
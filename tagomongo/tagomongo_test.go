@@ -0,0 +1,90 @@
+package tagomongo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+// Element type of the driver, bson.E
+type mongoE struct {
+	Key   string
+	Value any
+}
+
+type mongoAudit struct {
+	CreatedBy string `bson:"created_by" mongo:"listView=true;index=audit"`
+}
+
+type mongoAddress struct {
+	City    string `bson:"city" mongo:"listView=true;index=city_zip"`
+	Zip     string `mongo:"index=city_zip"`
+	Country string `bson:"country"`
+}
+
+type mongoUser struct {
+	ID        string       `bson:"_id" mongo:"listView=true"`
+	Email     string       `bson:"email,omitempty" mongo:"listView=true;unique=true"`
+	Tenant    string       `bson:"tenant" mongo:"index=tenant_created"`
+	Created   time.Time    `bson:"created" mongo:"index=tenant_created;listView=true"`
+	Nickname  string       `mongo:"index"`
+	Address   mongoAddress `bson:"address"`
+	Previous  []mongoAddress
+	Audit     mongoAudit   `bson:",inline"`
+	Manager   *mongoUser   `bson:"manager" mongo:"listView=true"`
+	Secret    string       `bson:"-" mongo:"listView=true"`
+	password  string       `mongo:"listView=true"`
+	Whole     mongoAddress `bson:"whole" mongo:"listView=true"`
+	Reference *mongoUser   `bson:"reference"`
+}
+
+func TestProjection(t *testing.T) {
+	projection, err := Projection[mongoE](tago.TaGo{Name: "mongo"}, &mongoUser{})
+	if err != nil {
+		t.Fatalf("Projection: %v", err)
+	}
+	want := []mongoE{
+		{"_id", 1},
+		{"email", 1},
+		{"created", 1},
+		{"address.city", 1},
+		{"previous.city", 1},
+		{"created_by", 1},
+		{"manager", 1},
+		{"whole", 1},
+	}
+	if !reflect.DeepEqual(projection, want) {
+		t.Errorf("Projection = %v, want %v", projection, want)
+	}
+}
+
+// Indexes declared on a nested struct are built from every path it is reached by, recursive types once
+func TestIndexes(t *testing.T) {
+	indexes, err := Indexes[mongoE](tago.TaGo{Name: "mongo"}, mongoUser{})
+	if err != nil {
+		t.Fatalf("Indexes: %v", err)
+	}
+	want := []Index[mongoE]{
+		{Name: "email_1", Keys: []mongoE{{"email", 1}}, Unique: true},
+		{Name: "tenant_created", Keys: []mongoE{{"tenant", 1}, {"created", 1}}},
+		{Name: "nickname_1", Keys: []mongoE{{"nickname", 1}}},
+		{Name: "city_zip", Keys: []mongoE{{"address.city", 1}, {"address.zip", 1}, {"previous.city", 1}, {"previous.zip", 1}, {"whole.city", 1}, {"whole.zip", 1}}},
+		{Name: "audit", Keys: []mongoE{{"created_by", 1}}},
+	}
+	if !reflect.DeepEqual(indexes, want) {
+		t.Errorf("Indexes =\n%v\nwant\n%v", indexes, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "mongo"}
+
+	if _, err := Projection[mongoE](tg, nil); err == nil {
+		t.Error("Projection(nil): expected an error")
+	}
+	if _, err := Indexes[mongoE](tg, 1); err == nil {
+		t.Error("Indexes(1): expected an error")
+	}
+}
@@ -0,0 +1,326 @@
+// Package tagoparquet describes the Parquet schema of tagged models in Arrow notation (the schema Arrow based
+// Parquet writers take), to export them to data lakes with the same definitions as the services producing them.
+//
+// The following instructions are supported on fields:
+//
+//	name=name                    name of the column (default: the json tag name, or the Go field name)
+//	name=-                       skip the field
+//	logicalType=timestamp-micros logical type of the column, see below
+//	precision=10;scale=2         precision and scale of decimal columns
+//
+// Logical types use the Avro vocabulary, so that one tag serves both tagoavro and tagoparquet:
+//
+//	date              date32[day]
+//	time-millis       time32[ms]
+//	time-micros       time64[us]
+//	timestamp-millis  timestamp[ms, tz=UTC] (default of time.Time fields)
+//	timestamp-micros  timestamp[us, tz=UTC]
+//	uuid              string
+//	decimal           decimal128(precision, scale)
+//
+// Pointers, slices and maps are nullable, other fields are not null. Embedded structs are flattened and fields tagged
+// json:"-" are skipped. Recursive types have no Parquet representation.
+//
+// Usage:
+//
+//	type Event struct {
+//		ID        string    `json:"id" parquet:"logicalType=uuid"`
+//		CreatedAt time.Time `json:"created_at" parquet:"logicalType=timestamp-micros"`
+//		Tags      []string  `json:"tags"`
+//	}
+//	schema, err := tagoparquet.Generate(tago.TaGo{Name: "parquet"}, &Event{})
+//	fmt.Println(schema)
+//	// id: string not null
+//	// created_at: timestamp[us, tz=UTC] not null
+//	// tags: list<item: string not null>
+package tagoparquet
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+// Schema is the Parquet schema of a model
+type Schema struct {
+	Fields []Field
+}
+
+// Field is a column of a Parquet schema, or a nested field
+type Field struct {
+	Name string
+
+	// Arrow notation of the type, nested types included: list<item: int64 not null>
+	Type string
+
+	Nullable bool
+
+	// Nested fields: the item of lists, the key and value of maps, the fields of structs
+	Children []Field
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Generate returns the Parquet schema of a model
+func Generate(t tago.TaGo, model any) (*Schema, error) {
+	if model == nil {
+		return nil, errors.New("tagoparquet: nil model")
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagoparquet: model %s is not a struct", modelType)
+	}
+
+	g := generator{tag: t, visiting: map[reflect.Type]bool{modelType: true}}
+	fields, err := g.fields(modelType)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{Fields: fields}, nil
+}
+
+// String returns the schema in Arrow notation, one column per line
+func (s Schema) String() string {
+	var b strings.Builder
+	for i, field := range s.Fields {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(field.String())
+	}
+	return b.String()
+}
+
+// String returns the field in Arrow notation: name: type [not null]
+func (f Field) String() string {
+	if f.Nullable {
+		return f.Name + ": " + f.Type
+	}
+	return f.Name + ": " + f.Type + " not null"
+}
+
+type generator struct {
+	tag tago.TaGo
+
+	// Struct types being described or flattened, to detect recursive types and embedded cycles
+	visiting map[reflect.Type]bool
+}
+
+func (g *generator) fields(typ reflect.Type) ([]Field, error) {
+	fields := make([]Field, 0)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := g.tag.GetFromField(field)
+
+		name, hasName := tags.Lookup("name")
+		if !hasName {
+			var omitted bool
+			if name, omitted = jsonName(field); omitted {
+				continue
+			}
+		}
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs are flattened into the parent, like encoding/json does, once per branch
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if g.visiting[fieldType] {
+				continue
+			}
+			g.visiting[fieldType] = true
+			embedded, err := g.fields(fieldType)
+			delete(g.visiting, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		column, err := g.field(name, field.Type, tags)
+		if err != nil {
+			return nil, fmt.Errorf("tagoparquet: %s.%s: %w", typ, field.Name, err)
+		}
+		fields = append(fields, column)
+	}
+	return fields, nil
+}
+
+// Describe a field of a Go type. The instructions of the field apply to the innermost type (the items of slices,
+// the values of maps).
+func (g *generator) field(name string, typ reflect.Type, tags tago.Instructions) (Field, error) {
+	field := Field{Name: name}
+	if typ.Kind() == reflect.Ptr {
+		field.Nullable = true
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+	}
+
+	if logicalType, exists := tags.Lookup("logicalType"); exists && !isCollection(typ) {
+		var err error
+		field.Type, err = logical(logicalType, tags)
+		return field, err
+	}
+
+	switch typ {
+	case timeType:
+		field.Type, _ = logical("timestamp-millis", tags)
+		return field, nil
+	case bytesType:
+		field.Type = "binary"
+		field.Nullable = true
+		return field, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		field.Type = "bool"
+	case reflect.Int:
+		field.Type = "int64"
+	case reflect.Uint, reflect.Uintptr:
+		field.Type = "uint64"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.Type = typ.Kind().String()
+	case reflect.Float32:
+		field.Type = "float"
+	case reflect.Float64:
+		field.Type = "double"
+	case reflect.String:
+		field.Type = "string"
+	case reflect.Slice, reflect.Array:
+		item, err := g.field("item", typ.Elem(), tags)
+		if err != nil {
+			return field, err
+		}
+		field.Children = []Field{item}
+		field.Type = "list<" + item.String() + ">"
+		field.Nullable = field.Nullable || typ.Kind() == reflect.Slice
+	case reflect.Map:
+		key, err := g.field("key", typ.Key(), nil)
+		if err != nil {
+			return field, err
+		}
+		value, err := g.field("value", typ.Elem(), tags)
+		if err != nil {
+			return field, err
+		}
+		field.Children = []Field{key, value}
+		field.Type = "map<" + key.Type + ", " + value.Type + ">"
+		field.Nullable = true
+	case reflect.Struct:
+		if g.visiting[typ] {
+			return field, fmt.Errorf("recursive type %s has no Parquet representation", typ)
+		}
+		g.visiting[typ] = true
+		defer delete(g.visiting, typ)
+
+		children, err := g.fields(typ)
+		if err != nil {
+			return field, err
+		}
+		descriptions := make([]string, len(children))
+		for i, child := range children {
+			descriptions[i] = child.String()
+		}
+		field.Children = children
+		field.Type = "struct<" + strings.Join(descriptions, ", ") + ">"
+	default:
+		return field, fmt.Errorf("type %s has no Parquet representation", typ)
+	}
+	return field, nil
+}
+
+// Arrow type of a logical type
+func logical(logicalType string, tags tago.Instructions) (string, error) {
+	switch logicalType {
+	case "date":
+		return "date32[day]", nil
+	case "time-millis":
+		return "time32[ms]", nil
+	case "time-micros":
+		return "time64[us]", nil
+	case "timestamp-millis":
+		return "timestamp[ms, tz=UTC]", nil
+	case "timestamp-micros":
+		return "timestamp[us, tz=UTC]", nil
+	case "uuid":
+		// Go models hold UUIDs in their string form
+		return "string", nil
+	case "decimal":
+		precision, err := intInstruction(tags, "precision")
+		if err != nil {
+			return "", err
+		}
+		if precision <= 0 {
+			return "", errors.New("decimal requires a positive precision")
+		}
+		scale, err := intInstruction(tags, "scale")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("decimal128(%d, %d)", precision, scale), nil
+	}
+	return "", fmt.Errorf("unknown logical type %q", logicalType)
+}
+
+// Value of an integer instruction, 0 if missing
+func intInstruction(tags tago.Instructions, key string) (int, error) {
+	value, exists := tags.Lookup(key)
+	if !exists {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return n, nil
+}
+
+// Check whether a type is a slice, an array or a map, []byte excepted
+func isCollection(typ reflect.Type) bool {
+	if typ == bytesType {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// Name of a field in JSON documents from its json tag, empty if it has none, and whether the field is omitted
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, exists := field.Tag.Lookup("json")
+	if !exists {
+		return "", false
+	}
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
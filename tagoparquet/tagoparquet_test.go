@@ -0,0 +1,100 @@
+package tagoparquet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type parquetLine struct {
+	SKU string `json:"sku"`
+}
+
+type parquetEvent struct {
+	ID        string            `json:"id" parquet:"logicalType=uuid"`
+	Amount    float64           `json:"amount" parquet:"logicalType=decimal;precision=10;scale=2"`
+	CreatedAt time.Time         `json:"created_at" parquet:"logicalType=timestamp-micros"`
+	Comment   *string           `json:"comment"`
+	Count     uint64            `json:"count"`
+	Tags      []string          `json:"tags"`
+	Attrs     map[string]int    `json:"attrs"`
+	Lines     []parquetLine     `json:"lines"`
+	Raw       []byte            `json:"raw"`
+	Skipped   string            `json:"-"`
+	Flag      bool              `parquet:"name=flag"`
+	Inline    struct{ A int32 } `json:"inline"`
+}
+
+type parquetNode struct {
+	*parquetNode
+	X int `json:"x"`
+}
+
+type parquetTree struct {
+	Children []parquetTree
+}
+
+func TestGenerate(t *testing.T) {
+	tg := tago.TaGo{Name: "parquet"}
+
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{"schema", &parquetEvent{}, `id: string not null
+amount: decimal128(10, 2) not null
+created_at: timestamp[us, tz=UTC] not null
+comment: string
+count: uint64 not null
+tags: list<item: string not null>
+attrs: map<string, int64>
+lines: list<item: struct<sku: string not null> not null>
+raw: binary
+flag: bool not null
+inline: struct<A: int32 not null> not null`},
+		// Embedded cycles are flattened once, like encoding/json does
+		{"embedded cycle", &parquetNode{}, "x: int64 not null"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, err := Generate(tg, test.model)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if got := schema.String(); got != test.want {
+				t.Errorf("Generate =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "parquet"}
+
+	tests := []struct {
+		name  string
+		model any
+		err   string
+	}{
+		{"nil", nil, "nil model"},
+		{"not a struct", 1, "not a struct"},
+		{"recursive", &parquetTree{}, "recursive type"},
+		{"unknown logical type", &struct {
+			A string `parquet:"logicalType=money"`
+		}{}, `unknown logical type "money"`},
+		{"decimal without precision", &struct {
+			A float64 `parquet:"logicalType=decimal"`
+		}{}, "positive precision"},
+		{"no Parquet representation", &struct {
+			A func()
+		}{}, "no Parquet representation"},
+	}
+	for _, test := range tests {
+		if _, err := Generate(tg, test.model); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Generate(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+}
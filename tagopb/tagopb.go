@@ -0,0 +1,153 @@
+// Package tagopb maps tagged Go struct fields to protobuf field numbers, for structs whose
+// proto compatibility is maintained by hand.
+//
+// The following instructions are supported on fields:
+//
+//	pb=3          protobuf field number
+//	pbName=name   protobuf field name (default: the Go field name in snake_case)
+//
+// Each struct is a message: field numbers must be unique within a struct, and nested structs
+// (which are messages of their own) are mapped recursively.
+//
+// Usage:
+//
+//	type User struct {
+//		ID      uint64  `proto:"pb=1"`
+//		Email   string  `proto:"pb=2;pbName=email_address"`
+//		Address Address `proto:"pb=3"`
+//	}
+//	mapping, err := tagopb.Map(tago.TaGo{Name: "proto"}, &User{})
+package tagopb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/naming"
+	"github.com/KooQix/tago/internal/typeutil"
+)
+
+const (
+	// MaxFieldNumber is the largest protobuf field number
+	MaxFieldNumber = 1<<29 - 1
+
+	// Field numbers reserved by the protobuf implementation
+	firstReservedNumber = 19000
+	lastReservedNumber  = 19999
+)
+
+// Field is the protobuf mapping of a Go struct field
+type Field struct {
+	// Path of the Go field, nested fields are prefixed with their parent field names ("Address.City")
+	Path tago.FieldName
+
+	// Go type of the message (struct) declaring the field
+	Message reflect.Type
+
+	Number int
+	Name   string
+}
+
+// Mapping of a model, messages in discovery order, fields by number within a message
+type Mapping []Field
+
+// Map returns the protobuf mapping of the fields of a model carrying a pb= instruction.
+// An error is returned, along with the mapping, if a number is invalid, reserved, or used twice in the same message.
+func Map(t tago.TaGo, model any) (Mapping, error) {
+	if model == nil {
+		return nil, errors.New("tagopb: nil model")
+	}
+	modelType := typeutil.Elem(reflect.TypeOf(model))
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagopb: model %s is not a struct", modelType)
+	}
+
+	m := mapper{tag: t, visiting: make(map[reflect.Type]bool)}
+	m.message(modelType, "")
+	return m.mapping, errors.Join(m.errs...)
+}
+
+type mapper struct {
+	tag      tago.TaGo
+	mapping  Mapping
+	errs     []error
+	visiting map[reflect.Type]bool
+}
+
+func (m *mapper) message(typ reflect.Type, prefix string) {
+	// Recursive messages are only mapped once per branch
+	if m.visiting[typ] {
+		return
+	}
+	m.visiting[typ] = true
+	defer delete(m.visiting, typ)
+
+	fields := make([]Field, 0)
+	numbers := make(map[int]tago.FieldName)
+	nestedFields := make([]reflect.StructField, 0)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := m.tag.GetFromField(field)
+		path := tago.FieldName(prefix + field.Name)
+
+		if value, exists := tags.Lookup("pb"); exists {
+			number, err := strconv.Atoi(value)
+			switch {
+			case err != nil:
+				m.errs = append(m.errs, fmt.Errorf("tagopb: %s: invalid field number %q", path, value))
+			case number < 1 || number > MaxFieldNumber:
+				m.errs = append(m.errs, fmt.Errorf("tagopb: %s: field number %d out of range [1, %d]", path, number, MaxFieldNumber))
+			case number >= firstReservedNumber && number <= lastReservedNumber:
+				m.errs = append(m.errs, fmt.Errorf("tagopb: %s: field number %d is reserved by protobuf", path, number))
+			default:
+				if other, used := numbers[number]; used {
+					m.errs = append(m.errs, fmt.Errorf("tagopb: %s: field number %d already used by %s", path, number, other))
+				}
+				numbers[number] = path
+
+				name, hasName := tags.Lookup("pbName")
+				if !hasName {
+					name = naming.SnakeCase(field.Name)
+				}
+				fields = append(fields, Field{Path: path, Message: typ, Number: number, Name: name})
+			}
+		}
+
+		// Nested structs are messages of their own
+		if nested := typeutil.Elem(field.Type); nested.Kind() == reflect.Struct && nested.PkgPath() != "time" {
+			nestedFields = append(nestedFields, field)
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Number < fields[j].Number })
+	m.mapping = append(m.mapping, fields...)
+
+	// Then the nested messages, after the fields of the current one
+	for _, field := range nestedFields {
+		m.message(typeutil.Elem(field.Type), prefix+field.Name+".")
+	}
+}
+
+// Table returns the mapping as a human readable table, one field per line
+//
+// Example:
+//
+//	User     ID            1  id
+//	User     Email         2  email_address
+//	Address  Address.City  1  city
+func (mapping Mapping) Table() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for _, field := range mapping {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", field.Message.Name(), field.Path, field.Number, field.Name)
+	}
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,361 @@
+// Package tagoschema generates JSON Schema (draft 2020-12) documents from tagged models, to validate inbound JSON
+// payloads against schemas derived from the structs decoding them.
+//
+// The following instructions are supported on fields:
+//
+//	required=true      the property is required
+//	min=1;max=64       bounds of numbers, of the length of strings and of the number of items of slices
+//	pattern=^[a-z]+$   regular expression strings must match
+//	enum=a|b|c         allowed values, converted to the type of the field
+//	description=text   description of the property
+//
+// Properties are named like encoding/json names them (json tag, or the Go field name), fields tagged json:"-" are
+// skipped and embedded structs are flattened. Named struct types are defined once under $defs and referenced with
+// $ref, recursive types included; anonymous structs are inlined.
+//
+// Usage:
+//
+//	type Webhook struct {
+//		Event   string  `json:"event" schema:"required;enum=created|deleted"`
+//		Retries int     `json:"retries" schema:"min=0;max=5"`
+//		Payload Payload `json:"payload" schema:"required"`
+//	}
+//	doc, err := tagoschema.JSON(tago.TaGo{Name: "schema"}, &Webhook{})
+package tagoschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+// Draft is the JSON Schema dialect of the generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, limited to the keywords the generator produces
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+
+	// Schema of the values of maps
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Generate returns the JSON Schema of a model, with the definitions of the struct types it references
+func Generate(t tago.TaGo, model any) (*Schema, error) {
+	if model == nil {
+		return nil, errors.New("tagoschema: nil model")
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagoschema: model %s is not a struct", modelType)
+	}
+
+	g := generator{
+		tag:   t,
+		names: make(map[reflect.Type]string),
+		used:  make(map[string]bool),
+		defs:  make(map[string]*Schema),
+		root:  modelType,
+	}
+
+	// The model itself is inlined at the root, recursive fields reference it with "#"
+	root := &Schema{Schema: Draft}
+	if err := g.object(root, modelType, make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root, nil
+}
+
+// JSON returns the JSON Schema document of a model, indented
+func JSON(t tago.TaGo, model any) ([]byte, error) {
+	schema, err := Generate(t, model)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+type generator struct {
+	tag tago.TaGo
+
+	// Name of each struct type under $defs, and the names already used
+	names map[reflect.Type]string
+	used  map[string]bool
+
+	defs map[string]*Schema
+
+	// Type of the model, at the root of the document
+	root reflect.Type
+}
+
+// Name of a struct type under $defs, unique even for types of the same name in different packages
+func (g *generator) uniqueName(typ reflect.Type, fallback string) string {
+	name := typ.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		// Generic instantiation: Page[main.User] -> Page
+		name = name[:i]
+	}
+	if name == "" {
+		name = fallback
+	}
+	base := name
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.used[name] = true
+	return name
+}
+
+// Reference to a named struct type, defining it under $defs on first use
+func (g *generator) ref(typ reflect.Type) (*Schema, error) {
+	if typ == g.root {
+		return &Schema{Ref: "#"}, nil
+	}
+	name, exists := g.names[typ]
+	if !exists {
+		name = g.uniqueName(typ, "")
+		g.names[typ] = name
+	}
+	if _, defined := g.defs[name]; !defined {
+		def := &Schema{}
+		g.defs[name] = def
+		if err := g.object(def, typ, make(map[reflect.Type]bool)); err != nil {
+			return nil, err
+		}
+	}
+	return &Schema{Ref: "#/$defs/" + name}, nil
+}
+
+// Fill an object schema with the properties of a struct type, visiting holds the types being flattened to stop on
+// embedded cycles
+func (g *generator) object(schema *Schema, typ reflect.Type, visiting map[reflect.Type]bool) error {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	schema.Type = "object"
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*Schema)
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, omitted := jsonName(field)
+		if omitted {
+			continue
+		}
+
+		// Embedded structs are flattened, like encoding/json does, once per branch
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if visiting[fieldType] {
+				continue
+			}
+			if err := g.object(schema, fieldType, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := g.schema(field.Type)
+		if err != nil {
+			return fmt.Errorf("tagoschema: %s.%s: %w", typ, field.Name, err)
+		}
+		tags := g.tag.GetFromField(field)
+		if err := constrain(property, fieldType, tags); err != nil {
+			return fmt.Errorf("tagoschema: %s.%s: %w", typ, field.Name, err)
+		}
+		schema.Properties[name] = property
+
+		if required, _ := tags.Lookup("required"); required == "true" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+// Schema of a Go type, without the constraints of its field
+func (g *generator) schema(typ reflect.Type) (*Schema, error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case typ == bytesType:
+		// encoding/json writes []byte in base64
+		return &Schema{Type: "string", Format: "byte"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := g.schema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Interface:
+		// Any value
+		return &Schema{}, nil
+	case reflect.Struct:
+		if typ.Name() == "" {
+			inline := &Schema{}
+			return inline, g.object(inline, typ, make(map[reflect.Type]bool))
+		}
+		return g.ref(typ)
+	}
+	return nil, fmt.Errorf("type %s has no JSON representation", typ)
+}
+
+// Apply the instructions of a field to its schema. typ is the field type, pointers dereferenced.
+func constrain(schema *Schema, typ reflect.Type, tags tago.Instructions) error {
+	if description, exists := tags.Lookup("description"); exists {
+		schema.Description = description
+	}
+	if pattern, exists := tags.Lookup("pattern"); exists {
+		schema.Pattern = pattern
+	}
+
+	for _, key := range []string{"min", "max"} {
+		value, exists := tags.Lookup(key)
+		if !exists {
+			continue
+		}
+		bound, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", key, value)
+		}
+
+		switch schema.Type {
+		case "integer", "number":
+			if key == "min" {
+				schema.Minimum = &bound
+			} else {
+				schema.Maximum = &bound
+			}
+		case "string":
+			length := int(bound)
+			if key == "min" {
+				schema.MinLength = &length
+			} else {
+				schema.MaxLength = &length
+			}
+		case "array":
+			length := int(bound)
+			if key == "min" {
+				schema.MinItems = &length
+			} else {
+				schema.MaxItems = &length
+			}
+		default:
+			return fmt.Errorf("%s isn't supported on a field of type %s", key, typ)
+		}
+	}
+
+	if enum, exists := tags.Lookup("enum"); exists {
+		for _, item := range strings.Split(enum, "|") {
+			value, err := enumValue(schema.Type, strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+			schema.Enum = append(schema.Enum, value)
+		}
+	}
+	return nil
+}
+
+// Value of an enum item, converted to the JSON type of the field
+func enumValue(jsonType string, item string) (any, error) {
+	switch jsonType {
+	case "integer":
+		value, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer enum value %q", item)
+		}
+		return value, nil
+	case "number":
+		value, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number enum value %q", item)
+		}
+		return value, nil
+	case "boolean":
+		value, err := strconv.ParseBool(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean enum value %q", item)
+		}
+		return value, nil
+	}
+	return item, nil
+}
+
+// Name of a field in JSON documents from its json tag, empty if it has none, and whether the field is omitted
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, exists := field.Tag.Lookup("json")
+	if !exists {
+		return "", false
+	}
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
@@ -0,0 +1,126 @@
+package tagoschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type schemaPayload struct {
+	ID   int64 `json:"id" schema:"required;min=1"`
+	Next *schemaPayload
+}
+
+type schemaWebhook struct {
+	Event   string            `json:"event" schema:"required;enum=created|deleted;description=kind of event"`
+	Retries int               `json:"retries" schema:"min=0;max=5;enum=0|1"`
+	Name    string            `json:"name,omitempty" schema:"min=1;max=64;pattern=^[a-z]+$"`
+	Tags    []string          `json:"tags" schema:"max=3"`
+	Payload schemaPayload     `json:"payload" schema:"required"`
+	Headers map[string]string `json:"headers"`
+	At      time.Time         `json:"at"`
+	Raw     []byte            `json:"raw"`
+	Extra   any               `json:"-"`
+	Parent  *schemaWebhook    `json:"parent"`
+	Inline  struct {
+		OK bool `json:"ok"`
+	} `json:"inline"`
+}
+
+type schemaNode struct {
+	*schemaNode
+	X int `json:"x"`
+}
+
+func TestGenerate(t *testing.T) {
+	tg := tago.TaGo{Name: "schema"}
+
+	schema, err := Generate(tg, &schemaWebhook{})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if schema.Schema != Draft || schema.Type != "object" || !reflect.DeepEqual(schema.Required, []string{"event", "payload"}) {
+		t.Errorf("Generate = %+v", schema)
+	}
+
+	one, five, sixtyFour, three, zero := 1.0, 5.0, 64, 3, 0.0
+	minLength := 1
+	tests := []struct {
+		property string
+		want     *Schema
+	}{
+		{"event", &Schema{Type: "string", Description: "kind of event", Enum: []any{"created", "deleted"}}},
+		{"retries", &Schema{Type: "integer", Enum: []any{int64(0), int64(1)}, Minimum: &zero, Maximum: &five}},
+		{"name", &Schema{Type: "string", Pattern: "^[a-z]+$", MinLength: &minLength, MaxLength: &sixtyFour}},
+		{"tags", &Schema{Type: "array", MaxItems: &three, Items: &Schema{Type: "string"}}},
+		{"payload", &Schema{Ref: "#/$defs/schemaPayload"}},
+		{"headers", &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}},
+		{"at", &Schema{Type: "string", Format: "date-time"}},
+		{"raw", &Schema{Type: "string", Format: "byte"}},
+		{"parent", &Schema{Ref: "#"}},
+		{"inline", &Schema{Type: "object", Properties: map[string]*Schema{"ok": {Type: "boolean"}}}},
+	}
+	for _, test := range tests {
+		if got := schema.Properties[test.property]; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("property %s = %+v, want %+v", test.property, got, test.want)
+		}
+	}
+	if _, exists := schema.Properties["Extra"]; exists || len(schema.Properties) != len(tests) {
+		t.Errorf("properties = %v, want %d without Extra", schema.Properties, len(tests))
+	}
+
+	// Named types are defined once, recursive ones included
+	payload := schema.Defs["schemaPayload"]
+	if payload == nil || !reflect.DeepEqual(payload.Properties["Next"], &Schema{Ref: "#/$defs/schemaPayload"}) ||
+		!reflect.DeepEqual(payload.Properties["id"], &Schema{Type: "integer", Minimum: &one}) {
+		t.Errorf("$defs = %+v", schema.Defs)
+	}
+}
+
+func TestGenerateEmbeddedCycle(t *testing.T) {
+	doc, err := JSON(tago.TaGo{Name: "schema"}, &schemaNode{})
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(doc, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if properties := schema["properties"]; !reflect.DeepEqual(properties, map[string]any{"x": map[string]any{"type": "integer"}}) {
+		t.Errorf("properties = %v, want x only", properties)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "schema"}
+
+	tests := []struct {
+		name  string
+		model any
+		err   string
+	}{
+		{"nil", nil, "nil model"},
+		{"not a struct", 1, "not a struct"},
+		{"invalid bound", &struct {
+			A int `schema:"min=one"`
+		}{}, `invalid min "one"`},
+		{"unsupported bound", &struct {
+			A bool `schema:"max=1"`
+		}{}, "max isn't supported"},
+		{"invalid enum", &struct {
+			A int `schema:"enum=a|b"`
+		}{}, "A"},
+		{"no JSON representation", &struct {
+			A func()
+		}{}, "no JSON representation"},
+	}
+	for _, test := range tests {
+		if _, err := Generate(tg, test.model); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Generate(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+}
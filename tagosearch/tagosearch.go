@@ -0,0 +1,136 @@
+// Package tagosearch builds full-text search documents from tagged models, for Elasticsearch, OpenSearch or Bleve
+// indexing pipelines.
+//
+// The following instructions are supported on fields:
+//
+//	search=true        index the field (or the bare search flag)
+//	searchName=title   name of the field in the document (default: its path, "Address.City")
+//	boost=2.5          relevance boost of the field (default: 1)
+//	analyzer=english   analyzer of the field (default: the index default)
+//
+// Nested fields are indexed under their path. Fields inside slices of structs aren't reachable by path and are skipped,
+// tag the slice itself to index it as a whole. Unexported fields are skipped.
+//
+// Usage:
+//
+//	type Product struct {
+//		Title       string   `search:"search=true;boost=3;analyzer=english"`
+//		Description string   `search:"search=true;analyzer=english"`
+//		Tags        []string `search:"search=true;searchName=tags"`
+//		Price       int
+//	}
+//	doc, err := tagosearch.Build(tago.TaGo{Name: "search"}, &product)
+//	body, err := json.Marshal(doc) // {"Description":"..","Title":"..","tags":[..]}
+package tagosearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/KooQix/tago"
+)
+
+// Field of a search document
+type Field struct {
+	Name     string
+	Value    any
+	Boost    float64
+	Analyzer string
+}
+
+// Document is a search document, its fields in model order
+type Document struct {
+	Fields []Field
+}
+
+// Build returns the search document of a populated model
+func Build(t tago.TaGo, model any) (Document, error) {
+	if model == nil {
+		return Document{}, errors.New("tagosearch: nil model")
+	}
+
+	doc := Document{}
+	var errs []error
+	err := t.ApplyFieldPipeline(model, map[string]func(tago.FieldContext) error{
+		// search=true or the bare search flag
+		"search": func(ctx tago.FieldContext) error {
+			if ctx.Instruction.Value() != "true" {
+				return nil
+			}
+			v, ok := value(ctx.Value)
+			if !ok {
+				return nil
+			}
+
+			field := Field{Name: ctx.Path.String(), Value: v, Boost: 1}
+			for _, instruction := range ctx.Instructions {
+				switch instruction.Key() {
+				case "searchName":
+					field.Name = instruction.Value()
+				case "analyzer":
+					field.Analyzer = instruction.Value()
+				case "boost":
+					boost, err := strconv.ParseFloat(instruction.Value(), 64)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("tagosearch: field %s: invalid boost %q", ctx.Path, instruction.Value()))
+						continue
+					}
+					field.Boost = boost
+				}
+			}
+			doc.Fields = append(doc.Fields, field)
+			return nil
+		},
+	})
+	errs = append(errs, err)
+	return doc, errors.Join(errs...)
+}
+
+// Value of a field, nil for a nil pointer. False if it can't be read: invalid (behind a nil pointer, inside a slice)
+// or unexported.
+func value(v reflect.Value) (any, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), true
+}
+
+// Map returns the document as a flat map of field names to values
+func (d Document) Map() map[string]any {
+	m := make(map[string]any, len(d.Fields))
+	for _, field := range d.Fields {
+		m[field.Name] = field.Value
+	}
+	return m
+}
+
+// MarshalJSON writes the document as a flat JSON object of field names to values
+func (d Document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Map())
+}
+
+// Mapping returns the index mapping of the document fields (Elasticsearch "properties" style):
+// a text type, with the analyzer and boost when set
+func (d Document) Mapping() map[string]any {
+	properties := make(map[string]any, len(d.Fields))
+	for _, field := range d.Fields {
+		property := map[string]any{"type": "text"}
+		if field.Analyzer != "" {
+			property["analyzer"] = field.Analyzer
+		}
+		if field.Boost != 1 {
+			property["boost"] = field.Boost
+		}
+		properties[field.Name] = property
+	}
+	return map[string]any{"properties": properties}
+}
@@ -0,0 +1,89 @@
+package tagosearch
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+type searchAddress struct {
+	City string `search:"search;analyzer=french"`
+}
+
+type searchProduct struct {
+	Title       string         `search:"search=true;boost=3;analyzer=english"`
+	Description string         `search:"search"`
+	Tags        []string       `search:"search=true;searchName=tags"`
+	Draft       string         `search:"search=false"`
+	Price       int            `search:"boost=2"`
+	Address     searchAddress  `search:"searchName=address"`
+	Shipping    *searchAddress `search:""`
+	Note        *string        `search:"search"`
+	secret      string         `search:"search=true"`
+}
+
+func TestBuild(t *testing.T) {
+	tg := tago.TaGo{Name: "search"}
+	product := searchProduct{
+		Title:       "Chair",
+		Description: "A chair",
+		Tags:        []string{"wood"},
+		Draft:       "draft",
+		Price:       10,
+		Address:     searchAddress{City: "Paris"},
+		secret:      "secret",
+	}
+
+	doc, err := Build(tg, &product)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	want := []Field{
+		{Name: "Title", Value: "Chair", Boost: 3, Analyzer: "english"},
+		{Name: "Description", Value: "A chair", Boost: 1},
+		{Name: "tags", Value: []string{"wood"}, Boost: 1},
+		{Name: "Address.City", Value: "Paris", Boost: 1, Analyzer: "french"},
+		{Name: "Note", Value: nil, Boost: 1},
+	}
+	if !reflect.DeepEqual(doc.Fields, want) {
+		t.Errorf("Build = %+v, want %+v", doc.Fields, want)
+	}
+
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(encoded); got != `{"Address.City":"Paris","Description":"A chair","Note":null,"Title":"Chair","tags":["wood"]}` {
+		t.Errorf("MarshalJSON = %s", got)
+	}
+
+	mapping := doc.Mapping()["properties"].(map[string]any)
+	if got := mapping["Title"]; !reflect.DeepEqual(got, map[string]any{"type": "text", "analyzer": "english", "boost": 3.0}) {
+		t.Errorf("Mapping()[Title] = %v", got)
+	}
+	if got := mapping["tags"]; !reflect.DeepEqual(got, map[string]any{"type": "text"}) {
+		t.Errorf("Mapping()[tags] = %v", got)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "search"}
+
+	if _, err := Build(tg, nil); err == nil {
+		t.Error("Build(nil): expected an error")
+	}
+
+	type invalid struct {
+		Title string `search:"search;boost=high"`
+	}
+	doc, err := Build(tg, &invalid{Title: "a"})
+	if err == nil || !strings.Contains(err.Error(), `field Title: invalid boost "high"`) {
+		t.Errorf("Build with an invalid boost: %v", err)
+	}
+	if len(doc.Fields) != 1 || doc.Fields[0].Boost != 1 {
+		t.Errorf("Build with an invalid boost = %+v, want the field with the default boost", doc.Fields)
+	}
+}
@@ -0,0 +1,262 @@
+// Package tagosource maps the types and fields of tagged models back to their position in the source code,
+// for precise diagnostics (file:line) in validation and linting tools.
+//
+// Sources are found with go/build (GOPATH and modules, through the go command) and parsed with go/parser,
+// so the package must be available on the machine running the locator. It lives in its own package
+// so programs which don't need it don't pay for the parsing.
+//
+// Usage:
+//
+//	locator := tagosource.New()
+//	position, err := locator.Field(reflect.TypeOf(User{}), "Email")
+//	fmt.Println(position) // /src/models/user.go:12:2
+//
+//	t.OnWarning(func(w tago.Warning) {
+//		log.Println(locator.Warning(w)) // /src/models/user.go:12:2: models.User: Email: ..
+//	})
+//
+// Only the files of the package matching the build constraints of the current platform (go/build.Default) are parsed,
+// so a type declared once per platform is found in the file built here.
+package tagosource
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/astutil"
+	"github.com/KooQix/tago/internal/typeutil"
+)
+
+// Locator finds the source position of types and fields, parsing each package once
+type Locator struct {
+	fset *token.FileSet
+
+	mu sync.Mutex
+
+	// Directory of the packages, found with go/build unless set with AddDir
+	dirs map[string]string
+
+	// Separator of the field paths of warnings (see SetSeparator)
+	separator string
+
+	// Struct type declarations of the parsed packages, by package path and type name
+	types map[string]map[string]*ast.TypeSpec
+}
+
+// New returns a Locator
+func New() *Locator {
+	return &Locator{
+		fset:      token.NewFileSet(),
+		dirs:      make(map[string]string),
+		separator: ".",
+		types:     make(map[string]map[string]*ast.TypeSpec),
+	}
+}
+
+// SetSeparator sets the separator of the field paths of the warnings given to Warning, "." by default:
+// the separator of the GetNested calls reporting them
+func (l *Locator) SetSeparator(separator string) *Locator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.separator = separator
+	return l
+}
+
+// AddDir sets the source directory of a package, for packages go/build can't find (e.g. "main")
+func (l *Locator) AddDir(pkgPath string, dir string) *Locator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dirs[pkgPath] = dir
+	delete(l.types, pkgPath)
+	return l
+}
+
+// Type returns the position of the declaration of a named type (pointers are dereferenced)
+func (l *Locator) Type(typ reflect.Type) (token.Position, error) {
+	spec, err := l.typeSpec(typ)
+	if err != nil {
+		return token.Position{}, err
+	}
+	return l.fset.Position(spec.Name.Pos()), nil
+}
+
+// Field returns the position of a field in the declaration of a named struct type (pointers are dereferenced).
+// Embedded fields are named after their type, like in reflect.
+func (l *Locator) Field(typ reflect.Type, field string) (token.Position, error) {
+	spec, err := l.typeSpec(typ)
+	if err != nil {
+		return token.Position{}, err
+	}
+	structType, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return token.Position{}, fmt.Errorf("tagosource: %s is not a struct", typ)
+	}
+
+	for _, f := range structType.Fields.List {
+		if len(f.Names) == 0 {
+			if astutil.EmbeddedName(f.Type) == field {
+				return l.fset.Position(f.Pos()), nil
+			}
+			continue
+		}
+		for _, name := range f.Names {
+			if name.Name == field {
+				return l.fset.Position(name.Pos()), nil
+			}
+		}
+	}
+	return token.Position{}, fmt.Errorf("tagosource: no field %s in %s", field, typ)
+}
+
+// Path returns the position of the field at a path of a model, e.g. "Address.City" with the separator ".",
+// following nested structs, pointers and slices like GetNested
+func (l *Locator) Path(model reflect.Type, path tago.FieldName, separator string) (token.Position, error) {
+	owner := typeutil.Elem(model)
+	segments := strings.Split(path.String(), separator)
+	for i, segment := range segments {
+		if owner.Kind() != reflect.Struct {
+			return token.Position{}, fmt.Errorf("tagosource: %s: %s is not a struct", path, owner)
+		}
+		field, found := owner.FieldByName(segment)
+		if !found {
+			return token.Position{}, fmt.Errorf("tagosource: %s: no field %s in %s", path, segment, owner)
+		}
+		if i < len(segments)-1 {
+			owner = typeutil.Elem(field.Type)
+			continue
+		}
+
+		// Promoted fields are declared by the embedded type
+		for len(field.Index) > 1 {
+			owner = typeutil.Elem(owner.Field(field.Index[0]).Type)
+			field, _ = owner.FieldByName(segment)
+		}
+		return l.Field(owner, segment)
+	}
+	return token.Position{}, errors.New("tagosource: empty path")
+}
+
+// Warning formats a warning prefixed with the position of its field (or type) when it can be found
+func (l *Locator) Warning(w tago.Warning) string {
+	if w.Type == nil {
+		return w.String()
+	}
+
+	// The field of a warning is its full path, the type declares its last segment
+	l.mu.Lock()
+	separator := l.separator
+	l.mu.Unlock()
+	field := lastSegment(w.Type, w.Field.String(), separator)
+
+	position, err := l.Field(w.Type, field)
+	if err != nil {
+		if position, err = l.Type(w.Type); err != nil {
+			return w.String()
+		}
+	}
+	return position.String() + ": " + w.String()
+}
+
+// Last segment of a field path, the field of the owner type. Without separator the segments are concatenated:
+// it's the longest field name of the owner ending the path.
+func lastSegment(owner reflect.Type, path string, separator string) string {
+	if separator != "" {
+		if i := strings.LastIndex(path, separator); i >= 0 {
+			return path[i+len(separator):]
+		}
+		return path
+	}
+
+	owner = typeutil.Elem(owner)
+	if owner.Kind() != reflect.Struct {
+		return path
+	}
+	segment := ""
+	for i := 0; i < owner.NumField(); i++ {
+		if name := owner.Field(i).Name; strings.HasSuffix(path, name) && len(name) > len(segment) {
+			segment = name
+		}
+	}
+	if segment == "" {
+		return path
+	}
+	return segment
+}
+
+// Find the declaration of a named type, parsing its package if needed
+func (l *Locator) typeSpec(typ reflect.Type) (*ast.TypeSpec, error) {
+	typ = typeutil.Elem(typ)
+	name := typ.Name()
+	if name == "" {
+		return nil, fmt.Errorf("tagosource: %s has no name", typ)
+	}
+	// Generic instantiations are declared without their type arguments: Page[models.User] -> Page
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	types, parsed := l.types[typ.PkgPath()]
+	if !parsed {
+		var err error
+		if types, err = l.parsePackage(typ.PkgPath()); err != nil {
+			return nil, err
+		}
+		l.types[typ.PkgPath()] = types
+	}
+
+	spec, exists := types[name]
+	if !exists {
+		return nil, fmt.Errorf("tagosource: no declaration of %s in %s", name, typ.PkgPath())
+	}
+	return spec, nil
+}
+
+// Parse the package level type declarations of the (non test) files of a package matching the build constraints
+func (l *Locator) parsePackage(pkgPath string) (map[string]*ast.TypeSpec, error) {
+	dir, exists := l.dirs[pkgPath]
+	if !exists {
+		pkg, err := build.Import(pkgPath, "", build.FindOnly)
+		if err != nil {
+			return nil, fmt.Errorf("tagosource: package %s: %w", pkgPath, err)
+		}
+		dir = pkg.Dir
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("tagosource: package %s: %w", pkgPath, err)
+	}
+
+	types := make(map[string]*ast.TypeSpec)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		// File name suffixes (_linux.go) and //go:build lines
+		if match, err := build.Default.MatchFile(dir, entry.Name()); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(l.fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("tagosource: %w", err)
+		}
+
+		for _, spec := range astutil.TypeSpecs(file) {
+			types[spec.Name.Name] = spec
+		}
+	}
+	return types, nil
+}
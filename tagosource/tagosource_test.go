@@ -0,0 +1,73 @@
+package tagosource
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+type locatedUser struct {
+	Name    string
+	Address locatedAddress
+}
+
+type locatedAddress struct {
+	City     string
+	Zip_Code string
+}
+
+func newTestLocator(t *testing.T) *Locator {
+	t.Helper()
+	return New().AddDir(reflect.TypeOf(locatedUser{}).PkgPath(), filepath.Join("testdata", "models"))
+}
+
+func TestField(t *testing.T) {
+	locator := newTestLocator(t)
+
+	tests := []struct {
+		typ   reflect.Type
+		field string
+		line  int
+	}{
+		// Not the local type declared in init, nor the one of the file excluded by its build constraint
+		{reflect.TypeOf(locatedUser{}), "Address", 5},
+		{reflect.TypeOf(&locatedAddress{}), "City", 9},
+		{reflect.TypeOf(locatedAddress{}), "Zip_Code", 10},
+	}
+	for _, test := range tests {
+		position, err := locator.Field(test.typ, test.field)
+		if err != nil {
+			t.Errorf("Field(%s, %s): %v", test.typ, test.field, err)
+			continue
+		}
+		if filepath.Base(position.Filename) != "user.go" || position.Line != test.line {
+			t.Errorf("Field(%s, %s) = %s, want user.go:%d", test.typ, test.field, position, test.line)
+		}
+	}
+
+	if _, err := locator.Field(reflect.TypeOf(locatedAddress{}), "Street"); err == nil {
+		t.Error("Field(Street): expected an error, the field is declared in an excluded file")
+	}
+}
+
+func TestWarning(t *testing.T) {
+	tests := []struct {
+		separator string
+		path      tago.FieldName
+	}{
+		{".", "Address.Zip_Code"},
+		{"/", "Address/Zip_Code"},
+		{"__", "Address__Zip_Code"},
+		{"", "AddressZip_Code"},
+	}
+	for _, test := range tests {
+		locator := newTestLocator(t).SetSeparator(test.separator)
+		w := tago.Warning{Type: reflect.TypeOf(locatedAddress{}), Field: test.path, Message: "invalid"}
+		if got := locator.Warning(w); !strings.Contains(got, "user.go:10:") {
+			t.Errorf("Warning(%q) with separator %q = %s, want the position of Zip_Code", test.path, test.separator, got)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package tagosource
+
+type locatedUser struct {
+	Name    string
+	Address locatedAddress
+}
+
+type locatedAddress struct {
+	City     string
+	Zip_Code string
+}
+
+func init() {
+	// A local type of the same name doesn't shadow the package level one
+	type locatedUser struct {
+		Other int
+	}
+	_ = locatedUser{}
+}
@@ -0,0 +1,9 @@
+//go:build ignore
+
+package tagosource
+
+type locatedAddress struct {
+	Street   string
+	City     string
+	Zip_Code string
+}
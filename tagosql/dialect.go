@@ -0,0 +1,188 @@
+package tagosql
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Dialect writes the database specific parts of the DDL
+type Dialect interface {
+	// Name of the dialect, used in error messages
+	Name() string
+
+	// Quote an identifier (table, column or index name)
+	Quote(identifier string) string
+
+	// SQL type of a Go type (pointers already removed), empty if there is none
+	ColumnType(typ reflect.Type) string
+
+	// Column definition inside CREATE TABLE
+	// inlinePrimaryKey is true if the column is the only primary key column
+	Column(column Column, inlinePrimaryKey bool) string
+}
+
+var (
+	Postgres Dialect = postgres{}
+	MySQL    Dialect = mysql{}
+	SQLite   Dialect = sqlite{}
+)
+
+// Write the column constraints shared by every dialect
+func writeConstraints(b *strings.Builder, column Column, inlinePrimaryKey bool) {
+	if column.NotNull && !(column.PrimaryKey && inlinePrimaryKey) {
+		b.WriteString(" NOT NULL")
+	}
+	if column.Unique {
+		b.WriteString(" UNIQUE")
+	}
+	if column.HasDefault {
+		b.WriteString(" DEFAULT ")
+		b.WriteString(column.Default)
+	}
+}
+
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+func (postgres) Quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func (postgres) ColumnType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint"
+	case reflect.Int32, reflect.Uint16:
+		return "integer"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double precision"
+	case reflect.String:
+		return "text"
+	case reflect.Slice, reflect.Array:
+		return "bytea"
+	case reflect.Struct:
+		return "timestamp with time zone"
+	}
+	return ""
+}
+
+func (d postgres) Column(column Column, inlinePrimaryKey bool) string {
+	var b strings.Builder
+	b.WriteString(d.Quote(column.Name))
+	b.WriteByte(' ')
+
+	// Auto incremented integers are identity columns
+	b.WriteString(column.Type)
+	if column.AutoIncrement {
+		b.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+	}
+	if column.PrimaryKey && inlinePrimaryKey {
+		b.WriteString(" PRIMARY KEY")
+	}
+	writeConstraints(&b, column, inlinePrimaryKey)
+	return b.String()
+}
+
+type mysql struct{}
+
+func (mysql) Name() string { return "mysql" }
+
+func (mysql) Quote(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+func (mysql) ColumnType(typ reflect.Type) string {
+	unsigned := ""
+	switch typ.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		unsigned = " unsigned"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Uint8:
+		return "tinyint" + unsigned
+	case reflect.Int16, reflect.Uint16:
+		return "smallint" + unsigned
+	case reflect.Int32, reflect.Uint32:
+		return "int" + unsigned
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return "bigint" + unsigned
+	case reflect.Float32:
+		return "float"
+	case reflect.Float64:
+		return "double"
+	case reflect.String:
+		return "longtext"
+	case reflect.Slice, reflect.Array:
+		return "longblob"
+	case reflect.Struct:
+		return "datetime(3)"
+	}
+	return ""
+}
+
+func (d mysql) Column(column Column, inlinePrimaryKey bool) string {
+	var b strings.Builder
+	b.WriteString(d.Quote(column.Name))
+	b.WriteByte(' ')
+	b.WriteString(column.Type)
+	if column.PrimaryKey && inlinePrimaryKey {
+		b.WriteString(" PRIMARY KEY")
+	}
+	if column.AutoIncrement {
+		b.WriteString(" AUTO_INCREMENT")
+	}
+	writeConstraints(&b, column, inlinePrimaryKey)
+	return b.String()
+}
+
+type sqlite struct{}
+
+func (sqlite) Name() string { return "sqlite" }
+
+func (sqlite) Quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func (sqlite) ColumnType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "real"
+	case reflect.String:
+		return "text"
+	case reflect.Slice, reflect.Array:
+		return "blob"
+	case reflect.Struct:
+		return "datetime"
+	}
+	return ""
+}
+
+func (d sqlite) Column(column Column, inlinePrimaryKey bool) string {
+	var b strings.Builder
+	b.WriteString(d.Quote(column.Name))
+	b.WriteByte(' ')
+	b.WriteString(column.Type)
+	if column.PrimaryKey && inlinePrimaryKey {
+		b.WriteString(" PRIMARY KEY")
+		// SQLite only supports AUTOINCREMENT on an INTEGER PRIMARY KEY
+		if column.AutoIncrement {
+			b.WriteString(" AUTOINCREMENT")
+		}
+	}
+	writeConstraints(&b, column, inlinePrimaryKey)
+	return b.String()
+}
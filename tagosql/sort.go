@@ -0,0 +1,133 @@
+package tagosql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/naming"
+)
+
+// SortKeys returns the sort keys accepted for a model and their column: the fields tagged sortable=true,
+// under their sortKey= instruction (default: their column name). Embedded structs are flattened like in Describe.
+//
+//	type User struct {
+//		CreatedAt time.Time `sql:"sortable=true;sortKey=created"`
+//		Name      string    `sql:"sortable=true;column=full_name"`
+//	}
+//	tagosql.SortKeys(t, &User{}) // map[created:created_at full_name:full_name]
+func SortKeys(t tago.TaGo, model any) (map[string]string, error) {
+	if model == nil {
+		return nil, errors.New("tagosql: nil model")
+	}
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagosql: model %s is not a struct", typ)
+	}
+
+	keys := make(map[string]string)
+	sortKeys(t, typ, keys, make(map[reflect.Type]bool))
+	return keys, nil
+}
+
+// visiting holds the struct types being flattened, to stop on embedded cycles
+func sortKeys(t tago.TaGo, typ reflect.Type, keys map[string]string, visiting map[reflect.Type]bool) {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := t.GetFromField(field)
+
+		name, hasName := tags.Lookup("column")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && !hasName {
+			if !visiting[fieldType] {
+				sortKeys(t, fieldType, keys, visiting)
+			}
+			continue
+		}
+		if !field.IsExported() || !isTrue(tags, "sortable") {
+			continue
+		}
+
+		if !hasName {
+			name = naming.SnakeCase(field.Name)
+		}
+		key, hasKey := tags.Lookup("sortKey")
+		if !hasKey {
+			key = name
+		}
+		keys[key] = name
+	}
+}
+
+// SortColumn validates a user-supplied sort key against the sortable fields of a model (see SortKeys)
+// and returns its column, so user input never reaches the query.
+func SortColumn(t tago.TaGo, model any, key string) (string, error) {
+	keys, err := SortKeys(t, model)
+	if err != nil {
+		return "", err
+	}
+	column, exists := keys[key]
+	if !exists {
+		return "", fmt.Errorf("tagosql: invalid sort key %q, expected one of %s", key, strings.Join(sortedKeys(keys), ", "))
+	}
+	return column, nil
+}
+
+// OrderBy returns the ORDER BY expression (without the keywords) of a user-supplied sort,
+// a comma separated list of sort keys prefixed with "-" for a descending order: "-created,name".
+// Keys are validated with SortColumn and columns quoted for the dialect. It returns an empty string for an empty sort.
+//
+//	tagosql.OrderBy(t, &User{}, "-created,full_name", tagosql.Postgres) // "created_at" DESC, "full_name" ASC
+func OrderBy(t tago.TaGo, model any, sortBy string, dialect Dialect) (string, error) {
+	keys, err := SortKeys(t, model)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, key := range strings.Split(sortBy, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		direction := "ASC"
+		if strings.HasPrefix(key, "-") {
+			key, direction = key[1:], "DESC"
+		} else {
+			key = strings.TrimPrefix(key, "+")
+		}
+
+		column, exists := keys[key]
+		if !exists {
+			return "", fmt.Errorf("tagosql: invalid sort key %q, expected one of %s", key, strings.Join(sortedKeys(keys), ", "))
+		}
+		parts = append(parts, dialect.Quote(column)+" "+direction)
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+func sortedKeys(keys map[string]string) []string {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
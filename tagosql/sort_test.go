@@ -0,0 +1,117 @@
+package tagosql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type sortAudit struct {
+	UpdatedAt time.Time `sql:"sortable=true;sortKey=updated"`
+}
+
+type sortUser struct {
+	sortAudit
+	CreatedAt time.Time `sql:"sortable=true;sortKey=created"`
+	Name      string    `sql:"sortable=true;column=full_name"`
+	Email     string
+	Password  string `sql:"sortable=true;column=-"`
+}
+
+type sortNode struct {
+	*sortNode
+	Rank int `sql:"sortable=true"`
+}
+
+func TestSortKeys(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string]string
+	}{
+		{"model", &sortUser{}, map[string]string{"updated": "updated_at", "created": "created_at", "full_name": "full_name"}},
+		{"embedded cycle", &sortNode{}, map[string]string{"rank": "rank"}},
+	}
+	for _, test := range tests {
+		got, err := SortKeys(tg, test.model)
+		if err != nil {
+			t.Fatalf("SortKeys(%s): %v", test.name, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("SortKeys(%s) = %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	if _, err := SortKeys(tg, nil); err == nil {
+		t.Error("SortKeys(nil): expected an error")
+	}
+	if _, err := SortKeys(tg, 1); err == nil {
+		t.Error("SortKeys(1): expected an error")
+	}
+}
+
+func TestSortColumn(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		key  string
+		want string
+		err  string
+	}{
+		{key: "created", want: "created_at"},
+		{key: "full_name", want: "full_name"},
+		{key: "updated", want: "updated_at"},
+		{key: "email", err: `tagosql: invalid sort key "email", expected one of created, full_name, updated`},
+		{key: "Name", err: `tagosql: invalid sort key "Name", expected one of created, full_name, updated`},
+		{key: "password", err: `tagosql: invalid sort key "password", expected one of created, full_name, updated`},
+		{key: "created_at; DROP TABLE users", err: `tagosql: invalid sort key "created_at; DROP TABLE users", expected one of created, full_name, updated`},
+	}
+	for _, test := range tests {
+		got, err := SortColumn(tg, &sortUser{}, test.key)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("SortColumn(%q) = %q, %v, want the error %q", test.key, got, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("SortColumn(%q) = %q, %v, want %q", test.key, got, err, test.want)
+		}
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		sort    string
+		dialect Dialect
+		want    string
+		err     string
+	}{
+		{sort: "", dialect: Postgres, want: ""},
+		{sort: " , ", dialect: Postgres, want: ""},
+		{sort: "created", dialect: Postgres, want: `"created_at" ASC`},
+		{sort: "-created,full_name", dialect: Postgres, want: `"created_at" DESC, "full_name" ASC`},
+		{sort: "+updated, -full_name", dialect: MySQL, want: "`updated_at` ASC, `full_name` DESC"},
+		{sort: "created,email", dialect: Postgres, err: `tagosql: invalid sort key "email", expected one of created, full_name, updated`},
+		{sort: "--created", dialect: Postgres, err: `tagosql: invalid sort key "-created", expected one of created, full_name, updated`},
+		{sort: "created DESC", dialect: Postgres, err: `tagosql: invalid sort key "created DESC", expected one of created, full_name, updated`},
+	}
+	for _, test := range tests {
+		got, err := OrderBy(tg, &sortUser{}, test.sort, test.dialect)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("OrderBy(%q) = %q, %v, want the error %q", test.sort, got, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("OrderBy(%q) = %q, %v, want %q", test.sort, got, err, test.want)
+		}
+	}
+}
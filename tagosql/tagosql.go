@@ -0,0 +1,293 @@
+// Package tagosql generates dialect-aware SQL DDL (CREATE TABLE / CREATE INDEX) from tagged models.
+//
+// The following instructions are supported on fields:
+//
+//	column=name         name of the column (default: the Go field name in snake_case)
+//	column=-            skip the field
+//	type=varchar(255)   SQL type of the column (default: derived from the Go type by the dialect)
+//	primaryKey=true     part of the primary key (composite keys are supported)
+//	autoIncrement=true  auto incremented column
+//	notNull=true        NOT NULL column (implied by primaryKey)
+//	unique=true         UNIQUE column
+//	default=value       DEFAULT value, written as is
+//	index=name          add the column to the index "name" (default name: idx_<table>_<column>)
+//	index=name,priority:2  position of the column in a composite index (default: declaration order)
+//	uniqueIndex=name    same as index, for a unique index
+//
+// Embedded structs are flattened into the table. Other struct fields (relations) and slices are skipped,
+// except time.Time and []byte which are mapped to columns.
+//
+// Usage:
+//
+//	type User struct {
+//		ID    uint64 `sql:"primaryKey;autoIncrement"`
+//		Email string `sql:"type=varchar(255);unique;notNull"`
+//		Name  string `sql:"index=idx_user_name"`
+//	}
+//	ddl, err := tagosql.CreateTable(tago.TaGo{Name: "sql"}, &User{}, tagosql.Postgres)
+//
+// Where builds the WHERE conditions of filter structs from op= instructions, OrderBy validates user-supplied sorts
+// against the fields tagged sortable=true, see their documentation.
+package tagosql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/naming"
+)
+
+// Tabler can be implemented by models to provide their table name (default: the type name in snake_case)
+type Tabler interface {
+	TableName() string
+}
+
+// Column of a table, as derived from a field and its instructions
+type Column struct {
+	Field         string
+	Name          string
+	Type          string
+	PrimaryKey    bool
+	AutoIncrement bool
+	NotNull       bool
+	Unique        bool
+	Default       string
+	HasDefault    bool
+}
+
+// Index of a table, named, with its columns in priority then declaration order
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+
+	// Priority of each column, to sort them once the table is described
+	priorities []int
+}
+
+// Table of a model, see Describe
+type Table struct {
+	Name    string
+	Columns []Column
+	Indexes []Index
+}
+
+// Describe returns the table structure of a model, without generating any SQL
+// The dialect is used to derive the column types that aren't given with type=
+func Describe(t tago.TaGo, model any, dialect Dialect) (Table, error) {
+	if model == nil {
+		return Table{}, errors.New("tagosql: nil model")
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return Table{}, fmt.Errorf("tagosql: model %s is not a struct", modelType)
+	}
+
+	table := Table{Name: tableName(modelType)}
+	indexes := make(map[string]int)
+	if err := describeFields(t, modelType, dialect, &table, indexes, make(map[reflect.Type]bool)); err != nil {
+		return Table{}, err
+	}
+	if len(table.Columns) == 0 {
+		return Table{}, fmt.Errorf("tagosql: model %s has no column", modelType)
+	}
+
+	// Sort the columns of composite indexes by priority
+	for i := range table.Indexes {
+		index := &table.Indexes[i]
+		order := make([]int, len(index.Columns))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool { return index.priorities[order[a]] < index.priorities[order[b]] })
+
+		columns := make([]string, len(order))
+		for j, k := range order {
+			columns[j] = index.Columns[k]
+		}
+		index.Columns = columns
+		index.priorities = nil
+	}
+	return table, nil
+}
+
+// Priority of a column in an index, from index=name,priority:N (default: 10, like gorm)
+func indexPriority(instruction tago.Instruction) int {
+	if priority, err := strconv.Atoi(instruction.Options()["priority"]); err == nil {
+		return priority
+	}
+	return 10
+}
+
+// Add the columns and indexes of a struct type to the table
+// visiting holds the struct types being flattened, to stop on embedded cycles
+func describeFields(t tago.TaGo, typ reflect.Type, dialect Dialect, table *Table, indexes map[string]int, visiting map[reflect.Type]bool) error {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := t.GetFromField(field)
+
+		name, hasName := tags.Lookup("column")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		// Embedded structs are flattened into the table, once per branch like encoding/json does
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && !hasName {
+			if visiting[fieldType] {
+				continue
+			}
+			if err := describeFields(t, fieldType, dialect, table, indexes, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		sqlType, hasType := tags.Lookup("type")
+		if !hasType {
+			// Relations and collections aren't columns
+			if !isColumnType(fieldType) {
+				continue
+			}
+			sqlType = dialect.ColumnType(fieldType)
+			if sqlType == "" {
+				return fmt.Errorf("tagosql: no %s type for field %s.%s of type %s, set one with type=", dialect.Name(), typ, field.Name, field.Type)
+			}
+		}
+
+		if !hasName {
+			name = naming.SnakeCase(field.Name)
+		}
+
+		column := Column{
+			Field:         field.Name,
+			Name:          name,
+			Type:          sqlType,
+			PrimaryKey:    isTrue(tags, "primaryKey"),
+			AutoIncrement: isTrue(tags, "autoIncrement"),
+			NotNull:       isTrue(tags, "notNull"),
+			Unique:        isTrue(tags, "unique"),
+		}
+		column.Default, column.HasDefault = tags.Lookup("default")
+		if column.PrimaryKey {
+			column.NotNull = true
+		}
+		table.Columns = append(table.Columns, column)
+
+		// Add the column to its indexes
+		for _, instruction := range tags.Keys() {
+			if instruction.Key() != "index" && instruction.Key() != "uniqueIndex" {
+				continue
+			}
+			indexName := instruction.BaseValue()
+			if indexName == "true" {
+				indexName = "idx_" + table.Name + "_" + name
+			}
+			position, exists := indexes[indexName]
+			if !exists {
+				position = len(table.Indexes)
+				indexes[indexName] = position
+				table.Indexes = append(table.Indexes, Index{Name: indexName})
+			}
+			table.Indexes[position].Columns = append(table.Indexes[position].Columns, name)
+			table.Indexes[position].priorities = append(table.Indexes[position].priorities, indexPriority(instruction))
+			table.Indexes[position].Unique = table.Indexes[position].Unique || instruction.Key() == "uniqueIndex"
+		}
+	}
+	return nil
+}
+
+// CreateTable returns the CREATE TABLE statement of a model followed by its CREATE INDEX statements
+func CreateTable(t tago.TaGo, model any, dialect Dialect) (string, error) {
+	table, err := Describe(t, model, dialect)
+	if err != nil {
+		return "", err
+	}
+	return table.SQL(dialect), nil
+}
+
+// SQL returns the CREATE TABLE statement of the table followed by its CREATE INDEX statements
+func (table Table) SQL(dialect Dialect) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", dialect.Quote(table.Name))
+
+	primaryKeys := make([]string, 0)
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, dialect.Quote(column.Name))
+		}
+	}
+
+	for i, column := range table.Columns {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		b.WriteString("  ")
+		b.WriteString(dialect.Column(column, len(primaryKeys) == 1))
+	}
+	if len(primaryKeys) > 1 {
+		fmt.Fprintf(&b, ",\n  PRIMARY KEY (%s)", strings.Join(primaryKeys, ", "))
+	}
+	b.WriteString("\n);\n")
+
+	for _, index := range table.Indexes {
+		columns := make([]string, len(index.Columns))
+		for i, column := range index.Columns {
+			columns[i] = dialect.Quote(column)
+		}
+		unique := ""
+		if index.Unique {
+			unique = "UNIQUE "
+		}
+		fmt.Fprintf(&b, "CREATE %sINDEX %s ON %s (%s);\n", unique, dialect.Quote(index.Name), dialect.Quote(table.Name), strings.Join(columns, ", "))
+	}
+	return b.String()
+}
+
+func isTrue(tags tago.Instructions, key string) bool {
+	value, _ := tags.Lookup(key)
+	return value == "true"
+}
+
+// Check whether a field of this type is a column by default (and not a relation or collection)
+func isColumnType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return typ.PkgPath() == "time" && typ.Name() == "Time"
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() == reflect.Uint8
+	case reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
+
+func tableName(typ reflect.Type) string {
+	if tabler, ok := reflect.New(typ).Interface().(Tabler); ok {
+		return tabler.TableName()
+	}
+	if tabler, ok := reflect.New(typ).Elem().Interface().(Tabler); ok {
+		return tabler.TableName()
+	}
+	return naming.SnakeCase(typ.Name())
+}
@@ -0,0 +1,145 @@
+package tagosql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type sqlBase struct {
+	ID        uint64    `sql:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `sql:"index=true"`
+}
+
+type sqlUser struct {
+	sqlBase
+	Email   string `sql:"type=varchar(255);unique"`
+	First   string `sql:"index=idx_name,priority:2"`
+	Last    string `sql:"index=idx_name,priority:1"`
+	Role    string `sql:"default='user';notNull"`
+	Ref     *int64 `sql:"column=ref_id;uniqueIndex=uq_ref"`
+	Skipped string `sql:"column=-"`
+	Posts   []string
+}
+
+type sqlNode struct {
+	*sqlNode
+	X int
+}
+
+type sqlLeft struct {
+	*sqlRight
+	L string
+}
+
+type sqlRight struct {
+	*sqlLeft
+	R string
+}
+
+func TestCreateTable(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, `CREATE TABLE "sql_user" (
+  "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "created_at" timestamp with time zone,
+  "email" varchar(255) UNIQUE,
+  "first" text,
+  "last" text,
+  "role" text NOT NULL DEFAULT 'user',
+  "ref_id" bigint
+);
+CREATE INDEX "idx_sql_user_created_at" ON "sql_user" ("created_at");
+CREATE INDEX "idx_name" ON "sql_user" ("last", "first");
+CREATE UNIQUE INDEX "uq_ref" ON "sql_user" ("ref_id");
+`},
+		{MySQL, "CREATE TABLE `sql_user` (\n" +
+			"  `id` bigint unsigned PRIMARY KEY AUTO_INCREMENT,\n" +
+			"  `created_at` datetime(3),\n" +
+			"  `email` varchar(255) UNIQUE,\n" +
+			"  `first` longtext,\n" +
+			"  `last` longtext,\n" +
+			"  `role` longtext NOT NULL DEFAULT 'user',\n" +
+			"  `ref_id` bigint\n" +
+			");\n" +
+			"CREATE INDEX `idx_sql_user_created_at` ON `sql_user` (`created_at`);\n" +
+			"CREATE INDEX `idx_name` ON `sql_user` (`last`, `first`);\n" +
+			"CREATE UNIQUE INDEX `uq_ref` ON `sql_user` (`ref_id`);\n"},
+		{SQLite, `CREATE TABLE "sql_user" (
+  "id" integer PRIMARY KEY AUTOINCREMENT,
+  "created_at" datetime,
+  "email" varchar(255) UNIQUE,
+  "first" text,
+  "last" text,
+  "role" text NOT NULL DEFAULT 'user',
+  "ref_id" integer
+);
+CREATE INDEX "idx_sql_user_created_at" ON "sql_user" ("created_at");
+CREATE INDEX "idx_name" ON "sql_user" ("last", "first");
+CREATE UNIQUE INDEX "uq_ref" ON "sql_user" ("ref_id");
+`},
+	}
+	for _, test := range tests {
+		t.Run(test.dialect.Name(), func(t *testing.T) {
+			got, err := CreateTable(tg, &sqlUser{}, test.dialect)
+			if err != nil {
+				t.Fatalf("CreateTable: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("CreateTable =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDescribeEmbeddedCycle(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	// Embedded cycles are flattened once, like encoding/json does
+	tests := []struct {
+		model any
+		want  []string
+	}{
+		{&sqlNode{}, []string{"x"}},
+		{&sqlLeft{}, []string{"r", "l"}},
+	}
+	for _, test := range tests {
+		table, err := Describe(tg, test.model, Postgres)
+		if err != nil {
+			t.Fatalf("Describe(%T): %v", test.model, err)
+		}
+		var columns []string
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+		}
+		if !reflect.DeepEqual(columns, test.want) {
+			t.Errorf("Describe(%T) columns = %v, want %v", test.model, columns, test.want)
+		}
+	}
+}
+
+func TestDescribeErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		name  string
+		model any
+		err   string
+	}{
+		{"nil", nil, "nil model"},
+		{"not a struct", 1, "not a struct"},
+		{"no column", &struct{ Tags []string }{}, "has no column"},
+	}
+	for _, test := range tests {
+		if _, err := Describe(tg, test.model, Postgres); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Describe(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+}
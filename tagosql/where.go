@@ -0,0 +1,208 @@
+package tagosql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/naming"
+)
+
+// Condition is a WHERE condition with "?" placeholders and their arguments,
+// usable as is with database/sql drivers using "?" and with gorm: db.Where(condition.SQL, condition.Args...)
+type Condition struct {
+	SQL  string
+	Args []any
+}
+
+// Where returns the WHERE conditions of a filter struct, in field order. Fields are matched against their column with:
+//
+//	column=name      name of the column (default: the Go field name in snake_case), column=- skips the field
+//	op=gte           operator: eq (default), ne, gt, gte, lt, lte, like, in, notIn, isNull
+//	allowZero=true   filter on the zero value too (zero values are skipped by default)
+//
+// Nil pointers are skipped, non-nil pointers always filter (even on a zero value). in and notIn expect a slice,
+// isNull a bool (true: IS NULL, false: IS NOT NULL). Nested structs are flattened, once when pointers cycle.
+//
+// Usage:
+//
+//	type UserFilter struct {
+//		Since  time.Time `sql:"column=created_at;op=gte"`
+//		Roles  []string  `sql:"column=role;op=in"`
+//		Banned *bool
+//	}
+//	conditions, err := tagosql.Where(tago.TaGo{Name: "sql"}, filter, tagosql.Postgres)
+//	query, args := tagosql.And(conditions, tagosql.Postgres) // "created_at" >= $1 AND "role" IN ($2, $3)
+func Where(t tago.TaGo, filter any, dialect Dialect) ([]Condition, error) {
+	if filter == nil {
+		return nil, errors.New("tagosql: nil filter")
+	}
+	value := reflect.ValueOf(filter)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, errors.New("tagosql: nil filter")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagosql: filter %s is not a struct", value.Type())
+	}
+
+	conditions := make([]Condition, 0)
+	if err := whereFields(t, value, dialect, &conditions, make(map[filterPointer]bool)); err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
+
+// Pointer to a filter group, the type tells a struct apart from its first field
+type filterPointer struct {
+	address uintptr
+	typ     reflect.Type
+}
+
+// visiting holds the filter groups being flattened, to flatten them once when pointers cycle (filter.Group = &filter)
+func whereFields(t tago.TaGo, value reflect.Value, dialect Dialect, conditions *[]Condition, visiting map[filterPointer]bool) error {
+	typ := value.Type()
+	if value.CanAddr() {
+		pointer := filterPointer{value.UnsafeAddr(), typ}
+		if visiting[pointer] {
+			return nil
+		}
+		visiting[pointer] = true
+		defer delete(visiting, pointer)
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tags := t.GetFromField(field)
+
+		name, hasName := tags.Lookup("column")
+		if name == "-" {
+			continue
+		}
+		if !hasName {
+			name = naming.SnakeCase(field.Name)
+		}
+
+		// Nil pointers don't filter, non-nil ones always do
+		fieldValue := value.Field(i)
+		explicit := false
+		for fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				break
+			}
+			fieldValue, explicit = fieldValue.Elem(), true
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			continue
+		}
+
+		// Filter groups are flattened
+		if fieldValue.Kind() == reflect.Struct && !isColumnType(fieldValue.Type()) {
+			if err := whereFields(t, fieldValue, dialect, conditions, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fieldValue.IsZero() && !explicit && !isTrue(tags, "allowZero") {
+			continue
+		}
+
+		op, _ := tags.Lookup("op")
+		condition, err := where(dialect.Quote(name), op, fieldValue)
+		if err != nil {
+			return fmt.Errorf("tagosql: field %s.%s: %w", typ, field.Name, err)
+		}
+		*conditions = append(*conditions, condition)
+	}
+	return nil
+}
+
+var operators = map[string]string{
+	"":     "=",
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+// Build the condition of a column for an operator and a value
+func where(column string, op string, value reflect.Value) (Condition, error) {
+	if operator, exists := operators[op]; exists {
+		return Condition{SQL: column + " " + operator + " ?", Args: []any{value.Interface()}}, nil
+	}
+
+	switch op {
+	case "in", "notIn":
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return Condition{}, fmt.Errorf("op=%s expects a slice, got %s", op, value.Type())
+		}
+		// An empty list matches nothing (in) or everything (notIn), IN () isn't valid SQL
+		if value.Len() == 0 {
+			if op == "in" {
+				return Condition{SQL: "1 = 0"}, nil
+			}
+			return Condition{SQL: "1 = 1"}, nil
+		}
+
+		placeholders := make([]string, value.Len())
+		args := make([]any, value.Len())
+		for i := range placeholders {
+			placeholders[i] = "?"
+			args[i] = value.Index(i).Interface()
+		}
+		operator := "IN"
+		if op == "notIn" {
+			operator = "NOT IN"
+		}
+		return Condition{SQL: column + " " + operator + " (" + strings.Join(placeholders, ", ") + ")", Args: args}, nil
+
+	case "isNull":
+		if value.Kind() != reflect.Bool {
+			return Condition{}, fmt.Errorf("op=isNull expects a bool, got %s", value.Type())
+		}
+		if value.Bool() {
+			return Condition{SQL: column + " IS NULL"}, nil
+		}
+		return Condition{SQL: column + " IS NOT NULL"}, nil
+	}
+	return Condition{}, fmt.Errorf("unknown operator %q", op)
+}
+
+// And joins conditions with AND, numbering the placeholders for the dialects which need it ($1, $2, .. for Postgres).
+// It returns an empty query for no condition.
+func And(conditions []Condition, dialect Dialect) (string, []any) {
+	parts := make([]string, len(conditions))
+	args := make([]any, 0)
+	for i, condition := range conditions {
+		parts[i] = condition.SQL
+		args = append(args, condition.Args...)
+	}
+	query := strings.Join(parts, " AND ")
+
+	if dialect.Name() != Postgres.Name() {
+		return query, args
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), args
+}
@@ -0,0 +1,183 @@
+package tagosql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type whereNode struct {
+	Name string
+	Node *whereNode
+}
+
+type whereUser struct {
+	Since  time.Time `sql:"column=created_at;op=gte"`
+	Roles  []string  `sql:"column=role;op=in"`
+	Banned *bool
+	Name   string `sql:"op=like"`
+}
+
+func TestWhereOperators(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+	zero, one := 0, 1
+
+	tests := []struct {
+		name   string
+		filter any
+		want   []Condition
+	}{
+		{"eq", &struct{ Age int }{1}, []Condition{{`"age" = ?`, []any{1}}}},
+		{"explicit eq", &struct {
+			Age int `sql:"op=eq"`
+		}{1}, []Condition{{`"age" = ?`, []any{1}}}},
+		{"ne", &struct {
+			Age int `sql:"op=ne"`
+		}{1}, []Condition{{`"age" <> ?`, []any{1}}}},
+		{"gt", &struct {
+			Age int `sql:"op=gt"`
+		}{1}, []Condition{{`"age" > ?`, []any{1}}}},
+		{"gte", &struct {
+			Age int `sql:"op=gte"`
+		}{1}, []Condition{{`"age" >= ?`, []any{1}}}},
+		{"lt", &struct {
+			Age int `sql:"op=lt"`
+		}{1}, []Condition{{`"age" < ?`, []any{1}}}},
+		{"lte", &struct {
+			Age int `sql:"op=lte"`
+		}{1}, []Condition{{`"age" <= ?`, []any{1}}}},
+		{"like", &struct {
+			Name string `sql:"op=like"`
+		}{"a%"}, []Condition{{`"name" LIKE ?`, []any{"a%"}}}},
+		{"in", &struct {
+			Role []string `sql:"op=in"`
+		}{[]string{"a", "b"}}, []Condition{{`"role" IN (?, ?)`, []any{"a", "b"}}}},
+		{"notIn", &struct {
+			Role [2]string `sql:"op=notIn"`
+		}{[2]string{"a", "b"}}, []Condition{{`"role" NOT IN (?, ?)`, []any{"a", "b"}}}},
+		{"empty in", &struct {
+			Role []string `sql:"op=in;allowZero=true"`
+		}{[]string{}}, []Condition{{SQL: "1 = 0"}}},
+		{"empty notIn", &struct {
+			Role []string `sql:"op=notIn;allowZero=true"`
+		}{[]string{}}, []Condition{{SQL: "1 = 1"}}},
+		{"isNull", &struct {
+			DeletedAt bool `sql:"op=isNull"`
+		}{true}, []Condition{{SQL: `"deleted_at" IS NULL`}}},
+		{"is not null", &struct {
+			DeletedAt *bool `sql:"op=isNull"`
+		}{new(bool)}, []Condition{{SQL: `"deleted_at" IS NOT NULL`}}},
+		{"zero skipped", &struct{ Age int }{0}, []Condition{}},
+		{"nil pointer skipped", &struct{ Age *int }{nil}, []Condition{}},
+		{"explicit zero", &struct{ Age *int }{&zero}, []Condition{{`"age" = ?`, []any{0}}}},
+		{"allowZero", &struct {
+			Age int `sql:"allowZero=true"`
+		}{0}, []Condition{{`"age" = ?`, []any{0}}}},
+		{"column", &struct {
+			Age  *int `sql:"column=user_age"`
+			Skip int  `sql:"column=-"`
+		}{&one, 1}, []Condition{{`"user_age" = ?`, []any{1}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Where(tg, test.filter, Postgres)
+			if err != nil {
+				t.Fatalf("Where: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Where = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWhereErrors(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	tests := []struct {
+		name   string
+		filter any
+		err    string
+	}{
+		{"nil", nil, "nil filter"},
+		{"nil pointer", (*whereUser)(nil), "nil filter"},
+		{"not a struct", 1, "not a struct"},
+		{"in without a slice", &struct {
+			Role string `sql:"op=in"`
+		}{"a"}, "op=in expects a slice, got string"},
+		{"isNull without a bool", &struct {
+			DeletedAt int `sql:"op=isNull"`
+		}{1}, "op=isNull expects a bool, got int"},
+		{"unknown operator", &struct {
+			Age int `sql:"op=between"`
+		}{1}, `unknown operator "between"`},
+	}
+	for _, test := range tests {
+		if _, err := Where(tg, test.filter, Postgres); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Where(%s) = %v, want an error containing %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestWhereCycle(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+
+	// A self pointing filter is flattened once
+	node := &whereNode{Name: "a"}
+	node.Node = node
+	got, err := Where(tg, node, Postgres)
+	if err != nil {
+		t.Fatalf("Where: %v", err)
+	}
+	if want := []Condition{{`"name" = ?`, []any{"a"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Where = %#v, want %#v", got, want)
+	}
+
+	// Shared groups which don't cycle are flattened each time
+	shared := &whereNode{Name: "b"}
+	got, err = Where(tg, &struct{ Left, Right *whereNode }{shared, shared}, Postgres)
+	if err != nil {
+		t.Fatalf("Where: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Where = %#v, want 2 conditions", got)
+	}
+}
+
+func TestAnd(t *testing.T) {
+	tg := tago.TaGo{Name: "sql"}
+	banned := false
+	filter := whereUser{
+		Since:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Roles:  []string{"admin", "editor"},
+		Banned: &banned,
+		Name:   "a%",
+	}
+	wantArgs := []any{filter.Since, "admin", "editor", false, "a%"}
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, `"created_at" >= $1 AND "role" IN ($2, $3) AND "banned" = $4 AND "name" LIKE $5`},
+		{MySQL, "`created_at` >= ? AND `role` IN (?, ?) AND `banned` = ? AND `name` LIKE ?"},
+		{SQLite, `"created_at" >= ? AND "role" IN (?, ?) AND "banned" = ? AND "name" LIKE ?`},
+	}
+	for _, test := range tests {
+		conditions, err := Where(tg, &filter, test.dialect)
+		if err != nil {
+			t.Fatalf("Where(%s): %v", test.dialect.Name(), err)
+		}
+		query, args := And(conditions, test.dialect)
+		if query != test.want || !reflect.DeepEqual(args, wantArgs) {
+			t.Errorf("And(%s) = %q, %v, want %q, %v", test.dialect.Name(), query, args, test.want, wantArgs)
+		}
+	}
+
+	if query, args := And(nil, Postgres); query != "" || len(args) != 0 {
+		t.Errorf("And(nil) = %q, %v, want an empty query", query, args)
+	}
+}
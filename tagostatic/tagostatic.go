@@ -0,0 +1,283 @@
+// Package tagostatic extracts the instructions of tagged models from Go source files, without compiling nor importing them.
+// It powers analysis of codebases we can't compile against, and code generation tools.
+//
+// The result is the one tago.TaGo.GetNested returns for the same types: tags are parsed by the same TaGo (aliases,
+// variables, normalizers, ..) and nested structs declared in the same sources are traversed.
+// Types of other packages are unknown statically, their fields are leaves; so are type parameters.
+// Unlike GetNested, the extraction only sees the tags written in the sources: the external tags and overlays of the
+// TaGo (RegisterExternal, LoadOverlay) are keyed by reflect types it doesn't have, and the types ignored at runtime
+// (Ignore, IgnoreFunc, AddLeafTypes) are still traversed when they are declared in the sources.
+// Relations returns the graph of the types declared in a directory, see cmd/tago for a command line front end.
+//
+// Usage:
+//
+//	e := tagostatic.New(tago.TaGo{Name: "gorm2"})
+//	models, err := e.ParseDir("./models")
+//	fmt.Println(models["User"]) // map[preload=true:[Address] column=city:[Address.City]]
+package tagostatic
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/astutil"
+)
+
+// Extractor extracts the instructions of the struct types declared in source files
+type Extractor struct {
+	Tag tago.TaGo
+
+	// Separator between parent and nested field names
+	Separator string
+
+	// Called for every warning raised while parsing the tags, with the name of the type being extracted and the
+	// path of the field in the warning. It replaces the OnWarning callback of Tag, if any.
+	OnWarning func(typeName string, w tago.Warning)
+}
+
+// Relation between two struct types declared in the sources: a field of From whose type is To, *To, []To, ..
+type Relation struct {
+	From  string
+	To    string
+	Field string
+
+	// The field is a slice, array or map of To
+	Many bool
+}
+
+// New returns an Extractor reading the instructions of the given tag, with "." as separator
+func New(t tago.TaGo) *Extractor {
+	return &Extractor{Tag: t, Separator: "."}
+}
+
+// ParseDir returns the instructions of the struct types declared in the (non test) Go files of a directory, by type name
+func (e *Extractor) ParseDir(dir string) (map[string]tago.Instructions, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return e.extract(files), nil
+}
+
+// Relations returns the relations between the struct types declared in the (non test) Go files of a directory,
+// sorted by type then field. Fields tagged "-" aren't relations.
+func (e *Extractor) Relations(dir string) ([]Relation, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	structs, names := declaredStructs(files)
+	sort.Strings(names)
+	relations := make([]Relation, 0)
+	for _, name := range names {
+		for _, field := range structs[name].Fields.List {
+			to, many := relatedType(field.Type, false)
+			if _, declared := structs[to]; !declared {
+				continue
+			}
+
+			fieldNames := make([]string, 0, len(field.Names))
+			for _, fieldName := range field.Names {
+				fieldNames = append(fieldNames, fieldName.Name)
+			}
+			if len(field.Names) == 0 {
+				fieldNames = append(fieldNames, astutil.EmbeddedName(field.Type))
+			}
+			for _, fieldName := range fieldNames {
+				if fieldName == "_" {
+					continue
+				}
+				if _, skipped := e.Tag.GetFromField(reflect.StructField{Name: fieldName, Tag: fieldTag(field)})[tago.Skip]; skipped {
+					continue
+				}
+				relations = append(relations, Relation{From: name, To: to, Field: fieldName, Many: many})
+			}
+		}
+	}
+	return relations, nil
+}
+
+// Parse the (non test) Go files of a directory
+func parseDir(dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("tagostatic: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("tagostatic: %w", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// ParseFile returns the instructions of the struct types declared in a Go file, by type name.
+// src is the source (string, []byte or io.Reader) or nil to read the file, like go/parser.ParseFile.
+func (e *Extractor) ParseFile(filename string, src any) (map[string]tago.Instructions, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("tagostatic: %w", err)
+	}
+	return e.extract([]*ast.File{file}), nil
+}
+
+func (e *Extractor) extract(files []*ast.File) map[string]tago.Instructions {
+	structs, names := declaredStructs(files)
+
+	models := make(map[string]tago.Instructions)
+	for _, name := range names {
+		w := walker{extractor: e, structs: structs, visiting: map[*ast.StructType]bool{}, tags: make(tago.Instructions)}
+		w.tag = e.Tag
+		if e.OnWarning != nil {
+			w.tag.OnWarning(func(warning tago.Warning) {
+				warning.Field = tago.FieldName(w.prefix) + warning.Field
+				e.OnWarning(name, warning)
+			})
+		}
+		w.walk(structs[name], "")
+		models[name] = w.tags
+	}
+	return models
+}
+
+// Package level struct types declared in the files, by name, and their names in declaration order
+func declaredStructs(files []*ast.File) (map[string]*ast.StructType, []string) {
+	structs := make(map[string]*ast.StructType)
+	var names []string
+	for _, file := range files {
+		for _, spec := range astutil.TypeSpecs(file) {
+			if structType, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = structType
+				names = append(names, spec.Name.Name)
+			}
+		}
+	}
+	return structs, names
+}
+
+type walker struct {
+	extractor *Extractor
+	structs   map[string]*ast.StructType
+
+	// Parser of the tags, the Tag of the extractor with its OnWarning callback
+	tag tago.TaGo
+
+	// Prefix of the fields being walked, for the warnings
+	prefix string
+
+	// Struct types being traversed, to stop on recursive types
+	visiting map[*ast.StructType]bool
+
+	tags tago.Instructions
+}
+
+func (w *walker) walk(structType *ast.StructType, prefix string) {
+	w.visiting[structType] = true
+	defer delete(w.visiting, structType)
+
+	for _, field := range structType.Fields.List {
+		tag := fieldTag(field)
+
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(field.Names) == 0 {
+			names = append(names, astutil.EmbeddedName(field.Type))
+		}
+
+		for _, name := range names {
+			if name == "" || name == "_" {
+				continue
+			}
+
+			// Parse the tag with the same TaGo as the reflection based API
+			w.prefix = prefix
+			fieldTags := w.tag.GetFromField(reflect.StructField{Name: name, Tag: tag})
+			for instruction, fields := range fieldTags {
+				for _, f := range fields {
+					w.tags[instruction] = append(w.tags[instruction], tago.FieldName(prefix)+f)
+				}
+			}
+			if _, skipped := fieldTags[tago.Skip]; skipped {
+				continue
+			}
+
+			if nested := w.resolve(field.Type); nested != nil && !w.visiting[nested] {
+				w.walk(nested, prefix+name+w.extractor.Separator)
+			}
+		}
+	}
+}
+
+// Find the struct type of a field type declared in the sources: T, *T, []T, [N]T, struct{..}
+func (w *walker) resolve(expr ast.Expr) *ast.StructType {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return w.structs[e.Name]
+	case *ast.StarExpr:
+		return w.resolve(e.X)
+	case *ast.ArrayType:
+		return w.resolve(e.Elt)
+	case *ast.ParenExpr:
+		return w.resolve(e.X)
+	case *ast.IndexExpr:
+		return w.resolve(e.X)
+	case *ast.IndexListExpr:
+		return w.resolve(e.X)
+	case *ast.StructType:
+		return e
+	}
+	return nil
+}
+
+// Tag of a field declaration, empty if it has none or it can't be unquoted
+func fieldTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag)
+}
+
+// Name of the named type of a field type expression (T, *T, []T, map[K]T, ..), and whether it is a collection
+func relatedType(expr ast.Expr, many bool) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name, many
+	case *ast.StarExpr:
+		return relatedType(e.X, many)
+	case *ast.ParenExpr:
+		return relatedType(e.X, many)
+	case *ast.ArrayType:
+		return relatedType(e.Elt, true)
+	case *ast.MapType:
+		return relatedType(e.Value, true)
+	case *ast.IndexExpr:
+		return relatedType(e.X, many)
+	case *ast.IndexListExpr:
+		return relatedType(e.X, many)
+	}
+	return "", many
+}
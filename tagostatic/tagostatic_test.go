@@ -0,0 +1,88 @@
+package tagostatic
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+const models = `package models
+
+import "time"
+
+type Base struct {
+	ID int ` + "`gorm2:\"primaryKey\"`" + `
+}
+
+type User struct {
+	*Base
+	Name    string     ` + "`gorm2:\"column=name\"`" + `
+	Address Address    ` + "`gorm2:\"preload=true\"`" + `
+	Orders  []Order
+	Secret  Address    ` + "`gorm2:\"-\"`" + `
+	Created time.Time  ` + "`gorm2:\"autoCreateTime\"`" + `
+}
+
+type Address struct {
+	City string ` + "`gorm2:\"column=city\"`" + `
+}
+
+type Order struct {
+	Total int ` + "`gorm2:\"column=total\"`" + `
+}
+
+func helper() {
+	// A local type of the same name doesn't shadow the package level one
+	type Address struct {
+		Street string ` + "`gorm2:\"column=street\"`" + `
+	}
+	_ = Address{}
+}
+`
+
+func TestParseFile(t *testing.T) {
+	e := New(tago.TaGo{Name: "gorm2"})
+	got, err := e.ParseFile("models.go", models)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	want := tago.Instructions{
+		"primaryKey":     {"Base.ID"},
+		"column=name":    {"Name"},
+		"preload=true":   {"Address"},
+		"column=city":    {"Address.City"},
+		"column=total":   {"Orders.Total"},
+		"-":              {"Secret"},
+		"autoCreateTime": {"Created"},
+	}
+	if !got["User"].Equal(want) {
+		t.Errorf("ParseFile(User) = %v, want %v", got["User"], want)
+	}
+	if len(got) != 4 {
+		t.Errorf("ParseFile: %d types, want 4 (Base, User, Address, Order)", len(got))
+	}
+}
+
+func TestRelations(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(models), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	relations, err := New(tago.TaGo{Name: "gorm2"}).Relations(dir)
+	if err != nil {
+		t.Fatalf("Relations: %v", err)
+	}
+	want := []Relation{
+		{From: "User", To: "Base", Field: "Base"},
+		{From: "User", To: "Address", Field: "Address"},
+		{From: "User", To: "Order", Field: "Orders", Many: true},
+	}
+	if !reflect.DeepEqual(relations, want) {
+		t.Errorf("Relations = %v, want %v", relations, want)
+	}
+}
@@ -0,0 +1,227 @@
+package tagotest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+// Schema declares the instructions a model may use, for AssertModel
+type Schema struct {
+	// TaGo parsing the model
+	Tag tago.TaGo
+
+	// Rules of the allowed instruction keys
+	Keys map[string]KeyRule
+
+	// Accept keys without rule instead of failing
+	AllowUnknownKeys bool
+}
+
+// KeyRule constrains the instructions with a key. Zero fields don't constrain anything.
+type KeyRule struct {
+	// Allowed values
+	Values []string
+
+	// Regular expression the whole value must match
+	Pattern string
+
+	// Kinds of the fields the key may be used on (pointers are dereferenced)
+	Kinds []reflect.Kind
+
+	// At most one field of a struct may carry the key (nested structs are checked separately)
+	Unique bool
+
+	// At most one field of the model, at any depth, may carry each instruction of the key (e.g. column=email),
+	// see tago.TaGo.Duplicates
+	UniqueValue bool
+}
+
+// AssertModel fails the test with one message per violation when the tags of a model, nested fields included,
+// don't follow the schema. Meant to be dropped into the tests of the packages declaring models.
+//
+// Example:
+//
+//	var schema = tagotest.Schema{
+//		Tag: tago.TaGo{Name: "gorm2"},
+//		Keys: map[string]tagotest.KeyRule{
+//			"column":     {Pattern: `[a-z_]+`, UniqueValue: true},
+//			"preload":    {Values: []string{"true", "false"}, Kinds: []reflect.Kind{reflect.Struct, reflect.Slice}},
+//			"primaryKey": {Unique: true},
+//		},
+//	}
+//
+//	func TestUserTags(t *testing.T) {
+//		tagotest.AssertModel(t, &User{}, schema)
+//		// User.Name: column=Name: value "Name" doesn't match `[a-z_]+`
+//	}
+func AssertModel(t testing.TB, model any, schema Schema) bool {
+	t.Helper()
+
+	violations := CheckModel(model, schema)
+	for _, violation := range violations {
+		t.Error(violation)
+	}
+	return len(violations) == 0
+}
+
+// CheckModel returns the violations of the schema by the tags of a model, sorted, see AssertModel
+func CheckModel(model any, schema Schema) []string {
+	typeName := "model"
+	if model != nil {
+		typ := reflect.TypeOf(model)
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			typ = typ.Elem()
+		}
+		typeName = typ.Name()
+	}
+
+	report, err := ModelReport(model, schema)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	violations := make([]string, len(report.Violations))
+	for i, violation := range report.Violations {
+		if violation.Path == "" {
+			violations[i] = fmt.Sprintf("%s: %s", typeName, violation.Message)
+		} else {
+			violations[i] = fmt.Sprintf("%s.%s: %s: %s", typeName, violation.Path, violation.Instruction, violation.Message)
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// ModelReport returns the violations of the schema by the tags of a model as a tago.Report, see AssertModel.
+// Violations of the uniqueness rules concern several fields, their path is empty. The error is the one of
+// tago.TaGo.Compile, if the tags can't be parsed.
+//
+// Example:
+//
+//	report, err := tagotest.ModelReport(&User{}, schema)
+//	report.Violations[0] // {Path: Name, Rule: pattern, Instruction: column=Name, Expected: [a-z_]+, Actual: Name, ..}
+func ModelReport(model any, schema Schema) (tago.Report, error) {
+	var report tago.Report
+	tags, err := schema.Tag.Compile(model)
+	if err != nil {
+		return report, err
+	}
+	fields := schema.Tag.FieldMap(model, ".")
+
+	add := func(field tago.FieldName, instruction tago.Instruction, rule string, expected string, actual string, format string, args ...any) {
+		report.Add(tago.Violation{
+			Path:        field,
+			Rule:        rule,
+			Instruction: instruction,
+			Expected:    expected,
+			Actual:      actual,
+			Message:     fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Patterns of the keys, compiled once: an invalid pattern is reported once, the other rules of its key still apply
+	keys := make([]string, 0, len(schema.Keys))
+	for key := range schema.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	patterns := make(map[string]*regexp.Regexp)
+	for _, key := range keys {
+		rule := schema.Keys[key]
+		if rule.Pattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			add("", "", "pattern", rule.Pattern, "", "key %q: invalid pattern `%s`: %v", key, rule.Pattern, err)
+			continue
+		}
+		patterns[key] = pattern
+	}
+
+	// Fields carrying each key by parent path, for the Unique rule
+	carriers := make(map[[2]string][]tago.FieldName)
+	var carrierOrder [][2]string
+
+	for _, instruction := range tags.Keys() {
+		key := instruction.Key()
+		rule, exists := schema.Keys[key]
+		for _, field := range tags[instruction] {
+			if instruction == tago.Skip {
+				continue
+			}
+			if !exists {
+				if !schema.AllowUnknownKeys {
+					add(field, instruction, "key", "", key, "unknown key %q", key)
+				}
+				continue
+			}
+			parent := ""
+			if i := strings.LastIndex(field.String(), "."); i >= 0 {
+				parent = field.String()[:i]
+			}
+			carrier := [2]string{key, parent}
+			if _, seen := carriers[carrier]; !seen {
+				carrierOrder = append(carrierOrder, carrier)
+			}
+			carriers[carrier] = append(carriers[carrier], field)
+
+			value := instruction.Value()
+			if len(rule.Values) > 0 && !slices.Contains(rule.Values, value) {
+				expected := strings.Join(rule.Values, ", ")
+				add(field, instruction, "values", expected, value, "value %q not allowed, expected one of %s", value, expected)
+			}
+			if pattern := patterns[key]; pattern != nil && !pattern.MatchString(value) {
+				add(field, instruction, "pattern", rule.Pattern, value, "value %q doesn't match `%s`", value, rule.Pattern)
+			}
+			if len(rule.Kinds) > 0 {
+				fieldType := fields[field].Type
+				for fieldType != nil && fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if fieldType != nil && !slices.Contains(rule.Kinds, fieldType.Kind()) {
+					kinds := make([]string, len(rule.Kinds))
+					for i, kind := range rule.Kinds {
+						kinds[i] = kind.String()
+					}
+					add(field, instruction, "kinds", strings.Join(kinds, ", "), fieldType.Kind().String(), "not allowed on a field of kind %s", fieldType.Kind())
+				}
+			}
+		}
+	}
+
+	for _, carrier := range carrierOrder {
+		fieldNames := carriers[carrier]
+		if key := carrier[0]; schema.Keys[key].Unique && len(fieldNames) > 1 {
+			names := make([]string, len(fieldNames))
+			for i, field := range fieldNames {
+				names[i] = field.String()
+			}
+			sort.Strings(names)
+			add("", "", "unique", "1 field", strings.Join(names, ", "), "key %q is unique but used on %s", key, strings.Join(names, ", "))
+		}
+	}
+
+	var uniqueValues []string
+	for key, rule := range schema.Keys {
+		if rule.UniqueValue {
+			uniqueValues = append(uniqueValues, key)
+		}
+	}
+	sort.Strings(uniqueValues)
+	for _, duplicate := range schema.Tag.Duplicates(model, ".", uniqueValues...) {
+		names := make([]string, len(duplicate.Fields))
+		for i, field := range duplicate.Fields {
+			names[i] = field.String()
+		}
+		add("", duplicate.Instruction, "uniqueValue", "1 field", strings.Join(names, ", "), "instruction %q is unique but used on %s", duplicate.Instruction, strings.Join(names, ", "))
+	}
+	return report, nil
+}
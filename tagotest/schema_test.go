@@ -0,0 +1,66 @@
+package tagotest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+type schemaAddress struct {
+	City string `gorm2:"column=city"`
+}
+
+type schemaUser struct {
+	ID      int           `gorm2:"primaryKey;column=id"`
+	Code    int           `gorm2:"primaryKey;column=code"`
+	Name    string        `gorm2:"column=Name;size=big"`
+	Address schemaAddress `gorm2:"preload=maybe"`
+	Email   string        `gorm2:"column=city;legacy"`
+	Tags    []string      `gorm2:"preload=true"`
+}
+
+func TestCheckModel(t *testing.T) {
+	schema := Schema{
+		Tag: tago.TaGo{Name: "gorm2"},
+		Keys: map[string]KeyRule{
+			"column":     {Pattern: `[a-z_]+`, UniqueValue: true},
+			"preload":    {Values: []string{"true", "false"}, Kinds: []reflect.Kind{reflect.Struct}},
+			"primaryKey": {Unique: true},
+			"size":       {Pattern: `[0-9+`, Kinds: []reflect.Kind{reflect.Int}},
+		},
+	}
+
+	want := []string{
+		`schemaUser.Name: column=Name: value "Name" doesn't match ` + "`[a-z_]+`",
+		// The other rules of a key with an invalid pattern still apply
+		`schemaUser.Name: size=big: not allowed on a field of kind string`,
+		`schemaUser.Address: preload=maybe: value "maybe" not allowed, expected one of true, false`,
+		`schemaUser.Email: legacy: unknown key "legacy"`,
+		`schemaUser.Tags: preload=true: not allowed on a field of kind slice`,
+		`schemaUser: instruction "column=city" is unique but used on Address.City, Email`,
+		`schemaUser: key "primaryKey" is unique but used on Code, ID`,
+		// An invalid pattern is reported once, not once per field
+		"schemaUser: key \"size\": invalid pattern `[0-9+`: error parsing regexp: missing closing ]: `[0-9+)$`",
+	}
+	got := CheckModel(&schemaUser{}, schema)
+	if len(got) != len(want) {
+		t.Fatalf("CheckModel = %d violations:\n%q\nwant %d", len(got), got, len(want))
+	}
+	for _, violation := range want {
+		found := false
+		for _, g := range got {
+			found = found || g == violation
+		}
+		if !found {
+			t.Errorf("missing violation %s in\n%q", violation, got)
+		}
+	}
+
+	schema.AllowUnknownKeys = true
+	for _, violation := range CheckModel(&schemaUser{}, schema) {
+		if violation == want[3] {
+			t.Errorf("AllowUnknownKeys: unexpected violation %s", violation)
+		}
+	}
+}
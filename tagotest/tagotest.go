@@ -0,0 +1,113 @@
+// Package tagotest provides helpers to assert on parsed tago.Instructions in unit tests.
+//
+// Usage:
+//
+//	func TestUserTags(t *testing.T) {
+//		got := tago.TaGo{Name: "gorm2"}.GetNested(&User{}, ".")
+//		tagotest.Equal(t, got, tago.Instructions{
+//			"preload=true": {"Address"},
+//		})
+//	}
+package tagotest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/KooQix/tago"
+)
+
+// Equal fails the test if got and want don't hold the same instructions and fields (field order is ignored)
+func Equal(t testing.TB, got, want tago.Instructions) bool {
+	t.Helper()
+	if got.Equal(want) {
+		return true
+	}
+	t.Errorf("instructions mismatch (-want +got):\n%s", Diff(want, got))
+	return false
+}
+
+// Contains fails the test if got doesn't hold every instruction and field of want (field order is ignored)
+func Contains(t testing.TB, got, want tago.Instructions) bool {
+	t.Helper()
+	if got.Contains(want) {
+		return true
+	}
+
+	// Only report what is missing, extra instructions/fields are allowed
+	var b strings.Builder
+	for _, line := range diffLines(want, got) {
+		if strings.HasPrefix(line, "-") {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	t.Errorf("instructions missing from result (-want):\n%s", b.String())
+	return false
+}
+
+// Diff returns a line-based diff between want and got, ignoring field order.
+// Lines starting with "-" are in want only, lines starting with "+" are in got only.
+// Returns an empty string if both are equal.
+//
+// Example output:
+//
+//	Diff(want, got)
+//	// - preload=true: Field3
+//	// + preload=true: Field4
+func Diff(want, got tago.Instructions) string {
+	lines := diffLines(want, got)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func diffLines(want, got tago.Instructions) []string {
+	// Union of the instructions, sorted for deterministic output
+	keys := make([]tago.Instruction, 0, len(want)+len(got))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	for key := range got {
+		if _, exists := want[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	lines := make([]string, 0)
+	for _, key := range keys {
+		missing, extra := diffFields(want[key], got[key])
+		for _, field := range missing {
+			lines = append(lines, "- "+string(key)+": "+field.String())
+		}
+		for _, field := range extra {
+			lines = append(lines, "+ "+string(key)+": "+field.String())
+		}
+	}
+	return lines
+}
+
+// Return the fields of want not in got, and the fields of got not in want (as multisets)
+func diffFields(want, got []tago.FieldName) (missing, extra []tago.FieldName) {
+	counts := make(map[tago.FieldName]int, len(got))
+	for _, field := range got {
+		counts[field]++
+	}
+	for _, field := range want {
+		if counts[field] > 0 {
+			counts[field]--
+			continue
+		}
+		missing = append(missing, field)
+	}
+	for _, field := range got {
+		if counts[field] > 0 {
+			counts[field]--
+			extra = append(extra, field)
+		}
+	}
+	return missing, extra
+}
@@ -0,0 +1,276 @@
+// Package tagots generates TypeScript interfaces from tagged models, to keep frontend types in sync with the Go
+// models serving them.
+//
+// The following instructions are supported on fields:
+//
+//	name=name      name of the property (default: the json tag name, or the Go field name)
+//	name=-         skip the field
+//	optional=true  optional property (name?: Type)
+//	readOnly=true  read-only property (readonly name: Type)
+//
+// Nested structs and slices of structs produce their own interfaces, embedded structs are flattened and fields
+// tagged json:"-" are skipped. Pointers are nullable (Type | null), like encoding/json writes nil pointers.
+//
+// Usage:
+//
+//	type User struct {
+//		ID        uint64    `json:"id" ts:"readOnly=true"`
+//		Name      string    `json:"name"`
+//		Nickname  *string   `ts:"name=nickname;optional=true"`
+//		Addresses []Address `json:"addresses"`
+//	}
+//	ts, err := tagots.Interfaces(tago.TaGo{Name: "ts"}, &User{})
+//	// export interface User {
+//	//   readonly id: number;
+//	//   name: string;
+//	//   nickname?: string | null;
+//	//   addresses: Address[];
+//	// }
+//	// ..
+package tagots
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KooQix/tago"
+	"github.com/KooQix/tago/internal/typeutil"
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Interfaces returns the TypeScript interfaces of the given models and of every struct type they reference.
+// Interfaces are written in discovery order.
+func Interfaces(t tago.TaGo, models ...any) (string, error) {
+	g := generator{
+		tag:   t,
+		names: make(map[reflect.Type]string),
+		used:  make(map[string]bool),
+	}
+
+	for _, model := range models {
+		if model == nil {
+			return "", errors.New("tagots: nil model")
+		}
+		modelType := typeutil.Elem(reflect.TypeOf(model))
+		if modelType.Kind() != reflect.Struct {
+			return "", fmt.Errorf("tagots: model %s is not a struct", modelType)
+		}
+		g.typeName(modelType, "")
+	}
+
+	// Types referenced while writing an interface are queued, write until the queue is empty
+	var b strings.Builder
+	for i := 0; i < len(g.queue); i++ {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if err := g.writeInterface(&b, g.queue[i]); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+type generator struct {
+	tag tago.TaGo
+
+	// Interface name of each struct type, and the names already used
+	names map[reflect.Type]string
+	used  map[string]bool
+
+	// Struct types to write, in discovery order
+	queue []reflect.Type
+}
+
+// Get the interface name of a struct type, registering it if needed
+// Anonymous structs are named after their parent type and field name
+func (g *generator) typeName(typ reflect.Type, fallback string) string {
+	if name, exists := g.names[typ]; exists {
+		return name
+	}
+
+	name := sanitize(typ.Name())
+	if name == "" {
+		name = fallback
+	}
+
+	// Avoid conflicts between types with the same name in different packages
+	base := name
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	g.names[typ] = name
+	g.used[name] = true
+	g.queue = append(g.queue, typ)
+	return name
+}
+
+func (g *generator) writeInterface(b *strings.Builder, typ reflect.Type) error {
+	fmt.Fprintf(b, "export interface %s {\n", g.names[typ])
+	if err := g.writeProperties(b, typ, g.names[typ], make(map[reflect.Type]bool)); err != nil {
+		return err
+	}
+	b.WriteString("}\n")
+	return nil
+}
+
+// Write the properties of a struct type, visiting holds the types being flattened to stop on embedded cycles
+func (g *generator) writeProperties(b *strings.Builder, typ reflect.Type, parentName string, visiting map[reflect.Type]bool) error {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := g.tag.GetFromField(field)
+
+		name, hasName := tags.Lookup("name")
+		if !hasName {
+			var omitted bool
+			if name, omitted = jsonName(field); omitted {
+				continue
+			}
+		}
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs are flattened into the parent interface, like encoding/json does, once per branch
+		if field.Anonymous && name == "" && typeutil.Elem(field.Type).Kind() == reflect.Struct {
+			if embedded := typeutil.Elem(field.Type); !visiting[embedded] {
+				if err := g.writeProperties(b, embedded, parentName, visiting); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		tsType, err := g.fieldType(field.Type, parentName+field.Name)
+		if err != nil {
+			return fmt.Errorf("tagots: %s.%s: %w", typ, field.Name, err)
+		}
+
+		b.WriteString("  ")
+		if readOnly, _ := tags.Lookup("readOnly"); readOnly == "true" {
+			b.WriteString("readonly ")
+		}
+		b.WriteString(propertyName(name))
+		if optional, _ := tags.Lookup("optional"); optional == "true" {
+			b.WriteByte('?')
+		}
+		fmt.Fprintf(b, ": %s;\n", tsType)
+	}
+	return nil
+}
+
+// Get the TypeScript type of a Go type
+func (g *generator) fieldType(typ reflect.Type, fallback string) (string, error) {
+	if typ.Kind() == reflect.Ptr {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		tsType, err := g.fieldType(typ, fallback)
+		if err != nil {
+			return "", err
+		}
+		return tsType + " | null", nil
+	}
+
+	switch typ {
+	case timeType:
+		return "string", nil
+	case bytesType:
+		// encoding/json writes []byte in base64
+		return "string", nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.fieldType(typ.Elem(), fallback)
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(items, " ") {
+			items = "(" + items + ")"
+		}
+		return items + "[]", nil
+	case reflect.Map:
+		values, err := g.fieldType(typ.Elem(), fallback)
+		if err != nil {
+			return "", err
+		}
+		// JSON object keys are always strings
+		return "Record<string, " + values + ">", nil
+	case reflect.Interface:
+		return "unknown", nil
+	case reflect.Struct:
+		return g.typeName(typ, fallback), nil
+	}
+	return "", fmt.Errorf("type %s has no JSON representation", typ)
+}
+
+// Name of a field in JSON documents from its json tag, empty if it has none, and whether the field is omitted
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, exists := field.Tag.Lookup("json")
+	if !exists {
+		return "", false
+	}
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// Quote property names which aren't valid identifiers: created-at -> "created-at"
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r)) {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
+
+// Keep only valid TypeScript identifier characters, dropping the package paths of generic type arguments
+// Page[github.com/org/models.User] -> PageUser
+func sanitize(name string) string {
+	var b, token strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '.' || r == '/':
+			// What we read so far is a package path element
+			token.Reset()
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'):
+			token.WriteRune(r)
+		default:
+			b.WriteString(token.String())
+			token.Reset()
+		}
+	}
+	b.WriteString(token.String())
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package tagots
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KooQix/tago"
+)
+
+type tsBase struct {
+	ID uint64 `json:"id" ts:"readOnly=true"`
+}
+
+type tsAddress struct {
+	City string `json:"city"`
+}
+
+type tsUser struct {
+	tsBase
+	Name      string      `json:"name"`
+	Nickname  *string     `ts:"name=nickname;optional=true"`
+	Addresses []tsAddress `json:"addresses"`
+	Meta      map[string]any
+	Created   time.Time `json:"created"`
+	Skipped   string    `json:"-"`
+	Hidden    string    `ts:"name=-"`
+	Inline    struct{ A bool }
+	secret    string
+}
+
+type tsNode struct {
+	*tsNode
+	X int
+}
+
+type tsLeft struct {
+	*tsRight
+	L string
+}
+
+type tsRight struct {
+	*tsLeft
+	R string
+}
+
+func TestInterfaces(t *testing.T) {
+	tg := tago.TaGo{Name: "ts"}
+
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{"model", &tsUser{}, `export interface tsUser {
+  readonly id: number;
+  name: string;
+  nickname?: string | null;
+  addresses: tsAddress[];
+  Meta: Record<string, unknown>;
+  created: string;
+  Inline: tsUserInline;
+}
+
+export interface tsAddress {
+  city: string;
+}
+
+export interface tsUserInline {
+  A: boolean;
+}
+`},
+		// Embedded cycles are flattened once, like encoding/json does
+		{"embedded self pointer", &tsNode{}, "export interface tsNode {\n  X: number;\n}\n"},
+		{"embedded cycle", tsLeft{}, "export interface tsLeft {\n  R: string;\n  L: string;\n}\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Interfaces(tg, test.model)
+			if err != nil {
+				t.Fatalf("Interfaces: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Interfaces =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+
+	if _, err := Interfaces(tg, nil); err == nil {
+		t.Error("Interfaces(nil): expected an error")
+	}
+	if _, err := Interfaces(tg, 1); err == nil {
+		t.Error("Interfaces(1): expected an error")
+	}
+}
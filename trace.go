@@ -0,0 +1,94 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TraceKind is the kind of a TraceEvent
+type TraceKind int
+
+const (
+	// A struct type is being traversed
+	TraceType TraceKind = iota
+
+	// A field has been parsed
+	TraceField
+
+	// A struct field isn't traversed, see TraceEvent.Reason
+	TraceSkip
+)
+
+func (k TraceKind) String() string {
+	switch k {
+	case TraceType:
+		return "type"
+	case TraceField:
+		return "field"
+	case TraceSkip:
+		return "skip"
+	}
+	return fmt.Sprintf("TraceKind(%d)", int(k))
+}
+
+// TraceEvent describes a step of the traversal of a model, see WithTracer
+type TraceEvent struct {
+	Kind TraceKind
+
+	// Struct type being traversed (TraceType) or declaring the field (TraceField, TraceSkip)
+	Type reflect.Type
+
+	// Path of the field, or of the field holding the traversed type (empty for the model itself)
+	Field FieldName
+
+	// Nesting level, 0 for the top-level fields
+	Depth int
+
+	// Raw tag of the field and its parsed instructions (TraceField)
+	RawTag       string
+	Instructions Instructions
+
+	// Why the field isn't traversed (TraceSkip)
+	Reason string
+}
+
+func (e TraceEvent) String() string {
+	indent := strings.Repeat("  ", e.Depth)
+	switch e.Kind {
+	case TraceType:
+		return fmt.Sprintf("%stype %s", indent, e.Type)
+	case TraceField:
+		return fmt.Sprintf("%sfield %s tag=%q instructions=%s", indent, e.Field, e.RawTag, e.Instructions)
+	case TraceSkip:
+		return fmt.Sprintf("%sskip %s: %s", indent, e.Field, e.Reason)
+	}
+	return fmt.Sprintf("%s%s %s", indent, e.Kind, e.Field)
+}
+
+// WithTracer reports every traversal event to the given function: visited types, parsed fields with their
+// raw tag and instructions, and struct fields that aren't traversed with the reason why.
+// Useful to understand why a deeply nested tag isn't picked up.
+//
+// Example:
+//
+//	t.GetNested(&User{}, ".", tago.WithTracer(func(e tago.TraceEvent) {
+//		fmt.Println(e)
+//	}))
+//	// type main.User
+//	// field Address tag="preload=true" instructions=map[preload=true:[Address]]
+//	//   type main.Address
+//	//   ..
+//	// skip CreatedAt: ignored type time.Time
+func WithTracer(tracer func(TraceEvent)) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// Report a traversal event to the tracer, if any
+func (o options) trace(event TraceEvent) {
+	if o.tracer != nil {
+		o.tracer(event)
+	}
+}
@@ -0,0 +1,225 @@
+package tago
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TransformFunc transforms the content of a field, arg is the value of its instruction (e.g. "aes" for encrypt=aes)
+type TransformFunc func(value []byte, arg string) ([]byte, error)
+
+type transform struct {
+	forward, reverse TransformFunc
+}
+
+// RegisterTransform registers a reversible transform for the instruction key, e.g. encryption for at-rest protection:
+// ApplyTransforms runs forward on the fields carrying the key, RevertTransforms runs reverse.
+// Transforms operate on string and []byte fields (and pointers to them): string fields are converted to and from bytes,
+// so a transform producing binary data for them should encode it (base64, hex, ..).
+// The fields of the structs held by slices, arrays and maps are transformed too; fields that can't be transformed
+// (unsupported type, held by an interface) are reported as errors rather than left as is.
+//
+// Example:
+//
+//	t.RegisterTransform("encrypt", encrypt, decrypt)
+//
+//	type User struct {
+//		SSN string `gorm2:"encrypt=aes"`
+//	}
+//	err := t.ApplyTransforms(&user)  // before writing to the database
+//	err = t.RevertTransforms(&user)  // after reading from the database
+func (t *TaGo) RegisterTransform(key string, forward TransformFunc, reverse TransformFunc) *TaGo {
+	if t.transforms == nil {
+		t.transforms = make(map[string]transform)
+	}
+	t.transforms[key] = transform{forward: forward, reverse: reverse}
+	return t
+}
+
+// ApplyTransforms runs the forward function of the registered transforms on the fields of a model, nested fields included,
+// in tag order. model must be a non-nil pointer to a struct. See RegisterTransform.
+func (t TaGo) ApplyTransforms(model interface{}, opts ...Option) error {
+	return t.runTransforms(model, false, opts)
+}
+
+// RevertTransforms runs the reverse function of the registered transforms on the fields of a model, nested fields included,
+// in reverse tag order, undoing ApplyTransforms. model must be a non-nil pointer to a struct. See RegisterTransform.
+func (t TaGo) RevertTransforms(model interface{}, opts ...Option) error {
+	return t.runTransforms(model, true, opts)
+}
+
+func (t TaGo) runTransforms(model interface{}, reverse bool, opts []Option) error {
+	if err := checkSettable(model); err != nil {
+		return err
+	}
+	if len(t.transforms) == 0 {
+		return nil
+	}
+	return errors.Join(t.transformFields(reflect.ValueOf(model), "", reverse, opts)...)
+}
+
+// Value of a field with its path, one per element of the slices and arrays on the way to the field (Items[0].SSN)
+type heldValue struct {
+	value reflect.Value
+	path  FieldName
+}
+
+// Run the transforms on the fields of a pointer to a struct, prefixing the paths of the errors.
+// The traversal visits the fields of the elements of slices and arrays once for their type: they are transformed
+// for every element. The structs held by maps aren't traversed, each of them is transformed on its own.
+func (t TaGo) transformFields(model reflect.Value, prefix FieldName, reverse bool, opts []Option) []error {
+	var errs []error
+	values := make(map[*FieldContext][]heldValue)
+	structs := make(map[*FieldContext][]heldValue)
+	t.walkContexts(model.Interface(), newOptions(".", -1, opts), func(ctx *FieldContext) {
+		var held []heldValue
+		switch {
+		case ctx.Parent == nil && ctx.Value.IsValid():
+			held = []heldValue{{ctx.Value, prefix + ctx.Path}}
+		case ctx.Parent != nil:
+			parents, exists := structs[ctx.Parent]
+			if !exists {
+				for _, parent := range values[ctx.Parent] {
+					parents = append(parents, structElements(parent)...)
+				}
+				structs[ctx.Parent] = parents
+			}
+			suffix := FieldName(strings.TrimPrefix(string(ctx.Path), string(ctx.Parent.Path)))
+			for _, parent := range parents {
+				if parent.value.Type() == ctx.Owner {
+					held = append(held, heldValue{parent.value.FieldByIndex(ctx.Field.Index), parent.path + suffix})
+				}
+			}
+		}
+		values[ctx] = held
+
+		for _, field := range held {
+			errs = append(errs, t.transformMap(field, reverse, opts)...)
+			if err := t.transformField(field, ctx.Instructions, reverse); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	return errs
+}
+
+// Run the transforms of the instructions on the value of a field, in tag order (reverse order to revert them)
+func (t TaGo) transformField(field heldValue, instructions []Instruction, reverse bool) error {
+	for i := range instructions {
+		instruction := instructions[i]
+		if reverse {
+			instruction = instructions[len(instructions)-1-i]
+		}
+
+		transform, exists := t.transforms[instruction.Key()]
+		if !exists {
+			continue
+		}
+		fn := transform.forward
+		if reverse {
+			fn = transform.reverse
+		}
+		if fn == nil {
+			continue
+		}
+
+		// A field held by an interface can't be set, it's reported rather than left as is
+		if !field.value.CanSet() {
+			return &PathError{Path: field.path, Instruction: instruction, Err: errors.New("field can't be set")}
+		}
+		if err := transformValue(field.value, func(b []byte) ([]byte, error) { return fn(b, instruction.Value()) }); err != nil {
+			return &PathError{Path: field.path, Instruction: instruction, Err: err}
+		}
+	}
+	return nil
+}
+
+// Run the transforms on the structs held by a map value, on an addressable copy of each value set back to the map
+func (t TaGo) transformMap(field heldValue, reverse bool, opts []Option) []error {
+	value := field.value
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Map {
+		return nil
+	}
+	if elem := typeToElem(value.Type().Elem()); elem.Kind() != reflect.Struct || t.isIgnored(elem) {
+		return nil
+	}
+
+	var errs []error
+	iter := value.MapRange()
+	for iter.Next() {
+		held := reflect.New(value.Type().Elem())
+		held.Elem().Set(iter.Value())
+		for _, element := range structElements(heldValue{held, FieldName(fmt.Sprintf("%s[%v]", field.path, iter.Key()))}) {
+			if !element.value.CanAddr() {
+				errs = append(errs, &PathError{Path: element.path, Err: errors.New("struct held by an interface can't be transformed")})
+				continue
+			}
+			errs = append(errs, t.transformFields(element.value.Addr(), element.path+".", reverse, opts)...)
+		}
+		value.SetMapIndex(iter.Key(), held.Elem())
+	}
+	return errs
+}
+
+// Struct values held by a value: itself, or the elements of a slice or array, pointers dereferenced
+func structElements(field heldValue) []heldValue {
+	value := field.value
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return []heldValue{{value, field.path}}
+	case reflect.Slice, reflect.Array:
+		var elements []heldValue
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, structElements(heldValue{value.Index(i), FieldName(fmt.Sprintf("%s[%d]", field.path, i))})...)
+		}
+		return elements
+	}
+	return nil
+}
+
+// Apply fn to a string or []byte value, or the one a pointer points to
+func transformValue(value reflect.Value, fn func([]byte) ([]byte, error)) error {
+	switch {
+	case value.Kind() == reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return transformValue(value.Elem(), fn)
+	case value.Kind() == reflect.String:
+		if value.Len() == 0 {
+			return nil
+		}
+		transformed, err := fn([]byte(value.String()))
+		if err != nil {
+			return err
+		}
+		value.SetString(string(transformed))
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+		if value.Len() == 0 {
+			return nil
+		}
+		transformed, err := fn(value.Bytes())
+		if err != nil {
+			return err
+		}
+		value.SetBytes(transformed)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package tago
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type transformItem struct {
+	Secret string `gorm2:"encrypt=rot"`
+	Note   string
+}
+
+type transformOrder struct {
+	Secret  string                     `gorm2:"encrypt=rot"`
+	Token   *[]byte                    `gorm2:"encrypt=rot"`
+	Items   []transformItem            `gorm2:"preload=true"`
+	Refs    []*transformItem           `gorm2:"preload=true"`
+	ByName  map[string]transformItem   `gorm2:"preload=true"`
+	ByID    map[int]*transformItem     `gorm2:"preload=true"`
+	Nested  [2][]transformItem         `gorm2:"preload=true"`
+	Missing *transformItem             `gorm2:"preload=true"`
+	Groups  map[string][]transformItem `gorm2:"preload=true"`
+}
+
+func upper(value []byte, arg string) ([]byte, error) {
+	return []byte(strings.ToUpper(string(value))), nil
+}
+
+func lower(value []byte, arg string) ([]byte, error) {
+	return []byte(strings.ToLower(string(value))), nil
+}
+
+func TestTransforms(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tg.RegisterTransform("encrypt", upper, lower)
+
+	token := []byte("token")
+	order := transformOrder{
+		Secret: "a",
+		Token:  &token,
+		Items:  []transformItem{{Secret: "b", Note: "n"}, {Secret: "c"}},
+		Refs:   []*transformItem{{Secret: "d"}, nil},
+		ByName: map[string]transformItem{"x": {Secret: "e"}},
+		ByID:   map[int]*transformItem{1: {Secret: "f"}},
+		Nested: [2][]transformItem{{{Secret: "g"}}, {{Secret: "h"}}},
+		Groups: map[string][]transformItem{"y": {{Secret: "i"}}},
+	}
+	if err := tg.ApplyTransforms(&order); err != nil {
+		t.Fatalf("ApplyTransforms: %v", err)
+	}
+
+	secrets := func() []string {
+		return []string{
+			order.Secret, string(*order.Token), order.Items[0].Secret, order.Items[0].Note, order.Items[1].Secret,
+			order.Refs[0].Secret, order.ByName["x"].Secret, order.ByID[1].Secret, order.Nested[0][0].Secret,
+			order.Nested[1][0].Secret, order.Groups["y"][0].Secret,
+		}
+	}
+	want := []string{"A", "TOKEN", "B", "n", "C", "D", "E", "F", "G", "H", "I"}
+	if got := secrets(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ApplyTransforms: %v, want %v", got, want)
+	}
+
+	if err := tg.RevertTransforms(&order); err != nil {
+		t.Fatalf("RevertTransforms: %v", err)
+	}
+	want = []string{"a", "token", "b", "n", "c", "d", "e", "f", "g", "h", "i"}
+	if got := secrets(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("RevertTransforms: %v, want %v", got, want)
+	}
+}
+
+type transformInvalid struct {
+	Count int            `gorm2:"encrypt=rot"`
+	Items []transformBad `gorm2:"preload=true"`
+}
+
+type transformBad struct {
+	Tags []string `gorm2:"encrypt=rot"`
+}
+
+func TestTransformsErrors(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tg.RegisterTransform("encrypt", upper, lower)
+
+	if err := tg.ApplyTransforms(transformOrder{}); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("model not a pointer: error = %v, want ErrNotStruct", err)
+	}
+
+	// Unsupported fields are reported with their path, elements of slices included
+	err := tg.ApplyTransforms(&transformInvalid{Items: []transformBad{{}, {Tags: []string{"a"}}}})
+	var paths []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var pathErr *PathError
+		if errors.As(err, &pathErr) {
+			paths = append(paths, string(pathErr.Path))
+		}
+	}
+	if want := "Count,Items[0].Tags,Items[1].Tags"; strings.Join(paths, ",") != want {
+		t.Errorf("ApplyTransforms errors at %v, want %s", paths, want)
+	}
+}
@@ -0,0 +1,55 @@
+package tago
+
+import "reflect"
+
+// Untagged returns the exported fields of a model, at any depth, that carry no instruction for the tag, in traversal
+// order. Embedded fields aren't reported, their own fields are. Fields skipped with "-" are tagged.
+// Combined with a test, it enforces policies like "every persisted field declares a column".
+//
+// Example:
+//
+//	if fields := t.Untagged(&User{}, "."); len(fields) > 0 {
+//		t.Errorf("fields without column: %v", fields) // [Address.Zip Nickname]
+//	}
+func (t TaGo) Untagged(model interface{}, separator string, opts ...Option) []FieldName {
+	return t.UntaggedKinds(model, separator, nil, opts...)
+}
+
+// UntaggedKinds is Untagged, only reporting the fields of the given kinds (pointers are dereferenced),
+// e.g. the scalar fields without the struct fields grouping them. No kind means every kind.
+//
+// Example:
+//
+//	t.UntaggedKinds(&User{}, ".", []reflect.Kind{reflect.String, reflect.Int, reflect.Bool})
+func (t TaGo) UntaggedKinds(model interface{}, separator string, kinds []reflect.Kind, opts ...Option) []FieldName {
+	fields := make([]FieldName, 0)
+
+	o := newOptions(separator, -1, opts)
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		if !field.IsExported() || field.Anonymous || !hasKind(field.Type, kinds) {
+			return
+		}
+		if len(instructions) == 0 {
+			fields = append(fields, path)
+		}
+	}
+	t.mustParseModel(model, o)
+
+	return fields
+}
+
+// Check whether the kind of a type, pointers dereferenced, is one of kinds (any kind if empty)
+func hasKind(typ reflect.Type, kinds []reflect.Kind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	for _, kind := range kinds {
+		if typ.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package tago
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GetForVersion returns the instructions of a model like GetNested, keeping only the fields present in the given version:
+// a field tagged `since=v2` appears from v2 on, a field tagged `until=v3` is removed in v3 (since is inclusive, until exclusive).
+// Fields without since/until are in every version, a field out of the version is skipped with its subtree.
+// Versions are compared segment by segment, numerically when possible: v2 < v2.1 < v10.
+//
+// Example:
+//
+//	type User struct {
+//		Name      string `gorm2:"column=name;until=v3"`
+//		FirstName string `gorm2:"column=first_name;since=v3"`
+//	}
+//	t.GetForVersion(&User{}, "v2", ".") // map[column=name:[Name] until=v3:[Name]]
+func (t TaGo) GetForVersion(model interface{}, version string, separator string, opts ...Option) Instructions {
+	o := newOptions(separator, -1, opts)
+	o.skip = func(path FieldName, field reflect.StructField, owner reflect.Type, instructions []Instruction) string {
+		for _, instruction := range instructions {
+			key, value := instruction.Key(), instruction.Value()
+			switch {
+			case key == "since" && compareVersions(version, value) < 0:
+				return "not in version " + version + " (since " + value + ")"
+			case key == "until" && compareVersions(version, value) >= 0:
+				return "not in version " + version + " (until " + value + ")"
+			}
+		}
+		return ""
+	}
+	return t.mustParseModel(model, o)
+}
+
+// Compare two versions segment by segment (v1.2.3), numerically when both segments are numbers
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(strings.TrimSpace(a), "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(strings.TrimSpace(b), "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		// Missing segments are 0: v2 == v2.0
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		xn, xErr := strconv.Atoi(x)
+		yn, yErr := strconv.Atoi(y)
+		switch {
+		case xErr == nil && yErr == nil && xn != yn:
+			if xn < yn {
+				return -1
+			}
+			return 1
+		case (xErr != nil || yErr != nil) && x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,125 @@
+package tago
+
+import "testing"
+
+type versionUser struct {
+	Name      string `gorm2:"column=name;until=v3"`
+	FirstName string `gorm2:"column=first_name;since=v3"`
+	Nick      string `gorm2:"Since=${NICK_VERSION}"`
+}
+
+func TestGetForVersion(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+	tg.CaseInsensitive().Variables(map[string]string{"NICK_VERSION": "v2.1"})
+
+	tests := []struct {
+		version string
+		want    Instructions
+	}{
+		{"v2", Instructions{"column=name": {"Name"}, "until=v3": {"Name"}}},
+		{"v2.1", Instructions{"column=name": {"Name"}, "until=v3": {"Name"}, "since=v2.1": {"Nick"}}},
+		{"v3", Instructions{"column=first_name": {"FirstName"}, "since=v3": {"FirstName"}, "since=v2.1": {"Nick"}}},
+	}
+	for _, test := range tests {
+		if got := tg.GetForVersion(&versionUser{}, test.version, "."); !got.Equal(test.want) {
+			t.Errorf("GetForVersion(%s) = %v, want %v", test.version, got, test.want)
+		}
+	}
+}
+
+type versionAddress struct {
+	City   string `gorm2:"column=city"`
+	Region string `gorm2:"column=region;since=v2"`
+}
+
+type versionOrder struct {
+	ID       int             `gorm2:"column=id"`
+	Legacy   string          `gorm2:"column=legacy;since=v1.5;until=v2.5"`
+	Address  versionAddress  `gorm2:"until=v3"`
+	Shipping *versionAddress `gorm2:"since=v3"`
+	Lines    []versionAddress
+}
+
+func TestGetForVersionCallPaths(t *testing.T) {
+	tg := &TaGo{Name: "gorm2"}
+	tg.EnableCache(10)
+
+	tests := []struct {
+		name      string
+		model     any
+		version   string
+		separator string
+		opts      []Option
+		want      Instructions
+	}{
+		{"before a range", &versionOrder{}, "v1", ".", nil, Instructions{
+			"column=id":   {"ID"},
+			"until=v3":    {"Address"},
+			"column=city": {"Address.City", "Lines.City"},
+		}},
+		{"in a range", versionOrder{}, "v2", ".", nil, Instructions{
+			"column=id":     {"ID"},
+			"column=legacy": {"Legacy"},
+			"since=v1.5":    {"Legacy"},
+			"until=v2.5":    {"Legacy"},
+			"until=v3":      {"Address"},
+			"column=city":   {"Address.City", "Lines.City"},
+			"column=region": {"Address.Region", "Lines.Region"},
+			"since=v2":      {"Address.Region", "Lines.Region"},
+		}},
+		{"subtree skipped", []versionOrder{}, "v3", "/", nil, Instructions{
+			"column=id":     {"ID"},
+			"since=v3":      {"Shipping"},
+			"column=city":   {"Shipping/City", "Lines/City"},
+			"column=region": {"Shipping/Region", "Lines/Region"},
+			"since=v2":      {"Shipping/Region", "Lines/Region"},
+		}},
+		{"max depth", &versionOrder{}, "v3", ".", []Option{WithMaxDepth(0)}, Instructions{
+			"column=id": {"ID"},
+			"since=v3":  {"Shipping"},
+		}},
+		{"excluded", &versionOrder{}, "v3", ".", []Option{WithExclude("Lines")}, Instructions{
+			"column=id":     {"ID"},
+			"since=v3":      {"Shipping"},
+			"column=city":   {"Shipping.City"},
+			"column=region": {"Shipping.Region"},
+			"since=v2":      {"Shipping.Region"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Twice: results filtered on a version must not be served from the cache
+			for i := 0; i < 2; i++ {
+				if got := tg.GetForVersion(test.model, test.version, test.separator, test.opts...); !got.Equal(test.want) {
+					t.Errorf("GetForVersion(%s) = %v, want %v", test.version, got, test.want)
+				}
+			}
+		})
+	}
+
+	// The cached results of GetNested keep every version
+	if got := tg.GetNested(&versionOrder{}, "."); len(got["column=legacy"]) != 1 || len(got["since=v3"]) != 1 {
+		t.Errorf("GetNested after GetForVersion = %v, want every field", got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v2", "v2.0", 0},
+		{"v2", "v2.1", -1},
+		{"v10", "v2", 1},
+		{"v2.beta", "v2.alpha", 1},
+		{" v1.2.3", "1.2.3", 0},
+		{"v1.2", "v1.10", -1},
+		{"v3", "v2.9.9", 1},
+		{"v2.1", "v2.rc", -1},
+	}
+	for _, test := range tests {
+		if got := compareVersions(test.a, test.b); got != test.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package tago
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// VisibleFields returns the paths of the fields of a model visible to a role, nested fields included, in discovery order.
+// A field tagged `roles=admin,owner` is only visible to these roles, a field without roles instruction is visible to all.
+// A field hidden from the role hides its subtree too. See Redact for a copy of a model without the hidden fields.
+//
+// Example:
+//
+//	type User struct {
+//		Name  string
+//		Email string `gorm2:"roles=admin,owner"`
+//	}
+//	t.VisibleFields(&User{}, "guest") // [Name]
+func (t TaGo) VisibleFields(model interface{}, role string, opts ...Option) []FieldName {
+	fields := make([]FieldName, 0)
+
+	o := newOptions(".", -1, opts)
+	o.skip = func(path FieldName, field reflect.StructField, owner reflect.Type, instructions []Instruction) string {
+		if !visible(instructions, role) {
+			return "hidden from role " + role
+		}
+		return ""
+	}
+	o.onField = func(path FieldName, field reflect.StructField, owner reflect.Type, depth int, instructions []Instruction) {
+		fields = append(fields, path)
+	}
+	t.mustParseModel(model, o)
+
+	return fields
+}
+
+// Redact returns a copy of a model (a struct or a pointer to a struct) where the fields hidden from the role are zeroed,
+// see VisibleFields. Nested structs, pointers to structs and the slices, arrays, maps and interfaces holding structs are
+// copied too, the model is left untouched. The fields promoted from unexported embedded structs are redacted like the others.
+//
+// Example:
+//
+//	user := User{Name: "Bob", Email: "bob@example.com"}
+//	t.Redact(user, "guest") // User{Name: "Bob"}
+func (t TaGo) Redact(model interface{}, role string) interface{} {
+	if model == nil {
+		return nil
+	}
+	value := reflect.ValueOf(model)
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+	t.redact(copied, role, make(map[uintptr]reflect.Value), make(typeFields))
+	return copied.Interface()
+}
+
+// Zero the fields of a (settable) value hidden from the role, copying what is shared with the original
+func (t TaGo) redact(value reflect.Value, role string, copies map[uintptr]reflect.Value, fields typeFields) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || value.Elem().Kind() != reflect.Struct {
+			return
+		}
+
+		// Pointers to the same struct share the same copy, which also ends cycles
+		if copied, exists := copies[value.Pointer()]; exists {
+			value.Set(copied)
+			return
+		}
+		copied := reflect.New(value.Type().Elem())
+		copies[value.Pointer()] = copied
+		copied.Elem().Set(value.Elem())
+		value.Set(copied)
+		t.redact(copied.Elem(), role, copies, fields)
+
+	case reflect.Interface:
+		if value.IsNil() || !mayHoldStructs(value.Elem().Type()) {
+			return
+		}
+		// The value held by an interface can't be set, it's redacted on a copy
+		copied := reflect.New(value.Elem().Type()).Elem()
+		copied.Set(value.Elem())
+		t.redact(copied, role, copies, fields)
+		value.Set(copied)
+
+	case reflect.Slice:
+		if value.IsNil() || !mayHoldStructs(value.Type().Elem()) {
+			return
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		reflect.Copy(copied, value)
+		value.Set(copied)
+		for i := 0; i < copied.Len(); i++ {
+			t.redact(copied.Index(i), role, copies, fields)
+		}
+
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			t.redact(value.Index(i), role, copies, fields)
+		}
+
+	case reflect.Map:
+		if value.IsNil() || !mayHoldStructs(value.Type().Elem()) {
+			return
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			elem := reflect.New(value.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			t.redact(elem, role, copies, fields)
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+		value.Set(copied)
+
+	case reflect.Struct:
+		typ := value.Type()
+		if t.isIgnored(typ) {
+			return
+		}
+		for i, instructions := range t.fieldsOf(fields, typ) {
+			field, fieldValue := typ.Field(i), value.Field(i)
+			if !field.IsExported() {
+				// Unexported embedded structs promote their exported fields (encoding/json marshals them): they're
+				// redacted through a settable alias of the field, the value being a copy
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				if !field.Anonymous || embedded.Kind() != reflect.Struct {
+					continue
+				}
+				fieldValue = reflect.NewAt(field.Type, unsafe.Pointer(fieldValue.UnsafeAddr())).Elem()
+			}
+			if !visible(instructions, role) {
+				fieldValue.SetZero()
+				continue
+			}
+			t.redact(fieldValue, role, copies, fields)
+		}
+	}
+}
+
+// Whether values of a type can hold structs: structs, maps and interfaces, behind pointers, slices and arrays
+func mayHoldStructs(typ reflect.Type) bool {
+	switch typeToElem(typ).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// Whether a field is visible to a role according to its roles instruction
+func visible(instructions []Instruction, role string) bool {
+	roles, exists := lookupKey(instructions, "roles")
+	if !exists {
+		return true
+	}
+	for _, allowed := range strings.Split(roles, ",") {
+		if strings.TrimSpace(allowed) == role {
+			return true
+		}
+	}
+	return false
+}
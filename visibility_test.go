@@ -0,0 +1,120 @@
+package tago
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type visibilityProfile struct {
+	Bio   string
+	Phone string `gorm2:"roles=admin, owner"`
+}
+
+type visibilityUser struct {
+	Name     string
+	Email    string `gorm2:"roles=admin,owner"`
+	Profile  *visibilityProfile
+	Friends  []visibilityProfile
+	ByName   map[string]visibilityProfile
+	ByID     map[int]*visibilityProfile
+	Extra    any
+	Internal visibilityProfile `gorm2:"roles=admin"`
+}
+
+func TestVisibleFields(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	got := tg.VisibleFields(&visibilityUser{}, "guest")
+	want := []FieldName{"Name", "Profile", "Profile.Bio", "Friends", "Friends.Bio", "ByName", "ByID", "Extra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VisibleFields(guest) = %v, want %v", got, want)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	profile := visibilityProfile{Bio: "bio", Phone: "555"}
+	user := visibilityUser{
+		Name:     "Bob",
+		Email:    "bob@example.com",
+		Profile:  &visibilityProfile{Bio: "bio", Phone: "555"},
+		Friends:  []visibilityProfile{profile},
+		ByName:   map[string]visibilityProfile{"a": profile},
+		ByID:     map[int]*visibilityProfile{1: {Bio: "bio", Phone: "555"}},
+		Extra:    profile,
+		Internal: profile,
+	}
+
+	redacted := tg.Redact(user, "guest").(visibilityUser)
+	hidden := visibilityProfile{Bio: "bio"}
+	want := visibilityUser{
+		Name:    "Bob",
+		Profile: &hidden,
+		Friends: []visibilityProfile{hidden},
+		ByName:  map[string]visibilityProfile{"a": hidden},
+		ByID:    map[int]*visibilityProfile{1: &hidden},
+		Extra:   hidden,
+	}
+	if !reflect.DeepEqual(redacted, want) {
+		t.Errorf("Redact(guest) = %+v, want %+v", redacted, want)
+	}
+
+	// The model is left untouched
+	if user.Email == "" || user.Profile.Phone == "" || user.Friends[0].Phone == "" || user.ByName["a"].Phone == "" ||
+		user.ByID[1].Phone == "" || user.Extra.(visibilityProfile).Phone == "" {
+		t.Errorf("Redact modified the model: %+v", user)
+	}
+
+	if owner := tg.Redact(&user, "owner").(*visibilityUser); owner.Email == "" || owner.ByID[1].Phone == "" || owner.Internal.Bio != "" {
+		t.Errorf("Redact(owner) = %+v", owner)
+	}
+}
+
+type visibilityBase struct {
+	ID    int
+	Email string `gorm2:"roles=admin"`
+}
+
+type visibilityAudit struct {
+	By    string
+	Token string `gorm2:"roles=admin"`
+}
+
+type visibilityAccount struct {
+	visibilityBase
+	*visibilityAudit
+	Name string
+}
+
+func TestRedactUnexportedEmbedded(t *testing.T) {
+	tg := TaGo{Name: "gorm2"}
+
+	account := visibilityAccount{
+		visibilityBase:  visibilityBase{ID: 1, Email: "bob@example.com"},
+		visibilityAudit: &visibilityAudit{By: "admin", Token: "secret"},
+		Name:            "Bob",
+	}
+	if got, want := tg.VisibleFields(&account, "guest"), []FieldName{"visibilityBase", "visibilityBase.ID", "visibilityAudit", "visibilityAudit.By", "Name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisibleFields(guest) = %v, want %v", got, want)
+	}
+
+	redacted := tg.Redact(account, "guest").(visibilityAccount)
+	encoded, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encoded), "bob@example.com") || strings.Contains(string(encoded), "secret") {
+		t.Errorf("Redact(guest) leaks promoted fields: %s", encoded)
+	}
+	if redacted.ID != 1 || redacted.By != "admin" || redacted.Name != "Bob" {
+		t.Errorf("Redact(guest) = %+v, want the visible fields kept", redacted)
+	}
+
+	// The model is left untouched
+	if account.Email == "" || account.Token == "" {
+		t.Errorf("Redact modified the model: %+v %+v", account.visibilityBase, *account.visibilityAudit)
+	}
+}
@@ -0,0 +1,95 @@
+package tago
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Warning reports a problem found while parsing tags that doesn't prevent parsing, e.g. a deprecated instruction key
+type Warning struct {
+	// Struct type declaring the field, nil if unknown (GetFromField)
+	Type reflect.Type
+
+	// Path of the field, nested fields being prefixed with their parents ("Address.City")
+	Field FieldName
+
+	// Instruction as written in the tag
+	Instruction Instruction
+
+	Message string
+
+	// Error behind the warning, if any: a *ParseError for a tag its parser couldn't parse
+	Err error
+}
+
+func (w Warning) String() string {
+	parts := make([]string, 0, 3)
+	if w.Type != nil {
+		location := w.Type.String()
+		if w.Field != "" {
+			location += " " + w.Field.String()
+		}
+		parts = append(parts, location)
+	} else if w.Field != "" {
+		parts = append(parts, w.Field.String())
+	}
+	if w.Instruction != "" {
+		parts = append(parts, string(w.Instruction))
+	}
+	parts = append(parts, w.Message)
+	return strings.Join(parts, ": ")
+}
+
+// OnWarning registers the function called for every warning raised while parsing tags
+//
+// Example:
+//
+//	t.OnWarning(func(w tago.Warning) {
+//		log.Println("tago:", w)
+//	})
+func (t *TaGo) OnWarning(callback func(Warning)) *TaGo {
+	t.onWarning = callback
+	return t
+}
+
+// Deprecate marks an instruction key as deprecated: parsing it raises a Warning (see OnWarning),
+// with the replacement hint in its message. The instruction is still parsed as usual.
+// Combine it with Alias to keep handlers working on the new key during the migration.
+//
+// Example:
+//
+//	t := &TaGo{Name: "gorm2"}
+//	t.Alias("eager", "preload").Deprecate("eager", "use preload instead")
+//	t.OnWarning(func(w tago.Warning) { log.Println(w) })
+//	t.GetNested(&User{}, ".") // main.User Address: eager=true: deprecated instruction key "eager", use preload instead
+func (t *TaGo) Deprecate(key string, hint string) *TaGo {
+	t.configChanged()
+	if t.deprecated == nil {
+		t.deprecated = make(map[string]string)
+	}
+	t.deprecated[key] = hint
+	return t
+}
+
+// Report a warning to the registered callback and logger, if any
+func (t TaGo) warn(w Warning) {
+	t.logWarning(w)
+	if t.onWarning != nil {
+		t.onWarning(w)
+	}
+}
+
+// Raise a warning if the key is deprecated
+func (t TaGo) warnDeprecated(key string, instruction Instruction, owner reflect.Type, path FieldName) {
+	hint, deprecated := t.deprecated[key]
+	if !deprecated {
+		return
+	}
+
+	message := fmt.Sprintf("deprecated instruction key %q", key)
+	if hint != "" {
+		message += ", " + hint
+	}
+	t.warn(Warning{Type: owner, Field: path, Instruction: instruction, Message: message})
+}
@@ -0,0 +1,72 @@
+package tago
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Watchers registered with Watch, by model type
+type watcherSet struct {
+	mu       sync.RWMutex
+	watchers map[reflect.Type][]watcher
+}
+
+type watcher struct {
+	instruction Instruction
+	fn          func(ctx FieldContext)
+}
+
+// Watch registers fn to be called by Emit for every field of the model type carrying the instruction,
+// nested fields included, a lightweight way to trigger side effects (cache invalidation, indexing, ..)
+// for fields declared `searchable=true`. model can be any value of the type, pointers are dereferenced.
+// Watchers are shared by the copies of the TaGo made after the first call to Watch.
+//
+// Example:
+//
+//	t.Watch(&Product{}, "searchable=true", func(ctx FieldContext) {
+//		index.Update(ctx.Path, ctx.Value.Interface())
+//	})
+//	t.Emit(&product) // after each update
+func (t *TaGo) Watch(model any, instruction Instruction, fn func(ctx FieldContext)) *TaGo {
+	if model == nil || fn == nil {
+		return t
+	}
+	if t.watchers == nil {
+		t.watchers = &watcherSet{watchers: make(map[reflect.Type][]watcher)}
+	}
+
+	typ := typeToElem(reflect.TypeOf(model))
+	t.watchers.mu.Lock()
+	defer t.watchers.mu.Unlock()
+	t.watchers.watchers[typ] = append(t.watchers.watchers[typ], watcher{instruction: t.normalizeInstruction(instruction), fn: fn})
+	return t
+}
+
+// Emit calls the watchers of the model type (see Watch) with the current values of the fields carrying their
+// instruction, in field discovery order then registration order. It returns the number of calls.
+func (t TaGo) Emit(model any) int {
+	if t.watchers == nil || model == nil {
+		return 0
+	}
+
+	t.watchers.mu.RLock()
+	watchers := t.watchers.watchers[typeToElem(reflect.TypeOf(model))]
+	t.watchers.mu.RUnlock()
+	if len(watchers) == 0 {
+		return 0
+	}
+
+	calls := 0
+	t.walkContexts(model, newOptions(".", -1, nil), func(ctx *FieldContext) {
+		instructions := ctx.Instructions
+		for _, w := range watchers {
+			if containsInstruction(instructions, w.instruction) {
+				call := *ctx
+				call.Instruction = w.instruction
+				w.fn(call)
+				calls++
+			}
+		}
+	})
+	return calls
+}